@@ -10,14 +10,17 @@ import (
 	"gorm.io/gorm"
 	"net"
 	"orderin-server/internal/components"
+	"orderin-server/internal/migrations"
 	"orderin-server/internal/models"
 	"orderin-server/internal/routers"
+	"orderin-server/internal/seeds"
 	"orderin-server/internal/services"
 	"orderin-server/pkg/common/cache"
 	"orderin-server/pkg/common/cmd"
 	"orderin-server/pkg/common/config"
 	"orderin-server/pkg/common/db/relation"
 	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/prommetrics"
 	"orderin-server/pkg/common/utils"
 	"orderin-server/pkg/common/weixin"
 	"os"
@@ -71,10 +74,15 @@ func run(port int, proPort int) error {
 		return err
 	}
 	components.RegisterComponents(db, rdb)
+	components.RegisterOperationLogWriter(db)
+	components.RegisterEntityHistory(db)
+	if config.Get().Prometheus.Enable {
+		prommetrics.Serve(proPort, db, rdb)
+	}
 
 	var address string
-	if config.Config.AdminApi.ListenIP != "" {
-		address = net.JoinHostPort(config.Config.AdminApi.ListenIP, strconv.Itoa(port))
+	if config.Get().AdminApi.ListenIP != "" {
+		address = net.JoinHostPort(config.Get().AdminApi.ListenIP, strconv.Itoa(port))
 	} else {
 		address = net.JoinHostPort("0.0.0.0", strconv.Itoa(port))
 	}
@@ -91,21 +99,28 @@ func run(port int, proPort int) error {
 }
 
 func initDatabase(db *gorm.DB) error {
-	//创建表
-	if err := db.AutoMigrate(&models.SysUser{}, &models.SysRole{}, &models.SysPermission{}, &models.SysRoleUser{},
-		&models.SysRolePermission{}, &models.SysConfig{}, &models.SysConfigLog{}, &models.SysDict{}, &models.SysDictItem{}, &models.SysSmsLog{},
-		&models.WxAuthorizer{}, &models.WxAuthorizerMedia{}); err != nil {
+	//建表和初始化数据都在主库上进行
+	db = relation.Primary(db)
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
 		return err
 	}
-	//创建索引
-	if !db.Migrator().HasIndex(&models.SysRoleUser{}, "uni_role_id_user_id") {
-		db.Migrator().CreateIndex(&models.SysRoleUser{}, "uni_role_id_user_id")
-	}
-	if !db.Migrator().HasIndex(&models.SysRolePermission{}, "uni_role_id_permission_id") {
-		db.Migrator().CreateIndex(&models.SysRolePermission{}, "uni_role_id_permission_id")
+	if config.Get().Mysql.AutoMigrate {
+		//表结构只由迁移维护，修改模型时需新增迁移
+		if _, err = migrator.Up(context.Background(), 0); err != nil {
+			return err
+		}
+	} else {
+		pending, err := migrator.Pending()
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migration(s), run orderin migrate up first", len(pending))
+		}
 	}
 	initSuperAdmin(db)
-	err := initApiPermissions(db)
+	err = initApiPermissions(db)
 	if err != nil {
 		log.ZError(context.Background(), "init api permissions occur error", err)
 	}
@@ -119,21 +134,21 @@ func initSuperAdmin(db *gorm.DB) {
 	s.Context = context.Background()
 	s.Orm = db
 
-	username := config.Config.SuperAdmin.Username
-	password := config.Config.SuperAdmin.Password
+	username := config.Get().SuperAdmin.Username
+	password := config.Get().SuperAdmin.Password
 	user, err := s.GetByUserName(username)
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
 		log.ZInfo(context.Background(), "super admin isn't exist in db")
 		user = &models.SysUser{
 			Username:     username,
-			RealName:     config.Config.SuperAdmin.RealName,
-			Phone:        config.Config.SuperAdmin.Phone,
+			RealName:     config.Get().SuperAdmin.RealName,
+			Phone:        config.Get().SuperAdmin.Phone,
 			PasswordSalt: utils.Md5(password),
 			SuperAdmin:   true,
 			Status:       1,
 		}
 		s.Insert(user)
-		log.ZInfo(context.Background(), "success to create super admin", "username", username, "password", password)
+		log.ZInfo(context.Background(), "success to create super admin", "username", username)
 	} else {
 		user.PasswordSalt = utils.Md5(password)
 		s.UpdateById(user.ID, *user)
@@ -234,16 +249,24 @@ func initApiPermissions(db *gorm.DB) error {
 	return nil
 }
 
+// initDict 按种子文件同步字典，只新增缺少的字典和字典项，已被管理员修改或删除的保持不变
 func initDict(db *gorm.DB) {
+	ctx := context.Background()
 	s := &services.SysDict{}
-	s.Context = context.Background()
+	s.Context = ctx
 	s.Orm = db
-	initSysDict(s)
-}
-
-func initSysDict(s *services.SysDict) {
-	s.BatchAddDictAndItems("sys_user_status", "系统用户状态", []string{"正常", "禁用"}, []string{"1", "2"})
-	s.BatchAddDictAndItems("sys_config_value_type", "配置数据类型", []string{"json", "bool", "number", "string"}, []string{"1", "2", "3", "4"})
-	s.BatchAddDictAndItems("sys_sms_log_status", "短信发送状态", []string{"失败", "成功"}, []string{"1", "2"})
-	s.BatchAddDictAndItems("sys_sms_log_template_code", "短信模板代码", []string{"验证码"}, []string{"SMS_296350564"})
+	bundle, err := services.DecodeDictBundle(seeds.Dicts, services.DictFormatYAML)
+	if err != nil {
+		log.ZError(ctx, "parse dict seeds occur error", err)
+		return
+	}
+	resp, err := s.ImportDicts(bundle, false, false)
+	if err != nil {
+		log.ZError(ctx, "init dict occur error", err)
+		return
+	}
+	for _, conflict := range resp.Conflicts {
+		log.ZInfo(ctx, "dict seed differs from database, keep database", "dictName", conflict.DictName, "itemValue", conflict.ItemValue, "reason", conflict.Reason)
+	}
+	log.ZInfo(ctx, "init dict finished", "dictCreated", resp.DictCreated, "itemCreated", resp.ItemCreated, "conflicts", len(resp.Conflicts))
 }
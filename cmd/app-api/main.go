@@ -11,6 +11,7 @@ import (
 	"orderin-server/pkg/common/config"
 	"orderin-server/pkg/common/db/relation"
 	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/prommetrics"
 	"strconv"
 )
 
@@ -44,12 +45,16 @@ func run(port int, proPort int) error {
 		return err
 	}
 	components.RegisterComponents(db, rdb)
+	components.RegisterEntityHistory(db)
+	if config.Get().Prometheus.Enable {
+		prommetrics.Serve(proPort, db, rdb)
+	}
 
 	router := routers.NewH5GinRouter(db, rdb)
 	log.ZInfo(context.Background(), "app-api init routers success")
 	var address string
-	if config.Config.AdminApi.ListenIP != "" {
-		address = net.JoinHostPort(config.Config.AdminApi.ListenIP, strconv.Itoa(port))
+	if config.Get().AdminApi.ListenIP != "" {
+		address = net.JoinHostPort(config.Get().AdminApi.ListenIP, strconv.Itoa(port))
 	} else {
 		address = net.JoinHostPort("0.0.0.0", strconv.Itoa(port))
 	}
@@ -6,6 +6,10 @@ import (
 	"github.com/spf13/cobra"
 	admin "orderin-server/cmd/admin-api"
 	app "orderin-server/cmd/app-api"
+	configcmd "orderin-server/cmd/config"
+	"orderin-server/cmd/gen"
+	loglevel "orderin-server/cmd/log-level"
+	"orderin-server/cmd/migrate"
 	"orderin-server/pkg/common/utils"
 	"os"
 )
@@ -36,6 +40,10 @@ func tip() {
 func init() {
 	rootCmd.AddCommand(&admin.AdminApiCmd.Command)
 	rootCmd.AddCommand(&app.AppApiCmd.Command)
+	rootCmd.AddCommand(&loglevel.LogLevelCmd.Command)
+	rootCmd.AddCommand(configcmd.ConfigCmd)
+	rootCmd.AddCommand(gen.GenCmd)
+	rootCmd.AddCommand(migrate.MigrateCmd)
 }
 
 func Execute() {
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"io"
+	"orderin-server/pkg/common/cmd"
+	conf "orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/constant"
+	"orderin-server/pkg/common/log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ConfigCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Inspect configuration",
+	}
+	printCmd = &cobra.Command{
+		Use:     "print",
+		Short:   "Print the effective config merged from files, env and flags",
+		Example: "orderin config print -c config/ --profile prod --redacted",
+		RunE:    runPrint,
+	}
+	encryptCmd = &cobra.Command{
+		Use:     "encrypt [value]",
+		Short:   "Encrypt a config value with the master key, reads stdin when value is omitted",
+		Example: "ORDERIN_MASTER_KEY_FILE=/etc/orderin/master.key orderin config encrypt 'my-password'",
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    runEncrypt,
+	}
+	reEncryptCmd = &cobra.Command{
+		Use:     "re-encrypt",
+		Short:   "Re-encrypt all ENC(...) values in config files under a new master key",
+		Example: "ORDERIN_MASTER_KEY_FILE=old.key orderin config re-encrypt -c config/ --new-key-file new.key",
+		RunE:    runReEncrypt,
+	}
+	genKeyCmd = &cobra.Command{
+		Use:   "gen-key",
+		Short: "Generate a random master key",
+		RunE:  runGenKey,
+	}
+)
+
+func init() {
+	cmd.AddConfFlags(printCmd)
+	printCmd.Flags().Bool("redacted", false, "mask secrets in output")
+	reEncryptCmd.Flags().StringP(constant.FlagConf, "c", "", "path to config file folder")
+	reEncryptCmd.Flags().String("new-key-file", "", "file containing the new master key")
+	_ = reEncryptCmd.MarkFlagRequired("new-key-file")
+	ConfigCmd.AddCommand(printCmd, encryptCmd, reEncryptCmd, genKeyCmd)
+}
+
+func runPrint(command *cobra.Command, args []string) error {
+	configFolderPath, opts := cmd.ConfOptionsFromFlags(command)
+	if err := conf.Load(configFolderPath, opts...); err != nil {
+		return err
+	}
+	var out interface{} = conf.Get()
+	if redacted, _ := command.Flags().GetBool("redacted"); redacted {
+		out = log.Redact(conf.Get())
+	}
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(out); err != nil {
+		return err
+	}
+	// 配置不合法时仍输出，便于排查
+	return conf.Validate()
+}
+
+func masterKey() (string, error) {
+	key, err := conf.MasterKey()
+	if err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", fmt.Errorf("master key is required, set %s or %s", conf.EnvMasterKey, conf.EnvMasterKeyFile)
+	}
+	return key, nil
+}
+
+func runEncrypt(command *cobra.Command, args []string) error {
+	key, err := masterKey()
+	if err != nil {
+		return err
+	}
+	var value string
+	if len(args) > 0 {
+		value = args[0]
+	} else {
+		// 从标准输入读取，避免明文出现在 shell 历史中
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	}
+	encrypted, err := conf.Encrypt(value, key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(encrypted)
+	return nil
+}
+
+func runReEncrypt(command *cobra.Command, args []string) error {
+	oldKey, err := masterKey()
+	if err != nil {
+		return err
+	}
+	newKeyFile, _ := command.Flags().GetString("new-key-file")
+	newKey, err := conf.ReadKeyFile(newKeyFile)
+	if err != nil {
+		return err
+	}
+	configFolderPath, _ := command.Flags().GetString(constant.FlagConf)
+	if configFolderPath == "" {
+		configFolderPath = conf.GetDefaultConfigPath()
+	}
+	files, err := filepath.Glob(filepath.Join(configFolderPath, "config*.yaml"))
+	if err != nil {
+		return err
+	}
+	// 先全部在内存中处理，任一文件解密失败则不改动任何文件
+	contents := make([]string, len(files))
+	counts := make([]int, len(files))
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if contents[i], counts[i], err = conf.ReEncrypt(string(data), oldKey, newKey); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	for i, file := range files {
+		if counts[i] == 0 {
+			continue
+		}
+		if err = writeFile(file, contents[i]); err != nil {
+			return err
+		}
+		fmt.Printf("%s: %d value(s) re-encrypted\n", file, counts[i])
+	}
+	fmt.Printf("done, switch %s to the new key and restart the servers\n", conf.EnvMasterKey)
+	return nil
+}
+
+// writeFile 先写临时文件再 rename，避免热加载读到写了一半的文件
+func writeFile(path, content string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, []byte(content), info.Mode().Perm()); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func runGenKey(command *cobra.Command, args []string) error {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	fmt.Println(base64.StdEncoding.EncodeToString(key))
+	return nil
+}
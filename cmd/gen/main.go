@@ -0,0 +1,93 @@
+package gen
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"orderin-server/internal/services"
+	"orderin-server/pkg/common/clientconst"
+	"orderin-server/pkg/common/cmd"
+	conf "orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/db/relation"
+	"os"
+	"path/filepath"
+)
+
+var (
+	GenCmd = &cobra.Command{
+		Use:   "gen",
+		Short: "Generate code",
+	}
+	clientConstantsCmd = &cobra.Command{
+		Use:     "client-constants",
+		Short:   "Generate TypeScript/Go enums from dictionaries and error codes",
+		Example: "orderin gen client-constants -c config/ --ts web/src/constants/generated.ts --go pkg/constants/generated.go",
+		RunE:    runClientConstants,
+	}
+)
+
+func init() {
+	cmd.AddConfFlags(clientConstantsCmd)
+	clientConstantsCmd.Flags().String("ts", "", "output TypeScript file")
+	clientConstantsCmd.Flags().String("go", "", "output Go file")
+	clientConstantsCmd.Flags().String("go-package", "", "package name of the Go file, defaults to the output directory name")
+	clientConstantsCmd.Flags().StringSlice("names", nil, "dictionary names, defaults to all dictionaries")
+	GenCmd.AddCommand(clientConstantsCmd)
+}
+
+func runClientConstants(command *cobra.Command, args []string) error {
+	tsFile, _ := command.Flags().GetString("ts")
+	goFile, _ := command.Flags().GetString("go")
+	goPackage, _ := command.Flags().GetString("go-package")
+	names, _ := command.Flags().GetStringSlice("names")
+	if tsFile == "" && goFile == "" {
+		return fmt.Errorf("at least one of --ts and --go is required")
+	}
+
+	configFolderPath, opts := cmd.ConfOptionsFromFlags(command)
+	if err := conf.InitConfig(configFolderPath, opts...); err != nil {
+		return err
+	}
+	db, err := relation.NewGormDB()
+	if err != nil {
+		return err
+	}
+	s := services.SysDict{}
+	s.Orm = db
+	s.Context = context.Background()
+	constants, err := s.ClientConstants(names)
+	if err != nil {
+		return err
+	}
+
+	if tsFile != "" {
+		if err = writeFile(tsFile, clientconst.TypeScript(constants)); err != nil {
+			return err
+		}
+	}
+	if goFile != "" {
+		if goPackage == "" {
+			goPackage = filepath.Base(filepath.Dir(goFile))
+		}
+		code, err := clientconst.Go(constants, goPackage)
+		if err != nil {
+			return err
+		}
+		if err = writeFile(goFile, code); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%d enum(s) and %d error code(s) generated\n", len(constants.Enums), len(constants.ErrorCodes))
+	return nil
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Println("written", path)
+	return nil
+}
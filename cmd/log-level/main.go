@@ -0,0 +1,53 @@
+package log_level
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	"orderin-server/internal/components"
+	"orderin-server/pkg/common/cache"
+	"orderin-server/pkg/common/cmd"
+	"orderin-server/pkg/common/log"
+	"time"
+)
+
+var (
+	LogLevelCmd = cmd.NewRootCmd("log-level")
+)
+
+func init() {
+	LogLevelCmd.Command.Short = "Change log level of all running instances"
+	LogLevelCmd.Command.Example = "orderin log-level -c config/ --module sql --level debug --ttl 10m"
+	LogLevelCmd.Command.Flags().StringP("module", "m", log.ModuleRoot, "logger module: root|sql|http|wechat")
+	LogLevelCmd.Command.Flags().StringP("level", "l", "", "log level: debug|info|warn|error")
+	LogLevelCmd.Command.Flags().Duration("ttl", 0, "revert to the configured level after ttl, 0 means never")
+	LogLevelCmd.Command.Flags().Bool("reset", false, "revert to the configured level now")
+	LogLevelCmd.Command.RunE = run
+}
+
+func run(command *cobra.Command, args []string) error {
+	module, _ := command.Flags().GetString("module")
+	level, _ := command.Flags().GetString("level")
+	ttl, _ := command.Flags().GetDuration("ttl")
+	reset, _ := command.Flags().GetBool("reset")
+	if !reset && level == "" {
+		return fmt.Errorf("level is required")
+	}
+
+	rdb, err := cache.NewRedis()
+	if err != nil {
+		log.ZError(context.Background(), "Failed to initialize Redis", err)
+		return err
+	}
+	err = components.PublishLogLevel(rdb, components.LogLevelMessage{
+		Module: module,
+		Level:  level,
+		Ttl:    int64(ttl / time.Second),
+		Reset:  reset,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("log level change has been broadcast")
+	return nil
+}
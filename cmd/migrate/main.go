@@ -0,0 +1,207 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+	"orderin-server/internal/migrations"
+	"orderin-server/pkg/common/cmd"
+	conf "orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/db/migrate"
+	"orderin-server/pkg/common/db/relation"
+)
+
+var (
+	MigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Versioned database schema migrations",
+	}
+	upCmd = &cobra.Command{
+		Use:     "up",
+		Short:   "Apply pending migrations",
+		Example: "orderin migrate up -c config/ --profile prod",
+		RunE:    runUp,
+	}
+	downCmd = &cobra.Command{
+		Use:     "down",
+		Short:   "Roll back the latest applied migrations",
+		Example: "orderin migrate down -c config/ --steps 1",
+		RunE:    runDown,
+	}
+	statusCmd = &cobra.Command{
+		Use:     "status",
+		Short:   "Show applied and pending migrations",
+		Example: "orderin migrate status -c config/",
+		RunE:    runStatus,
+	}
+	createCmd = &cobra.Command{
+		Use:     "create <name>",
+		Short:   "Create up/down SQL files, or a Go migration with --go",
+		Example: "orderin migrate create add_user_email",
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCreate,
+	}
+)
+
+var nameRegexp = regexp.MustCompile(`^\w+$`)
+
+func init() {
+	cmd.AddConfFlags(upCmd)
+	upCmd.Flags().Int("steps", 0, "number of migrations to apply, 0 applies all")
+	cmd.AddConfFlags(downCmd)
+	downCmd.Flags().Int("steps", 1, "number of migrations to roll back")
+	cmd.AddConfFlags(statusCmd)
+	createCmd.Flags().Bool("go", false, "create a Go migration instead of SQL files")
+	createCmd.Flags().String("dir", "", "output directory, defaults to "+migrations.SqlDir+" or its parent for --go")
+	MigrateCmd.AddCommand(upCmd, downCmd, statusCmd, createCmd)
+}
+
+func newMigrator(command *cobra.Command) (*migrate.Migrator, error) {
+	configFolderPath, opts := cmd.ConfOptionsFromFlags(command)
+	if err := conf.InitConfig(configFolderPath, opts...); err != nil {
+		return nil, err
+	}
+	db, err := relation.NewGormDB()
+	if err != nil {
+		return nil, err
+	}
+	return migrations.NewMigrator(db)
+}
+
+func runUp(command *cobra.Command, args []string) error {
+	steps, _ := command.Flags().GetInt("steps")
+	m, err := newMigrator(command)
+	if err != nil {
+		return err
+	}
+	done, err := m.Up(context.Background(), steps)
+	for _, migration := range done {
+		fmt.Printf("applied %s_%s\n", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(done) == 0 {
+		fmt.Println("no pending migrations")
+	}
+	return nil
+}
+
+func runDown(command *cobra.Command, args []string) error {
+	steps, _ := command.Flags().GetInt("steps")
+	if steps < 1 {
+		return fmt.Errorf("--steps must be at least 1")
+	}
+	m, err := newMigrator(command)
+	if err != nil {
+		return err
+	}
+	done, err := m.Down(context.Background(), steps)
+	for _, migration := range done {
+		fmt.Printf("rolled back %s_%s\n", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(done) == 0 {
+		fmt.Println("no applied migrations")
+	}
+	return nil
+}
+
+func runStatus(command *cobra.Command, args []string) error {
+	m, err := newMigrator(command)
+	if err != nil {
+		return err
+	}
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, s := range statuses {
+		status := "pending"
+		if s.AppliedAt != nil {
+			status = "applied at " + s.AppliedAt.Format(time.DateTime)
+		}
+		if s.Missing {
+			status += " (missing in code)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.Version, s.Name, status)
+	}
+	return w.Flush()
+}
+
+var goTemplate = template.Must(template.New("migration").Parse(`package migrations
+
+import (
+	"gorm.io/gorm"
+	"orderin-server/pkg/common/db/migrate"
+)
+
+func init() {
+	register(migrate.Migration{
+		Version: "{{.Version}}",
+		Name:    "{{.Name}}",
+		Up: func(tx *gorm.DB) error {
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	})
+}
+`))
+
+func runCreate(command *cobra.Command, args []string) error {
+	name := args[0]
+	if !nameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid migration name %q, only letters, digits and _ are allowed", name)
+	}
+	goMigration, _ := command.Flags().GetBool("go")
+	dir, _ := command.Flags().GetString("dir")
+	if dir == "" {
+		dir = migrations.SqlDir
+		if goMigration {
+			dir = filepath.Dir(migrations.SqlDir)
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	version := time.Now().Format("20060102150405")
+	var files []string
+	if goMigration {
+		file := filepath.Join(dir, version+"_"+name+".go")
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err = goTemplate.Execute(f, map[string]string{"Version": version, "Name": name}); err != nil {
+			return err
+		}
+		files = append(files, file)
+	} else {
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(dir, fmt.Sprintf("%s_%s.%s.sql", version, name, direction))
+			content := fmt.Sprintf("-- %s %s\n", name, direction)
+			if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+	}
+	for _, file := range files {
+		fmt.Println("created", file)
+	}
+	return nil
+}
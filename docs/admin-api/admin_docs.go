@@ -275,14 +275,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/config/page_query": {
+        "/sys/config/diff": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "分页查询配置",
+                "description": "比较配置的两个版本，版本为某条配置日志修改后的值，日志id为0表示当前值",
                 "consumes": [
                     "application/json"
                 ],
@@ -292,14 +292,15 @@ const docTemplateadmin = `{
                 "tags": [
                     "配置管理"
                 ],
-                "summary": "分页查询配置",
+                "summary": "比较配置版本",
                 "parameters": [
                     {
-                        "description": "配置筛选条件",
+                        "description": "配置id及两个版本的日志id",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysConfigPageQueryReq"
+                            "$ref": "#/definitions/dto.SysConfigDiffReq"
                         }
                     }
                 ],
@@ -315,19 +316,7 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/api.PageData"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "List": {
-                                                            "$ref": "#/definitions/models.SysConfig"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/dto.SysConfigDiffResp"
                                         }
                                     }
                                 }
@@ -337,14 +326,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/config/page_query_log": {
+        "/sys/config/export": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "分页查询配置日志",
+                "description": "导出配置快照，用于导入到其他环境",
                 "consumes": [
                     "application/json"
                 ],
@@ -354,14 +343,14 @@ const docTemplateadmin = `{
                 "tags": [
                     "配置管理"
                 ],
-                "summary": "分页查询配置日志",
+                "summary": "导出配置",
                 "parameters": [
                     {
-                        "description": "配置日志筛选条件",
+                        "description": "要导出的配置名称，为空时导出全部",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysConfigLogPageQueryReq"
+                            "$ref": "#/definitions/dto.SysConfigExportReq"
                         }
                     }
                 ],
@@ -377,19 +366,7 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/api.PageData"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "List": {
-                                                            "$ref": "#/definitions/models.SysConfigLog"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/dto.SysConfigSnapshot"
                                         }
                                     }
                                 }
@@ -399,14 +376,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/config/update": {
+        "/sys/config/get_definitions": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据id修改配置信息",
+                "description": "查询代码中注册的配置定义、缺省值、校验规则及当前值",
                 "consumes": [
                     "application/json"
                 ],
@@ -416,35 +393,40 @@ const docTemplateadmin = `{
                 "tags": [
                     "配置管理"
                 ],
-                "summary": "修改配置",
-                "parameters": [
-                    {
-                        "description": "配置信息",
-                        "name": "param",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SysConfigUpdateReq"
-                        }
-                    }
-                ],
+                "summary": "查询配置定义",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SysConfigDefinitionResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/dict/add": {
+        "/sys/config/get_versions": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "添加字典",
+                "description": "查询当前配置版本号及各实例已加载的版本",
                 "consumes": [
                     "application/json"
                 ],
@@ -452,37 +434,39 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
-                ],
-                "summary": "添加字典",
-                "parameters": [
-                    {
-                        "description": "字典信息",
-                        "name": "param",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SysDictAddReq"
-                        }
-                    }
+                    "配置管理"
                 ],
+                "summary": "查询各实例配置版本",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysConfigVersionResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/dict/delete": {
+        "/sys/config/import": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据id删除字典",
+                "description": "导入配置快照，不存在的新增，有变化的更新，快照中没有的配置保持不变；dryRun为true时只返回将要发生的变更",
                 "consumes": [
                     "application/json"
                 ],
@@ -490,16 +474,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "配置管理"
                 ],
-                "summary": "删除字典",
+                "summary": "导入配置",
                 "parameters": [
                     {
-                        "description": "要删除的字典ID",
+                        "description": "配置快照",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysDictDeleteReq"
+                            "$ref": "#/definitions/dto.SysConfigImportReq"
                         }
                     }
                 ],
@@ -507,20 +492,32 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysConfigImportResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/dict/item/add": {
+        "/sys/config/page_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "添加字典项",
+                "description": "分页查询配置",
                 "consumes": [
                     "application/json"
                 ],
@@ -528,17 +525,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "配置管理"
                 ],
-                "summary": "添加字典项",
+                "summary": "分页查询配置",
                 "parameters": [
                     {
-                        "description": "字典项",
+                        "description": "配置筛选条件",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysDictItemAddReq"
+                            "$ref": "#/definitions/dto.SysConfigPageQueryReq"
                         }
                     }
                 ],
@@ -546,20 +542,44 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysConfig"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/dict/item/batch_get_by_names": {
+        "/sys/config/page_query_log": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据字典名称数组获取所有项目",
+                "description": "分页查询配置日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -567,17 +587,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "配置管理"
                 ],
-                "summary": "根据字典名称数组获取所有项目",
+                "summary": "分页查询配置日志",
                 "parameters": [
                     {
-                        "description": "字典名称数组",
+                        "description": "配置日志筛选条件",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysDictGetItemsReq"
+                            "$ref": "#/definitions/dto.SysConfigLogPageQueryReq"
                         }
                     }
                 ],
@@ -593,13 +612,19 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "array",
-                                                "items": {
-                                                    "$ref": "#/definitions/models.SysDictItem"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysConfigLog"
+                                                        }
+                                                    }
                                                 }
-                                            }
+                                            ]
                                         }
                                     }
                                 }
@@ -609,14 +634,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/dict/item/get_by_name": {
+        "/sys/config/rollback": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据字典名称获取所有项目",
+                "description": "把配置回滚到某条配置日志修改前或修改后的值，并记录一条新的配置日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -624,17 +649,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "配置管理"
                 ],
-                "summary": "根据字典名称获取所有项目",
+                "summary": "回滚配置",
                 "parameters": [
                     {
-                        "description": "字典名称",
+                        "description": "回滚目标",
                         "name": "param",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysDictGetItemsReq"
+                            "$ref": "#/definitions/dto.SysConfigRollbackReq"
                         }
                     }
                 ],
@@ -642,32 +667,20 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.SysDictGetItemsResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/dict/item/page_query": {
+        "/sys/config/update": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "分页查询字典",
+                "description": "根据id修改配置信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -675,16 +688,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "配置管理"
                 ],
-                "summary": "分页查询字典",
+                "summary": "修改配置",
                 "parameters": [
                     {
-                        "description": "字典筛选条件",
+                        "description": "配置信息",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysDictPageQueryReq"
+                            "$ref": "#/definitions/dto.SysConfigUpdateReq"
                         }
                     }
                 ],
@@ -692,44 +705,20 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/api.PageData"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "List": {
-                                                            "$ref": "#/definitions/models.SysDict"
-                                                        }
-                                                    }
-                                                }
-                                            ]
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/dict/item/update": {
+        "/sys/dict/add": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "修改字典项",
+                "description": "添加字典",
                 "consumes": [
                     "application/json"
                 ],
@@ -739,15 +728,14 @@ const docTemplateadmin = `{
                 "tags": [
                     "字典管理"
                 ],
-                "summary": "修改字典项",
+                "summary": "添加字典",
                 "parameters": [
                     {
-                        "description": "字典项",
+                        "description": "字典信息",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysDictItemUpdateReq"
+                            "$ref": "#/definitions/dto.SysDictAddReq"
                         }
                     }
                 ],
@@ -761,31 +749,33 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/dict/page_query": {
+        "/sys/dict/client_constants": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "分页查询字典",
+                "description": "由字典和错误码生成前端枚举，与 orderin gen client-constants 的结果一致，可在构建时校验前端代码中的常量",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/plain"
                 ],
                 "tags": [
                     "字典管理"
                 ],
-                "summary": "分页查询字典",
+                "summary": "获取前端常量",
                 "parameters": [
                     {
-                        "description": "字典筛选条件",
+                        "description": "字典名称及格式",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysDictPageQueryReq"
+                            "$ref": "#/definitions/dto.SysDictClientConstantsReq"
                         }
                     }
                 ],
@@ -801,19 +791,7 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/api.PageData"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "List": {
-                                                            "$ref": "#/definitions/models.SysDict"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/clientconst.Constants"
                                         }
                                     }
                                 }
@@ -823,14 +801,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/dict/update": {
+        "/sys/dict/delete": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据id修改字典信息",
+                "description": "根据id删除字典",
                 "consumes": [
                     "application/json"
                 ],
@@ -840,14 +818,14 @@ const docTemplateadmin = `{
                 "tags": [
                     "字典管理"
                 ],
-                "summary": "修改字典",
+                "summary": "删除字典",
                 "parameters": [
                     {
-                        "description": "字典信息",
+                        "description": "要删除的字典ID",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysDictUpdateReq"
+                            "$ref": "#/definitions/dto.SysDictDeleteReq"
                         }
                     }
                 ],
@@ -861,31 +839,32 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/permission/add": {
+        "/sys/dict/export": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "添加权限",
+                "description": "导出字典及字典项为json、yaml或xlsx文件，可用于环境间迁移或作为种子文件",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "权限管理"
+                    "字典管理"
                 ],
-                "summary": "添加权限",
+                "summary": "导出字典",
                 "parameters": [
                     {
-                        "description": "权限信息",
+                        "description": "要导出的字典名称及格式",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysPermissionAddReq"
+                            "$ref": "#/definitions/dto.SysDictExportReq"
                         }
                     }
                 ],
@@ -893,58 +872,81 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/sys/permission/batch_add": {
+        "/sys/dict/import": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "批量添加权限",
+                "description": "从json、yaml或xlsx文件导入字典，格式由文件扩展名决定。不存在的新增；已存在且内容不同的，upsert时覆盖，否则跳过并返回冲突",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "权限管理"
+                    "字典管理"
                 ],
-                "summary": "批量添加权限",
+                "summary": "导入字典",
                 "parameters": [
                     {
-                        "description": "权限筛选条件",
-                        "name": "param",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SysPermissionBatchAddReq"
-                        }
+                        "type": "file",
+                        "description": "字典文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否覆盖已存在的字典和字典项",
+                        "name": "upsert",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否只预览变更",
+                        "name": "dryRun",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysDictImportResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/permission/batch_import": {
+        "/sys/dict/item/add": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "批量导入权限",
+                "description": "添加字典项",
                 "consumes": [
                     "application/json"
                 ],
@@ -952,16 +954,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "权限管理"
+                    "字典管理"
                 ],
-                "summary": "批量导入权限",
+                "summary": "添加字典项",
                 "parameters": [
                     {
-                        "description": "权限树",
+                        "description": "字典项",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysPermissionImportReq"
+                            "$ref": "#/definitions/dto.SysDictItemAddReq"
                         }
                     }
                 ],
@@ -975,14 +978,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/permission/bind_users": {
+        "/sys/dict/item/batch_get_by_names": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "以树形结构呈现所有权限",
+                "description": "根据字典名称数组获取所有项目",
                 "consumes": [
                     "application/json"
                 ],
@@ -990,9 +993,26 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "权限管理"
+                    "字典管理"
+                ],
+                "summary": "根据字典名称数组获取所有项目",
+                "parameters": [
+                    {
+                        "description": "字典名称数组",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysDictGetItemsReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的ETag，内容未变化时返回304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
                 ],
-                "summary": "获取权限树",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1005,27 +1025,33 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.SysPermissionTree"
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "array",
+                                                "items": {
+                                                    "$ref": "#/definitions/models.SysDictItem"
+                                                }
                                             }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "304": {
+                        "description": "内容未变化"
                     }
                 }
             }
         },
-        "/sys/permission/delete": {
+        "/sys/dict/item/get_by_name": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据id删除权限",
+                "description": "根据字典名称获取所有项目",
                 "consumes": [
                     "application/json"
                 ],
@@ -1033,37 +1059,59 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "权限管理"
+                    "字典管理"
                 ],
-                "summary": "删除权限",
+                "summary": "根据字典名称获取所有项目",
                 "parameters": [
                     {
-                        "description": "要删除的权限ID",
+                        "description": "字典名称",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysPermissionDeleteReq"
+                            "$ref": "#/definitions/dto.SysDictGetItemsReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的ETag，内容未变化时返回304",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysDictGetItemsResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
+                    },
+                    "304": {
+                        "description": "内容未变化"
                     }
                 }
             }
         },
-        "/sys/permission/page_query": {
+        "/sys/dict/item/get_path": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "分页查询权限",
+                "description": "根据值获取从顶级到该字典项的所有字典项",
                 "consumes": [
                     "application/json"
                 ],
@@ -1071,16 +1119,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "权限管理"
+                    "字典管理"
                 ],
-                "summary": "分页查询权限",
+                "summary": "获取字典项的完整路径",
                 "parameters": [
                     {
-                        "description": "权限筛选条件",
+                        "description": "字典名称及字典项的值",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysPermissionPageQueryReq"
+                            "$ref": "#/definitions/dto.SysDictItemPathReq"
                         }
                     }
                 ],
@@ -1096,19 +1145,10 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/api.PageData"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "List": {
-                                                            "$ref": "#/definitions/models.SysPermission"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SysDictItem"
+                                            }
                                         }
                                     }
                                 }
@@ -1118,14 +1158,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/permission/update": {
+        "/sys/dict/item/get_tree": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据id修改权限信息",
+                "description": "获取字典项树，包括已禁用的字典项，可指定从某个字典项的下级开始及返回的层数",
                 "consumes": [
                     "application/json"
                 ],
@@ -1133,16 +1173,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "权限管理"
+                    "字典管理"
                 ],
-                "summary": "修改权限",
+                "summary": "获取字典项树",
                 "parameters": [
                     {
-                        "description": "权限信息",
+                        "description": "字典名称",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysPermissionUpdateReq"
+                            "$ref": "#/definitions/dto.SysDictItemTreeReq"
                         }
                     }
                 ],
@@ -1150,20 +1191,35 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SysDictItemTree"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/role/add": {
+        "/sys/dict/item/page_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "添加角色",
+                "description": "分页查询字典项，tree为true时按树返回，list为dto.SysDictItemTree",
                 "consumes": [
                     "application/json"
                 ],
@@ -1171,16 +1227,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "字典管理"
                 ],
-                "summary": "添加角色",
+                "summary": "分页查询字典项",
                 "parameters": [
                     {
-                        "description": "角色信息",
+                        "description": "字典项筛选条件",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysRoleAddReq"
+                            "$ref": "#/definitions/dto.SysDictItemPageQueryReq"
                         }
                     }
                 ],
@@ -1188,20 +1244,44 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysDictItem"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/role/bind_permissions": {
+        "/sys/dict/item/update": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "批量关联权限给角色，会覆盖之前角色拥有的权限",
+                "description": "修改字典项",
                 "consumes": [
                     "application/json"
                 ],
@@ -1209,17 +1289,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "字典管理"
                 ],
-                "summary": "给角色授予权限",
+                "summary": "修改字典项",
                 "parameters": [
                     {
-                        "description": "角色id和权限id数组",
+                        "description": "字典项",
                         "name": "param",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysRoleBindPermissionsReq"
+                            "$ref": "#/definitions/dto.SysDictItemUpdateReq"
                         }
                     }
                 ],
@@ -1233,14 +1313,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/role/bind_users": {
+        "/sys/dict/page_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "角色关联用户，关联后用户拥有该角色和该角色的所有权限",
+                "description": "分页查询字典",
                 "consumes": [
                     "application/json"
                 ],
@@ -1248,17 +1328,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "字典管理"
                 ],
-                "summary": "授权角色给用户",
+                "summary": "分页查询字典",
                 "parameters": [
                     {
-                        "description": "需要绑定的角色id和用户id数组",
+                        "description": "字典筛选条件",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysRoleBindPermissionsReq"
+                            "$ref": "#/definitions/dto.SysDictPageQueryReq"
                         }
                     }
                 ],
@@ -1266,58 +1345,44 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/sys/role/delete": {
-            "post": {
-                "security": [
-                    {
-                        "RequireLogin": []
-                    }
-                ],
-                "description": "根据id删除角色",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "角色管理"
-                ],
-                "summary": "删除角色",
-                "parameters": [
-                    {
-                        "description": "要删除的角色ID",
-                        "name": "param",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SysRoleDeleteReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysDict"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/role/get_by_name": {
+        "/sys/dict/update": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据名字获取角色",
+                "description": "根据id修改字典信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -1325,16 +1390,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "字典管理"
                 ],
-                "summary": "根据名字获取角色",
+                "summary": "修改字典",
                 "parameters": [
                     {
-                        "description": "角色名称",
+                        "description": "字典信息",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysRoleGetByNameReq"
+                            "$ref": "#/definitions/dto.SysDictUpdateReq"
                         }
                     }
                 ],
@@ -1348,14 +1413,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/role/get_permission_tree": {
+        "/sys/entity_history/get_timeline": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "获取角色拥有的权限树",
+                "description": "按时间倒序查询任意实体的字段变更时间线，entityType如sys_user、sys_config",
                 "consumes": [
                     "application/json"
                 ],
@@ -1363,17 +1428,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "变更历史"
                 ],
-                "summary": "获取角色拥有的权限树",
+                "summary": "查询实体变更历史",
                 "parameters": [
                     {
-                        "description": "角色id",
+                        "description": "实体类型和实体id",
                         "name": "param",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysRoleGetPermissionTreeReq"
+                            "$ref": "#/definitions/dto.SysEntityHistoryTimelineReq"
                         }
                     }
                 ],
@@ -1389,7 +1454,19 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SysRolePermissionTreeResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysEntityHistory"
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -1399,14 +1476,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/role/page_query": {
+        "/sys/log/get_levels": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "分页查询角色",
+                "description": "查询当前实例各logger的日志级别",
                 "consumes": [
                     "application/json"
                 ],
@@ -1414,19 +1491,9 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
-                ],
-                "summary": "分页查询角色",
-                "parameters": [
-                    {
-                        "description": "角色筛选条件",
-                        "name": "param",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SysRolePageQueryReq"
-                        }
-                    }
+                    "日志管理"
                 ],
+                "summary": "查询日志级别",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1439,19 +1506,10 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/api.PageData"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "List": {
-                                                            "$ref": "#/definitions/models.SysRole"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/log.LevelInfo"
+                                            }
                                         }
                                     }
                                 }
@@ -1461,14 +1519,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/role/unbind_users": {
+        "/sys/log/set_level": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "取消角色关联用户，取消后用户不再拥有该角色",
+                "description": "修改所有实例的日志级别，ttl大于0时到期自动恢复",
                 "consumes": [
                     "application/json"
                 ],
@@ -1476,17 +1534,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "日志管理"
                 ],
-                "summary": "取消授权角色给用户",
+                "summary": "修改日志级别",
                 "parameters": [
                     {
-                        "description": "需要解绑的角色id和用户id数组",
+                        "description": "日志级别",
                         "name": "param",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysRoleUnbindUsersReq"
+                            "$ref": "#/definitions/dto.SysLogSetLevelReq"
                         }
                     }
                 ],
@@ -1500,14 +1558,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/role/update": {
+        "/sys/login_log/cursor_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据id修改角色信息",
+                "description": "按游标分页查询登录日志，适用于数据量大的场景。nextCursor 为空表示没有下一页，count 为 exact 或 estimate 时返回总数",
                 "consumes": [
                     "application/json"
                 ],
@@ -1515,16 +1573,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "登录日志"
                 ],
-                "summary": "修改角色",
+                "summary": "游标分页查询登录日志",
                 "parameters": [
                     {
-                        "description": "角色信息",
+                        "description": "登录日志筛选条件",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysRoleUpdateReq"
+                            "$ref": "#/definitions/dto.SysLoginLogCursorQueryReq"
                         }
                     }
                 ],
@@ -1532,20 +1590,44 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.CursorPageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysLoginLog"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/sms/page_query": {
+        "/sys/login_log/page_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "分页查询短信记录",
+                "description": "分页查询登录日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -1553,16 +1635,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "短信管理"
+                    "登录日志"
                 ],
-                "summary": "分页查询短信记录",
+                "summary": "分页查询登录日志",
                 "parameters": [
                     {
-                        "description": "短信记录筛选条件",
+                        "description": "登录日志筛选条件",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysSmsLogPageQueryReq"
+                            "$ref": "#/definitions/dto.SysLoginLogPageQueryReq"
                         }
                     }
                 ],
@@ -1586,7 +1668,7 @@ const docTemplateadmin = `{
                                                     "type": "object",
                                                     "properties": {
                                                         "List": {
-                                                            "$ref": "#/definitions/models.SysSmsLog"
+                                                            "$ref": "#/definitions/models.SysLoginLog"
                                                         }
                                                     }
                                                 }
@@ -1600,9 +1682,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/sms/send": {
+        "/sys/login_log/page_query_personal": {
             "post": {
-                "description": "发送短信",
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "分页查询当前用户的登录日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -1610,16 +1697,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "短信管理"
+                    "登录日志"
                 ],
-                "summary": "发送短信",
+                "summary": "分页查询我的登录日志",
                 "parameters": [
                     {
-                        "description": "手机号和发送场景",
+                        "description": "登录日志筛选条件",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysSmsSendReq"
+                            "$ref": "#/definitions/dto.SysLoginLogPersonalPageQueryReq"
                         }
                     }
                 ],
@@ -1627,20 +1714,44 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysLoginLog"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/sms/send_to_myself": {
+        "/sys/operation_log/cursor_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "发送短信(登录后)",
+                "description": "按游标分页查询操作日志，适用于数据量大的场景。nextCursor 为空表示没有下一页，count 为 exact 或 estimate 时返回总数",
                 "consumes": [
                     "application/json"
                 ],
@@ -1648,16 +1759,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "短信管理"
+                    "操作日志"
                 ],
-                "summary": "发送短信(登录后)",
+                "summary": "游标分页查询操作日志",
                 "parameters": [
                     {
-                        "description": "发送场景",
+                        "description": "操作日志筛选条件",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysSmsSendToMyselfReq"
+                            "$ref": "#/definitions/dto.SysOperationLogCursorQueryReq"
                         }
                     }
                 ],
@@ -1665,20 +1776,44 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.CursorPageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysOperationLog"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/user/add": {
+        "/sys/operation_log/page_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "添加用户",
+                "description": "分页查询操作日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -1686,17 +1821,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "操作日志"
                 ],
-                "summary": "添加用户",
+                "summary": "分页查询操作日志",
                 "parameters": [
                     {
-                        "description": "用户信息",
+                        "description": "操作日志筛选条件",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserInsertReq"
+                            "$ref": "#/definitions/dto.SysOperationLogPageQueryReq"
                         }
                     }
                 ],
@@ -1712,7 +1846,19 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SysUserAddResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysOperationLog"
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -1722,14 +1868,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/bind_email": {
+        "/sys/permission/add": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "绑定邮箱",
+                "description": "添加权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1737,17 +1883,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限管理"
                 ],
-                "summary": "绑定邮箱",
+                "summary": "添加权限",
                 "parameters": [
                     {
-                        "description": "邮箱和邮箱验证码",
+                        "description": "权限信息",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserBindEmailReq"
+                            "$ref": "#/definitions/dto.SysPermissionAddReq"
                         }
                     }
                 ],
@@ -1761,14 +1906,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/bind_mfa_device": {
+        "/sys/permission/batch_add": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "绑定多因素认证设备",
+                "description": "批量添加权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1776,17 +1921,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限管理"
                 ],
-                "summary": "绑定多因素认证设备",
+                "summary": "批量添加权限",
                 "parameters": [
                     {
-                        "description": "mfa信息",
+                        "description": "权限筛选条件",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserBindMFAReq"
+                            "$ref": "#/definitions/dto.SysPermissionBatchAddReq"
                         }
                     }
                 ],
@@ -1794,32 +1938,20 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.SysUserBindMFAKeyResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/user/bind_phone": {
+        "/sys/permission/batch_import": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "绑定手机",
+                "description": "批量导入权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1827,17 +1959,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限管理"
                 ],
-                "summary": "绑定手机",
+                "summary": "批量导入权限",
                 "parameters": [
                     {
-                        "description": "手机号和短信验证码",
+                        "description": "权限树",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserBindPhoneReq"
+                            "$ref": "#/definitions/dto.SysPermissionImportReq"
                         }
                     }
                 ],
@@ -1851,9 +1982,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/bind_wechat": {
-            "get": {
-                "description": "微信授权重定向",
+        "/sys/permission/bind_users": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "以树形结构呈现所有权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1861,9 +1997,9 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限管理"
                 ],
-                "summary": "绑定微信",
+                "summary": "获取权限树",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1876,7 +2012,10 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SysUserGetOAuth2UrlResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SysPermissionTree"
+                                            }
                                         }
                                     }
                                 }
@@ -1886,14 +2025,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/change_password": {
+        "/sys/permission/delete": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "更新密码（无需旧密码，但是需要先进行身份验证）",
+                "description": "根据id删除权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1901,17 +2040,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限管理"
                 ],
-                "summary": "更新密码（无需旧密码，但是需要先进行身份验证）",
+                "summary": "删除权限",
                 "parameters": [
                     {
-                        "description": "手机号和验证码",
+                        "description": "要删除的权限ID",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserChangePasswordReq"
+                            "$ref": "#/definitions/dto.SysPermissionDeleteReq"
                         }
                     }
                 ],
@@ -1925,14 +2063,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/check_identity": {
+        "/sys/permission/page_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "身份验证",
+                "description": "分页查询权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1940,48 +2078,19 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限管理"
                 ],
-                "summary": "身份验证",
+                "summary": "分页查询权限",
                 "parameters": [
                     {
-                        "description": "验证方式和验证码",
+                        "description": "权限筛选条件",
                         "name": "param",
                         "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SysUserCheckIdentityReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "$ref": "#/definitions/dto.SysPermissionPageQueryReq"
                         }
                     }
-                }
-            }
-        },
-        "/sys/user/generate_mfa_device": {
-            "post": {
-                "security": [
-                    {
-                        "RequireLogin": []
-                    }
-                ],
-                "description": "生成多因素认证密钥",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "用户管理"
                 ],
-                "summary": "生成多因素认证密钥",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1994,7 +2103,19 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SysUserGenerateMFAKeyResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysPermission"
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -2004,14 +2125,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/get_by_id": {
+        "/sys/permission/update": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "根据id获取用户信息",
+                "description": "根据id修改权限信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -2019,17 +2140,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限管理"
                 ],
-                "summary": "根据id获取用户信息",
+                "summary": "修改权限",
                 "parameters": [
                     {
-                        "description": "用户id",
+                        "description": "权限信息",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserGetReq"
+                            "$ref": "#/definitions/dto.SysPermissionUpdateReq"
                         }
                     }
                 ],
@@ -2037,32 +2157,20 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.SysUser"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/user/get_oauth2_url": {
+        "/sys/role/add": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "获取绑定微信的授权链接",
+                "description": "添加角色",
                 "consumes": [
                     "application/json"
                 ],
@@ -2070,39 +2178,37 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
+                ],
+                "summary": "添加角色",
+                "parameters": [
+                    {
+                        "description": "角色信息",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysRoleAddReq"
+                        }
+                    }
                 ],
-                "summary": "获取绑定微信的授权链接",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.SysUserGetOAuth2UrlResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/user/get_personal_info": {
+        "/sys/role/bind_permissions": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "获取个人信息",
+                "description": "批量关联权限给角色，会覆盖之前角色拥有的权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -2110,34 +2216,38 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
+                ],
+                "summary": "给角色授予权限",
+                "parameters": [
+                    {
+                        "description": "角色id和权限id数组",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysRoleBindPermissionsReq"
+                        }
+                    }
                 ],
-                "summary": "获取个人信息",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.SysUserPersonalInfoResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/user/get_wechat_login_result": {
-            "get": {
-                "description": "获取微信登录结果",
+        "/sys/role/bind_users": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "角色关联用户，关联后用户拥有该角色和该角色的所有权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -2145,17 +2255,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
                 ],
-                "summary": "获取微信登录结果",
+                "summary": "授权角色给用户",
                 "parameters": [
                     {
-                        "description": "登录id",
+                        "description": "需要绑定的角色id和用户id数组",
                         "name": "param",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserGetWxLoginResultReq"
+                            "$ref": "#/definitions/dto.SysRoleBindPermissionsReq"
                         }
                     }
                 ],
@@ -2163,32 +2273,20 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.SysUserGetOAuth2UrlResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/user/get_wechat_login_url": {
+        "/sys/role/delete": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "获取微信登录链接",
+                "description": "根据id删除角色",
                 "consumes": [
                     "application/json"
                 ],
@@ -2196,34 +2294,37 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
+                ],
+                "summary": "删除角色",
+                "parameters": [
+                    {
+                        "description": "要删除的角色ID",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysRoleDeleteReq"
+                        }
+                    }
                 ],
-                "summary": "获取微信登录链接",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.SysUserGetWxLoginUrlResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/user/login_by_password": {
+        "/sys/role/get": {
             "post": {
-                "description": "账号密码登录",
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "根据id获取角色",
                 "consumes": [
                     "application/json"
                 ],
@@ -2231,17 +2332,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
                 ],
-                "summary": "账号密码登录",
+                "summary": "获取角色",
                 "parameters": [
                     {
-                        "description": "账号密码",
+                        "description": "角色ID",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserLoginByPasswordReq"
+                            "$ref": "#/definitions/dto.SysRoleGetReq"
                         }
                     }
                 ],
@@ -2257,7 +2357,7 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SysUserLoginResp"
+                                            "$ref": "#/definitions/models.SysRole"
                                         }
                                     }
                                 }
@@ -2267,9 +2367,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/login_by_phone": {
+        "/sys/role/get_by_name": {
             "post": {
-                "description": "手机号登录",
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "根据名字获取角色",
                 "consumes": [
                     "application/json"
                 ],
@@ -2277,17 +2382,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
                 ],
-                "summary": "手机号登录",
+                "summary": "根据名字获取角色",
                 "parameters": [
                     {
-                        "description": "手机号和验证码",
+                        "description": "角色名称",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserLoginByPhoneReq"
+                            "$ref": "#/definitions/dto.SysRoleGetByNameReq"
                         }
                     }
                 ],
@@ -2295,27 +2399,20 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/api.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.SysUserLoginResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/user/login_by_wechat": {
-            "get": {
-                "description": "微信授权重定向",
+        "/sys/role/get_permission_tree": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "获取角色拥有的权限树",
                 "consumes": [
                     "application/json"
                 ],
@@ -2323,9 +2420,20 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
+                ],
+                "summary": "获取角色拥有的权限树",
+                "parameters": [
+                    {
+                        "description": "角色id",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysRoleGetPermissionTreeReq"
+                        }
+                    }
                 ],
-                "summary": "微信登录",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2338,7 +2446,7 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SysUserGetOAuth2UrlResp"
+                                            "$ref": "#/definitions/dto.SysRolePermissionTreeResp"
                                         }
                                     }
                                 }
@@ -2348,42 +2456,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/logout": {
-            "post": {
-                "security": [
-                    {
-                        "RequireLogin": []
-                    }
-                ],
-                "description": "退出登录",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "用户管理"
-                ],
-                "summary": "退出登录",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/sys/user/page_query": {
+        "/sys/role/page_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "分页查询用户",
+                "description": "分页查询角色",
                 "consumes": [
                     "application/json"
                 ],
@@ -2391,16 +2471,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
                 ],
-                "summary": "分页查询用户",
+                "summary": "分页查询角色",
                 "parameters": [
                     {
-                        "description": "用户筛选条件",
+                        "description": "角色筛选条件",
                         "name": "param",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserPageQueryReq"
+                            "$ref": "#/definitions/dto.SysRolePageQueryReq"
                         }
                     }
                 ],
@@ -2424,7 +2504,7 @@ const docTemplateadmin = `{
                                                     "type": "object",
                                                     "properties": {
                                                         "List": {
-                                                            "$ref": "#/definitions/models.SysUser"
+                                                            "$ref": "#/definitions/models.SysRole"
                                                         }
                                                     }
                                                 }
@@ -2438,14 +2518,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/perfect_info": {
+        "/sys/role/unbind_users": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "完善基本信息",
+                "description": "取消角色关联用户，取消后用户不再拥有该角色",
                 "consumes": [
                     "application/json"
                 ],
@@ -2453,17 +2533,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
                 ],
-                "summary": "完善基本信息",
+                "summary": "取消授权角色给用户",
                 "parameters": [
                     {
-                        "description": "用户基本信息",
+                        "description": "需要解绑的角色id和用户id数组",
                         "name": "param",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserPerfectInfoReq"
+                            "$ref": "#/definitions/dto.SysRoleUnbindUsersReq"
                         }
                     }
                 ],
@@ -2477,14 +2557,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/reset_password": {
+        "/sys/role/update": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "重置密码",
+                "description": "根据id修改角色信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -2492,17 +2572,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "角色管理"
                 ],
-                "summary": "重置密码",
+                "summary": "修改角色",
                 "parameters": [
                     {
-                        "description": "用户ID",
+                        "description": "角色信息",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserResetPasswordReq"
+                            "$ref": "#/definitions/dto.SysRoleUpdateReq"
                         }
                     }
                 ],
@@ -2516,14 +2595,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/send_verify_email": {
+        "/sys/sms/cursor_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "发送验证邮件",
+                "description": "按游标分页查询短信记录，适用于数据量大的场景。nextCursor 为空表示没有下一页，count 为 exact 或 estimate 时返回总数",
                 "consumes": [
                     "application/json"
                 ],
@@ -2531,17 +2610,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "短信管理"
                 ],
-                "summary": "发送验证邮件",
+                "summary": "游标分页查询短信记录",
                 "parameters": [
                     {
-                        "description": "邮箱",
+                        "description": "短信记录筛选条件",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserSendVerifyEmailReq"
+                            "$ref": "#/definitions/dto.SysSmsLogCursorQueryReq"
                         }
                     }
                 ],
@@ -2549,20 +2627,44 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.CursorPageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysSmsLog"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/user/should_check_identity": {
+        "/sys/sms/page_query": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "当前操作是否需要进行身份验证",
+                "description": "分页查询短信记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -2570,17 +2672,16 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "短信管理"
                 ],
-                "summary": "当前操作是否需要进行身份验证",
+                "summary": "分页查询短信记录",
                 "parameters": [
                     {
-                        "description": "操作",
+                        "description": "短信记录筛选条件",
                         "name": "param",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserShouldCheckIdentityReq"
+                            "$ref": "#/definitions/dto.SysSmsLogPageQueryReq"
                         }
                     }
                 ],
@@ -2596,7 +2697,19 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SysUserShouldCheckIdentityResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysSmsLog"
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -2606,14 +2719,9 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/switch_enable_mfa": {
+        "/sys/sms/send": {
             "post": {
-                "security": [
-                    {
-                        "RequireLogin": []
-                    }
-                ],
-                "description": "切换是否开启MFA",
+                "description": "发送短信",
                 "consumes": [
                     "application/json"
                 ],
@@ -2621,27 +2729,37 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "短信管理"
                 ],
-                "summary": "切换是否开启MFA",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "发送短信",
+                "parameters": [
+                    {
+                        "description": "手机号和发送场景",
+                        "name": "param",
+                        "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "$ref": "#/definitions/dto.SysSmsSendReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
                         }
                     }
                 }
             }
         },
-        "/sys/user/unbind_mfa_device": {
+        "/sys/sms/send_to_myself": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "解绑多因素认证设备",
+                "description": "发送短信(登录后)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2649,32 +2767,19 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "短信管理"
                 ],
-                "summary": "解绑多因素认证设备",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "发送短信(登录后)",
+                "parameters": [
+                    {
+                        "description": "发送场景",
+                        "name": "param",
+                        "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "$ref": "#/definitions/dto.SysSmsSendToMyselfReq"
                         }
                     }
-                }
-            }
-        },
-        "/sys/user/unbind_wechat": {
-            "get": {
-                "description": "解绑微信",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "用户管理"
                 ],
-                "summary": "解绑微信",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2685,14 +2790,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/sys/user/update_password": {
+        "/sys/user/add": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "更新密码（需要旧密码）",
+                "description": "添加用户",
                 "consumes": [
                     "application/json"
                 ],
@@ -2702,15 +2807,15 @@ const docTemplateadmin = `{
                 "tags": [
                     "用户管理"
                 ],
-                "summary": "更新密码（需要旧密码）",
+                "summary": "添加用户",
                 "parameters": [
                     {
-                        "description": "手机号和验证码",
+                        "description": "用户信息",
                         "name": "param",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserUpdatePasswordReq"
+                            "$ref": "#/definitions/dto.SysUserInsertReq"
                         }
                     }
                 ],
@@ -2718,20 +2823,32 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserAddResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/sys/user/update_status": {
+        "/sys/user/bind_email": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "修改用户状态",
+                "description": "绑定邮箱",
                 "consumes": [
                     "application/json"
                 ],
@@ -2741,15 +2858,15 @@ const docTemplateadmin = `{
                 "tags": [
                     "用户管理"
                 ],
-                "summary": "修改用户状态",
+                "summary": "绑定邮箱",
                 "parameters": [
                     {
-                        "description": "用户id和状态",
+                        "description": "邮箱和邮箱验证码",
                         "name": "param",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SysUserUpdateStatusReq"
+                            "$ref": "#/definitions/dto.SysUserBindEmailReq"
                         }
                     }
                 ],
@@ -2763,34 +2880,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/wx/authorizer/authorize/redirect": {
-            "get": {
-                "description": "获取auth_code",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "授权公众号管理"
-                ],
-                "summary": "授权回调",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/wx/authorizer/create_kf_account": {
+        "/sys/user/bind_mfa_device": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "为公众号创建客服账号",
+                "description": "绑定多因素认证设备",
                 "consumes": [
                     "application/json"
                 ],
@@ -2798,16 +2895,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "授权公众号管理"
+                    "用户管理"
                 ],
-                "summary": "创建客服账号",
+                "summary": "绑定多因素认证设备",
                 "parameters": [
                     {
-                        "description": "客服账号和昵称",
+                        "description": "mfa信息",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.KfAccountCreateReq"
+                            "$ref": "#/definitions/dto.SysUserBindMFAReq"
                         }
                     }
                 ],
@@ -2815,20 +2913,32 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserBindMFAKeyResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/wx/authorizer/delete_kf_account": {
+        "/sys/user/bind_phone": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "删除客服账号",
+                "description": "绑定手机",
                 "consumes": [
                     "application/json"
                 ],
@@ -2836,16 +2946,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "授权公众号管理"
+                    "用户管理"
                 ],
-                "summary": "删除客服账号",
+                "summary": "绑定手机",
                 "parameters": [
                     {
-                        "description": "公众号appId",
+                        "description": "手机号和短信验证码",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.KfAccountDeleteReq"
+                            "$ref": "#/definitions/dto.SysUserBindPhoneReq"
                         }
                     }
                 ],
@@ -2859,14 +2970,49 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/wx/authorizer/delete_menu": {
+        "/sys/user/bind_wechat": {
+            "get": {
+                "description": "微信授权重定向",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "绑定微信",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserGetOAuth2UrlResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/change_password": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "清空菜单",
+                "description": "更新密码（无需旧密码，但是需要先进行身份验证）",
                 "consumes": [
                     "application/json"
                 ],
@@ -2874,16 +3020,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "授权公众号管理"
+                    "用户管理"
                 ],
-                "summary": "清空菜单",
+                "summary": "更新密码（无需旧密码，但是需要先进行身份验证）",
                 "parameters": [
                     {
-                        "description": "公众号appId",
+                        "description": "手机号和验证码",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.MenuClearReq"
+                            "$ref": "#/definitions/dto.SysUserChangePasswordReq"
                         }
                     }
                 ],
@@ -2897,14 +3044,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/wx/authorizer/get_all_kf_accounts": {
+        "/sys/user/check_identity": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "获取公众号的所有客服账号",
+                "description": "身份验证",
                 "consumes": [
                     "application/json"
                 ],
@@ -2912,16 +3059,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "授权公众号管理"
+                    "用户管理"
                 ],
-                "summary": "获取公众号的所有客服账号",
+                "summary": "身份验证",
                 "parameters": [
                     {
-                        "description": "公众号appId",
+                        "description": "验证方式和验证码",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.KfAccountGetAllReq"
+                            "$ref": "#/definitions/dto.SysUserCheckIdentityReq"
                         }
                     }
                 ],
@@ -2935,14 +3083,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/wx/authorizer/get_authorize_url": {
+        "/sys/user/generate_mfa_device": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "获取授权链接，微信扫码访问该连接，会跳转到选择公众号授权给第三方平台页面",
+                "description": "生成多因素认证密钥",
                 "consumes": [
                     "application/json"
                 ],
@@ -2950,9 +3098,9 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "授权公众号管理"
+                    "用户管理"
                 ],
-                "summary": "获取公众号授权连接",
+                "summary": "生成多因素认证密钥",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2965,7 +3113,7 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.GetAuthorizeUrlResp"
+                                            "$ref": "#/definitions/dto.SysUserGenerateMFAKeyResp"
                                         }
                                     }
                                 }
@@ -2975,14 +3123,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/wx/authorizer/get_menu": {
+        "/sys/user/get_by_id": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "获取菜单",
+                "description": "根据id获取用户信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -2990,16 +3138,17 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "授权公众号管理"
+                    "用户管理"
                 ],
-                "summary": "获取菜单",
+                "summary": "根据id获取用户信息",
                 "parameters": [
                     {
-                        "description": "公众号appId",
+                        "description": "用户id",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.MenuClearReq"
+                            "$ref": "#/definitions/dto.SysUserGetReq"
                         }
                     }
                 ],
@@ -3007,20 +3156,32 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SysUser"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/wx/authorizer/page_query": {
+        "/sys/user/get_oauth2_url": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "分页查询授权公众号",
+                "description": "获取绑定微信的授权链接",
                 "consumes": [
                     "application/json"
                 ],
@@ -3028,19 +3189,9 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "授权公众号管理"
-                ],
-                "summary": "分页查询授权公众号",
-                "parameters": [
-                    {
-                        "description": "授权公众号筛选条件",
-                        "name": "param",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.WxAuthorizerPageQueryReq"
-                        }
-                    }
+                    "用户管理"
                 ],
+                "summary": "获取绑定微信的授权链接",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3053,19 +3204,7 @@ const docTemplateadmin = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/api.PageData"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "List": {
-                                                            "$ref": "#/definitions/models.WxAuthorizer"
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/dto.SysUserGetOAuth2UrlResp"
                                         }
                                     }
                                 }
@@ -3075,14 +3214,14 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "/wx/authorizer/set_default_kf_account": {
+        "/sys/user/get_personal_info": {
             "post": {
                 "security": [
                     {
                         "RequireLogin": []
                     }
                 ],
-                "description": "设置默认的客服账号（使用该账号给用户发送消息）",
+                "description": "获取个人信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -3090,16 +3229,52 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "授权公众号管理"
+                    "用户管理"
                 ],
-                "summary": "设置默认的客服账号（使用该账号给用户发送消息）",
+                "summary": "获取个人信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserPersonalInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/get_wechat_login_result": {
+            "get": {
+                "description": "获取微信登录结果",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取微信登录结果",
                 "parameters": [
                     {
-                        "description": "公众号appId",
+                        "description": "登录id",
                         "name": "param",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.KfAccountSetDefaultReq"
+                            "$ref": "#/definitions/dto.SysUserGetWxLoginResultReq"
                         }
                     }
                 ],
@@ -3107,15 +3282,32 @@ const docTemplateadmin = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserGetOAuth2UrlResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/wx/authorizer/sync_info": {
+        "/sys/user/get_wechat_login_url": {
             "post": {
-                "description": "同步授权方信息",
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "获取微信登录链接",
                 "consumes": [
                     "application/json"
                 ],
@@ -3123,169 +3315,1850 @@ const docTemplateadmin = `{
                     "application/json"
                 ],
                 "tags": [
-                    "授权公众号管理"
+                    "用户管理"
                 ],
-                "summary": "同步授权方信息",
+                "summary": "获取微信登录链接",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserGetWxLoginUrlResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.PageData": {
-            "type": "object",
-            "properties": {
-                "list": {},
-                "pageNum": {
-                    "type": "integer"
-                },
-                "pageSize": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
+        },
+        "/sys/user/login_by_mfa": {
+            "post": {
+                "description": "开启二步验证的用户账号密码登录后，用返回的凭证和TOTP密码完成登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "二步验证登录",
+                "parameters": [
+                    {
+                        "description": "凭证和TOTP密码",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserLoginByMfaReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
                 }
             }
         },
-        "api.Response": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "data": {},
-                "detail": {
-                    "type": "string"
-                },
-                "msg": {
-                    "type": "string"
-                },
-                "requestId": {
-                    "type": "string"
+        "/sys/user/login_by_password": {
+            "post": {
+                "description": "账号密码登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "账号密码登录",
+                "parameters": [
+                    {
+                        "description": "账号密码",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserLoginByPasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/login_by_phone": {
+            "post": {
+                "description": "手机号登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "手机号登录",
+                "parameters": [
+                    {
+                        "description": "手机号和验证码",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserLoginByPhoneReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/login_by_wechat": {
+            "get": {
+                "description": "微信授权重定向",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "微信登录",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserGetOAuth2UrlResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/logout": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "退出登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "退出登录",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/page_query": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "分页查询用户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "分页查询用户",
+                "parameters": [
+                    {
+                        "description": "用户筛选条件",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserPageQueryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.SysUser"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/perfect_info": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "完善基本信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "完善基本信息",
+                "parameters": [
+                    {
+                        "description": "用户基本信息",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserPerfectInfoReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/reset_password": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "重置密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "重置密码",
+                "parameters": [
+                    {
+                        "description": "用户ID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserResetPasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/send_verify_email": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "发送验证邮件",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "发送验证邮件",
+                "parameters": [
+                    {
+                        "description": "邮箱",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserSendVerifyEmailReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/should_check_identity": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "当前操作是否需要进行身份验证",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "当前操作是否需要进行身份验证",
+                "parameters": [
+                    {
+                        "description": "操作",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserShouldCheckIdentityReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysUserShouldCheckIdentityResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/switch_enable_mfa": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "切换是否开启MFA",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "切换是否开启MFA",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/unbind_mfa_device": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "解绑多因素认证设备",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "解绑多因素认证设备",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/unbind_wechat": {
+            "get": {
+                "description": "解绑微信",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "解绑微信",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/update_password": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "更新密码（需要旧密码）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新密码（需要旧密码）",
+                "parameters": [
+                    {
+                        "description": "手机号和验证码",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserUpdatePasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sys/user/update_status": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "修改用户状态",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "修改用户状态",
+                "parameters": [
+                    {
+                        "description": "用户id和状态",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysUserUpdateStatusReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/authorize/redirect": {
+            "get": {
+                "description": "获取auth_code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "授权回调",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/create_kf_account": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "为公众号创建客服账号",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "创建客服账号",
+                "parameters": [
+                    {
+                        "description": "客服账号和昵称",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KfAccountCreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/delete_kf_account": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "删除客服账号",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "删除客服账号",
+                "parameters": [
+                    {
+                        "description": "公众号appId",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KfAccountDeleteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/delete_menu": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "清空菜单",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "清空菜单",
+                "parameters": [
+                    {
+                        "description": "公众号appId",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MenuClearReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/get_all_kf_accounts": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "获取公众号的所有客服账号",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "获取公众号的所有客服账号",
+                "parameters": [
+                    {
+                        "description": "公众号appId",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KfAccountGetAllReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/get_authorize_url": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "获取授权链接，微信扫码访问该连接，会跳转到选择公众号授权给第三方平台页面",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "获取公众号授权连接",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.GetAuthorizeUrlResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/get_menu": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "获取菜单",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "获取菜单",
+                "parameters": [
+                    {
+                        "description": "公众号appId",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MenuClearReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/page_query": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "分页查询授权公众号",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "分页查询授权公众号",
+                "parameters": [
+                    {
+                        "description": "授权公众号筛选条件",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WxAuthorizerPageQueryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/api.PageData"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "List": {
+                                                            "$ref": "#/definitions/models.WxAuthorizer"
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/set_default_kf_account": {
+            "post": {
+                "security": [
+                    {
+                        "RequireLogin": []
+                    }
+                ],
+                "description": "设置默认的客服账号（使用该账号给用户发送消息）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "设置默认的客服账号（使用该账号给用户发送消息）",
+                "parameters": [
+                    {
+                        "description": "公众号appId",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KfAccountSetDefaultReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/authorizer/sync_info": {
+            "post": {
+                "description": "同步授权方信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "授权公众号管理"
+                ],
+                "summary": "同步授权方信息",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.CursorPageData": {
+            "type": "object",
+            "properties": {
+                "list": {},
+                "nextCursor": {
+                    "description": "为空表示没有下一页",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "请求统计总数时返回",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.PageData": {
+            "type": "object",
+            "properties": {
+                "list": {},
+                "pageNum": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {},
+                "detail": {
+                    "type": "string"
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "traceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "clientconst.Constants": {
+            "type": "object",
+            "properties": {
+                "enums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clientconst.Enum"
+                    }
+                },
+                "errorCodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clientconst.ErrorCode"
+                    }
+                }
+            }
+        },
+        "clientconst.Enum": {
+            "type": "object",
+            "properties": {
+                "dict": {
+                    "description": "字典名称，如 sys_user_status",
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clientconst.EnumItem"
+                    }
+                },
+                "label": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "类型名，如 SysUserStatus",
+                    "type": "string"
+                }
+            }
+        },
+        "clientconst.EnumItem": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "成员名",
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "clientconst.ErrorCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "customtypes.StringMap": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "dto.Base64ImageUploadReq": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetAuthorizeUrlResp": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImageUrlUploadReq": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.KfAccountCreateReq": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "appId": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.KfAccountDeleteReq": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "appId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.KfAccountGetAllReq": {
+            "type": "object",
+            "properties": {
+                "appId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.KfAccountSetDefaultReq": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "appId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MenuClearReq": {
+            "type": "object",
+            "properties": {
+                "appId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysConfigAddReq": {
+            "type": "object",
+            "properties": {
+                "ValueType": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysConfigDefinitionResp": {
+            "type": "object",
+            "properties": {
+                "configured": {
+                    "description": "是否已在数据库中配置",
+                    "type": "boolean"
+                },
+                "defaultValue": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "max": {
+                    "description": "number 类型的最大值",
+                    "type": "number"
+                },
+                "min": {
+                    "description": "number 类型的最小值",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "schema": {
+                    "description": "json 类型的 JSON-schema",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "数据库中的值，未配置时为空",
+                    "type": "string"
+                },
+                "valueType": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysConfigDeleteReq": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "dto.SysConfigDiffReq": {
+            "type": "object",
+            "properties": {
+                "configId": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "fromLogId": {
+                    "description": "日志id，为0表示当前值",
+                    "type": "string",
+                    "example": "0"
+                },
+                "toLogId": {
+                    "description": "日志id，为0表示当前值",
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "dto.SysConfigDiffResp": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dynconfig.Change"
+                    }
+                },
+                "configId": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "from": {
+                    "$ref": "#/definitions/dto.SysConfigRevision"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "to": {
+                    "$ref": "#/definitions/dto.SysConfigRevision"
+                },
+                "valueType": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysConfigExportReq": {
+            "type": "object",
+            "properties": {
+                "names": {
+                    "description": "为空时导出全部",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.SysConfigImportItem": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "create|update|unchanged",
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dynconfig.Change"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysConfigImportReq": {
+            "type": "object",
+            "properties": {
+                "configs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SysConfigSnapshotItem"
+                    }
+                },
+                "dryRun": {
+                    "description": "为true时只返回将要发生的变更，不写入",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.SysConfigImportResp": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SysConfigImportItem"
+                    }
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SysConfigInstanceResp": {
+            "type": "object",
+            "properties": {
+                "instance": {
+                    "description": "实例标识，主机名-进程号",
+                    "type": "string"
+                },
+                "loadedAt": {
+                    "type": "string"
+                },
+                "reportedAt": {
+                    "type": "string"
+                },
+                "upToDate": {
+                    "description": "是否已加载最新版本",
+                    "type": "boolean"
+                },
+                "version": {
+                    "description": "实例已加载的配置版本号",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SysConfigLogPageQueryReq": {
+            "type": "object",
+            "properties": {
+                "configId": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "pageNum": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SysConfigPageQueryReq": {
+            "type": "object",
+            "properties": {
+                "idOrder": {
+                    "type": "string"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "pageNum": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SysConfigRevision": {
+            "type": "object",
+            "properties": {
+                "changedAt": {
+                    "type": "string"
+                },
+                "logId": {
+                    "description": "为0表示当前值",
+                    "type": "string",
+                    "example": "0"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysConfigRollbackReq": {
+            "type": "object",
+            "properties": {
+                "before": {
+                    "description": "为true时回滚到该日志修改之前的值，否则回滚到该日志修改之后的值",
+                    "type": "boolean"
+                },
+                "logId": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "dto.SysConfigSnapshot": {
+            "type": "object",
+            "properties": {
+                "configs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SysConfigSnapshotItem"
+                    }
+                },
+                "exportedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysConfigSnapshotItem": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                },
+                "valueType": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysConfigUpdateReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysConfigVersionResp": {
+            "type": "object",
+            "properties": {
+                "instances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SysConfigInstanceResp"
+                    }
+                },
+                "version": {
+                    "description": "当前配置版本号",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SysDictAddReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "public": {
+                    "description": "是否公开，公开的字典可通过app-api匿名读取",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.SysDictClientConstantsReq": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "json|ts|go，默认json",
+                    "type": "string"
+                },
+                "goPackage": {
+                    "description": "format为go时的包名，默认constants",
+                    "type": "string"
+                },
+                "names": {
+                    "description": "为空时包括全部字典",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.SysDictDeleteReq": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.Base64ImageUploadReq": {
+        "dto.SysDictExportReq": {
             "type": "object",
             "properties": {
-                "image": {
+                "format": {
+                    "description": "json|yaml|xlsx",
                     "type": "string"
+                },
+                "names": {
+                    "description": "为空时导出全部",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.GetAuthorizeUrlResp": {
+        "dto.SysDictGetItemsReq": {
             "type": "object",
             "properties": {
-                "url": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "dto.ImageUrlUploadReq": {
+        "dto.SysDictGetItemsResp": {
             "type": "object",
             "properties": {
-                "url": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SysDictItem"
+                    }
+                },
+                "version": {
+                    "description": "内容版本，与响应头 ETag 一致，可在请求头 If-None-Match 中带上以跳过未变化的内容",
                     "type": "string"
                 }
             }
         },
-        "dto.KfAccountCreateReq": {
+        "dto.SysDictImportConflict": {
             "type": "object",
             "properties": {
-                "account": {
+                "dictName": {
                     "type": "string"
                 },
-                "appId": {
+                "itemValue": {
+                    "description": "为空表示字典本身冲突",
                     "type": "string"
                 },
-                "nickname": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "dto.KfAccountDeleteReq": {
+        "dto.SysDictImportResp": {
             "type": "object",
             "properties": {
-                "account": {
-                    "type": "string"
+                "conflicts": {
+                    "description": "未覆盖的冲突",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SysDictImportConflict"
+                    }
                 },
-                "appId": {
-                    "type": "string"
+                "dictCreated": {
+                    "type": "integer"
+                },
+                "dictUpdated": {
+                    "type": "integer"
+                },
+                "dicts": {
+                    "description": "有变更的字典名称",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "itemCreated": {
+                    "type": "integer"
+                },
+                "itemUpdated": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "description": "内容一致的字典和字典项个数",
+                    "type": "integer"
                 }
             }
         },
-        "dto.KfAccountGetAllReq": {
+        "dto.SysDictItemAddReq": {
             "type": "object",
             "properties": {
-                "appId": {
+                "description": {
+                    "type": "string"
+                },
+                "dictName": {
+                    "type": "string"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "itemLabel": {
+                    "type": "string"
+                },
+                "itemValue": {
                     "type": "string"
+                },
+                "parentValue": {
+                    "description": "上级字典项的值，为空表示顶级",
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "integer"
+                },
+                "translations": {
+                    "description": "各语言的标签，如{\"en-US\":\"Normal\"}",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/customtypes.StringMap"
+                        }
+                    ]
                 }
             }
         },
-        "dto.KfAccountSetDefaultReq": {
+        "dto.SysDictItemPageQueryReq": {
             "type": "object",
             "properties": {
-                "account": {
+                "depth": {
+                    "description": "树模式下返回的层数，0表示不限",
+                    "type": "integer"
+                },
+                "dictName": {
                     "type": "string"
                 },
-                "appId": {
+                "keyword": {
+                    "type": "string"
+                },
+                "pageNum": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "sortOrder": {
                     "type": "string"
+                },
+                "tree": {
+                    "description": "为true时按树返回：匹配的字典项连同其上级组成树，按顶级字典项分页",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.MenuClearReq": {
+        "dto.SysDictItemPathReq": {
             "type": "object",
             "properties": {
-                "appId": {
+                "dictName": {
+                    "type": "string"
+                },
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "dto.SysConfigAddReq": {
+        "dto.SysDictItemTree": {
             "type": "object",
             "properties": {
-                "ValueType": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SysDictItemTree"
+                    }
+                },
+                "createdAt": {
                     "type": "string"
                 },
+                "createdBy": {
+                    "type": "string",
+                    "example": "0"
+                },
                 "description": {
                     "type": "string"
                 },
-                "name": {
+                "dictName": {
                     "type": "string"
                 },
-                "value": {
+                "id": {
+                    "type": "integer"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "itemLabel": {
+                    "type": "string"
+                },
+                "itemValue": {
+                    "type": "string"
+                },
+                "parentValue": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "translations": {
+                    "$ref": "#/definitions/customtypes.StringMap"
+                },
+                "updatedAt": {
                     "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
-        "dto.SysConfigDeleteReq": {
+        "dto.SysDictItemTreeReq": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "0"
+                "depth": {
+                    "description": "返回的层数，0表示不限",
+                    "type": "integer"
+                },
+                "dictName": {
+                    "type": "string"
+                },
+                "parentValue": {
+                    "description": "从该字典项的下级开始，为空时从顶级开始",
+                    "type": "string"
                 }
             }
         },
-        "dto.SysConfigLogPageQueryReq": {
+        "dto.SysDictItemUpdateReq": {
             "type": "object",
             "properties": {
-                "configId": {
-                    "type": "string",
-                    "example": "0"
+                "description": {
+                    "type": "string"
                 },
-                "pageNum": {
+                "id": {
                     "type": "integer"
                 },
-                "pageSize": {
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "itemLabel": {
+                    "type": "string"
+                },
+                "itemValue": {
+                    "type": "string"
+                },
+                "parentValue": {
+                    "description": "上级字典项的值，为空表示顶级",
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "integer"
+                },
+                "translations": {
+                    "description": "各语言的标签，为空时不修改，传{}时清空",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/customtypes.StringMap"
+                        }
+                    ]
                 }
             }
         },
-        "dto.SysConfigPageQueryReq": {
+        "dto.SysDictPageQueryReq": {
             "type": "object",
             "properties": {
                 "idOrder": {
@@ -3302,118 +5175,208 @@ const docTemplateadmin = `{
                 }
             }
         },
-        "dto.SysConfigUpdateReq": {
+        "dto.SysDictUpdateReq": {
             "type": "object",
             "properties": {
                 "description": {
                     "type": "string"
                 },
                 "id": {
-                    "type": "string",
-                    "example": "0"
+                    "type": "integer"
                 },
-                "value": {
-                    "type": "string"
+                "public": {
+                    "description": "为空时不修改",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.SysDictAddReq": {
+        "dto.SysEntityHistoryTimelineReq": {
             "type": "object",
             "properties": {
-                "description": {
+                "action": {
                     "type": "string"
                 },
-                "label": {
+                "entityId": {
                     "type": "string"
                 },
-                "name": {
+                "entityType": {
                     "type": "string"
+                },
+                "pageNum": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.SysDictDeleteReq": {
+        "dto.SysLogSetLevelReq": {
             "type": "object",
             "properties": {
-                "id": {
+                "level": {
+                    "description": "debug|info|warn|error",
+                    "type": "string"
+                },
+                "module": {
+                    "description": "root|sql|http|wechat，为空表示root",
+                    "type": "string"
+                },
+                "reset": {
+                    "description": "撤销运行时设置，恢复为配置文件中的级别",
+                    "type": "boolean"
+                },
+                "ttl": {
+                    "description": "有效期（秒），到期后恢复为配置文件中的级别，0表示一直有效",
                     "type": "integer"
                 }
             }
         },
-        "dto.SysDictGetItemsReq": {
+        "dto.SysLoginLogCursorQueryReq": {
             "type": "object",
             "properties": {
-                "name": {
+                "account": {
+                    "type": "string"
+                },
+                "count": {
+                    "description": "是否返回总数：为空不统计，exact精确统计，estimate估算",
+                    "type": "string"
+                },
+                "createdAtEnd": {
+                    "type": "string"
+                },
+                "createdAtStart": {
+                    "type": "string"
+                },
+                "cursor": {
+                    "type": "string"
+                },
+                "idOrder": {
                     "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
-        "dto.SysDictGetItemsResp": {
+        "dto.SysLoginLogPageQueryReq": {
             "type": "object",
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.SysDictItem"
-                    }
+                "account": {
+                    "type": "string"
+                },
+                "createdAtEnd": {
+                    "type": "string"
+                },
+                "createdAtStart": {
+                    "type": "string"
+                },
+                "idOrder": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "pageNum": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
-        "dto.SysDictItemAddReq": {
+        "dto.SysLoginLogPersonalPageQueryReq": {
             "type": "object",
             "properties": {
-                "description": {
+                "createdAtEnd": {
                     "type": "string"
                 },
-                "dictName": {
+                "createdAtStart": {
                     "type": "string"
                 },
-                "isDefault": {
-                    "type": "boolean"
-                },
-                "itemLabel": {
+                "idOrder": {
                     "type": "string"
                 },
-                "itemValue": {
-                    "type": "string"
+                "pageNum": {
+                    "type": "integer"
                 },
-                "sort": {
+                "pageSize": {
                     "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.SysDictItemUpdateReq": {
+        "dto.SysOperationLogCursorQueryReq": {
             "type": "object",
             "properties": {
-                "description": {
+                "count": {
+                    "description": "是否返回总数：为空不统计，exact精确统计，estimate估算",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "createdAtEnd": {
+                    "type": "string"
                 },
-                "isDefault": {
-                    "type": "boolean"
+                "createdAtStart": {
+                    "type": "string"
                 },
-                "itemLabel": {
+                "cursor": {
                     "type": "string"
                 },
-                "itemValue": {
+                "idOrder": {
                     "type": "string"
                 },
-                "sort": {
+                "pageSize": {
                     "type": "integer"
                 },
-                "status": {
+                "path": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "respCode": {
                     "type": "integer"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
-        "dto.SysDictPageQueryReq": {
+        "dto.SysOperationLogPageQueryReq": {
             "type": "object",
             "properties": {
-                "idOrder": {
+                "createdAtEnd": {
                     "type": "string"
                 },
-                "keyword": {
+                "createdAtStart": {
+                    "type": "string"
+                },
+                "idOrder": {
                     "type": "string"
                 },
                 "pageNum": {
@@ -3421,17 +5384,19 @@ const docTemplateadmin = `{
                 },
                 "pageSize": {
                     "type": "integer"
-                }
-            }
-        },
-        "dto.SysDictUpdateReq": {
-            "type": "object",
-            "properties": {
-                "description": {
+                },
+                "path": {
                     "type": "string"
                 },
-                "id": {
+                "requestId": {
+                    "type": "string"
+                },
+                "respCode": {
                     "type": "integer"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
@@ -3669,6 +5634,15 @@ const docTemplateadmin = `{
                 }
             }
         },
+        "dto.SysRoleGetReq": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
         "dto.SysRolePageQueryReq": {
             "type": "object",
             "properties": {
@@ -3730,6 +5704,36 @@ const docTemplateadmin = `{
                 }
             }
         },
+        "dto.SysSmsLogCursorQueryReq": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "是否返回总数：为空不统计，exact精确统计，estimate估算",
+                    "type": "string"
+                },
+                "createdAtEnd": {
+                    "type": "string"
+                },
+                "createdAtStart": {
+                    "type": "string"
+                },
+                "cursor": {
+                    "type": "string"
+                },
+                "idOrder": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "templateId": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.SysSmsLogPageQueryReq": {
             "type": "object",
             "properties": {
@@ -3920,6 +5924,18 @@ const docTemplateadmin = `{
                 }
             }
         },
+        "dto.SysUserLoginByMfaReq": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "ticket": {
+                    "description": "账号密码登录返回的二步验证凭证",
+                    "type": "string"
+                }
+            }
+        },
         "dto.SysUserLoginByPasswordReq": {
             "type": "object",
             "properties": {
@@ -3948,6 +5964,14 @@ const docTemplateadmin = `{
                 "expireTimeSeconds": {
                     "type": "integer"
                 },
+                "mfaRequired": {
+                    "description": "为true时需用mfaTicket调用login_by_mfa完成登录",
+                    "type": "boolean"
+                },
+                "mfaTicket": {
+                    "description": "二步验证凭证，5分钟内有效",
+                    "type": "string"
+                },
                 "token": {
                     "type": "string"
                 }
@@ -3999,6 +6023,10 @@ const docTemplateadmin = `{
                 "avatar": {
                     "type": "string"
                 },
+                "language": {
+                    "description": "偏好语言，如zh-CN、en-US",
+                    "type": "string"
+                },
                 "nickname": {
                     "type": "string"
                 },
@@ -4123,6 +6151,40 @@ const docTemplateadmin = `{
                 }
             }
         },
+        "dynconfig.Change": {
+            "type": "object",
+            "properties": {
+                "new": {
+                    "type": "string"
+                },
+                "old": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "log.LevelInfo": {
+            "type": "object",
+            "properties": {
+                "expireAt": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "module": {
+                    "type": "string"
+                },
+                "override": {
+                    "type": "boolean"
+                }
+            }
+        },
         "models.SysConfig": {
             "type": "object",
             "properties": {
@@ -4133,6 +6195,10 @@ const docTemplateadmin = `{
                     "type": "string",
                     "example": "0"
                 },
+                "defined": {
+                    "description": "是否已在代码中注册定义",
+                    "type": "boolean"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -4180,6 +6246,11 @@ const docTemplateadmin = `{
                 },
                 "oldValue": {
                     "type": "string"
+                },
+                "rollbackLogId": {
+                    "description": "由回滚产生时为回滚所依据的日志id",
+                    "type": "string",
+                    "example": "0"
                 }
             }
         },
@@ -4215,6 +6286,9 @@ const docTemplateadmin = `{
                 "name": {
                     "type": "string"
                 },
+                "public": {
+                    "type": "boolean"
+                },
                 "updatedAt": {
                     "type": "string"
                 },
@@ -4252,12 +6326,18 @@ const docTemplateadmin = `{
                 "itemValue": {
                     "type": "string"
                 },
+                "parentValue": {
+                    "type": "string"
+                },
                 "sort": {
                     "type": "integer"
                 },
                 "status": {
                     "type": "integer"
                 },
+                "translations": {
+                    "$ref": "#/definitions/customtypes.StringMap"
+                },
                 "updatedAt": {
                     "type": "string"
                 },
@@ -4267,6 +6347,126 @@ const docTemplateadmin = `{
                 }
             }
         },
+        "models.SysEntityHistory": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "entityId": {
+                    "type": "string"
+                },
+                "entityType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "models.SysLoginLog": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "browser": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "failReason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "os": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "userAgent": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "models.SysOperationLog": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "latency": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "respCode": {
+                    "type": "integer"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
         "models.SysPermission": {
             "type": "object",
             "properties": {
@@ -4400,6 +6600,9 @@ const docTemplateadmin = `{
                 "isBindMfaDevice": {
                     "type": "boolean"
                 },
+                "language": {
+                    "type": "string"
+                },
                 "lastLoginIp": {
                     "type": "string"
                 },
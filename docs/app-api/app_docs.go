@@ -20,6 +20,268 @@ const docTemplateapp = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/dict/item/batch_get_by_names": {
+            "post": {
+                "description": "根据字典名称数组获取所有项目，只能获取公开的字典",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "字典"
+                ],
+                "summary": "根据字典名称数组获取所有项目",
+                "parameters": [
+                    {
+                        "description": "字典名称数组",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysDictBatchGetItemsReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的ETag，内容未变化时返回304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "array",
+                                                "items": {
+                                                    "$ref": "#/definitions/models.SysDictItem"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "内容未变化"
+                    }
+                }
+            }
+        },
+        "/dict/item/get_by_name": {
+            "post": {
+                "description": "根据字典名称获取所有项目，只能获取公开的字典",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "字典"
+                ],
+                "summary": "根据字典名称获取所有项目",
+                "parameters": [
+                    {
+                        "description": "字典名称",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysDictGetItemsReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "上次响应的ETag，内容未变化时返回304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SysDictGetItemsResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "内容未变化"
+                    }
+                }
+            }
+        },
+        "/dict/item/get_path": {
+            "post": {
+                "description": "根据值获取从顶级到该字典项的所有字典项，只能获取公开的字典",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "字典"
+                ],
+                "summary": "获取字典项的完整路径",
+                "parameters": [
+                    {
+                        "description": "字典名称及字典项的值",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysDictItemPathReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SysDictItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/dict/item/get_tree": {
+            "post": {
+                "description": "获取字典项树，用于级联选择，只能获取公开的字典，不包括已禁用的字典项及其下级",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "字典"
+                ],
+                "summary": "获取字典项树",
+                "parameters": [
+                    {
+                        "description": "字典名称",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SysDictItemTreeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SysDictItemTree"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/feature/get_flags": {
+            "post": {
+                "description": "返回所有功能开关对调用者是否开启。请求头带token时按登录用户判断，否则按匿名用户及传入的平台判断",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "功能开关"
+                ],
+                "summary": "查询功能开关",
+                "parameters": [
+                    {
+                        "description": "平台",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FeatureFlagGetReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/api.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "boolean"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/file/upload_base64_image": {
             "post": {
                 "description": "上传Base64格式图片",
@@ -126,9 +388,18 @@ const docTemplateapp = `{
                 },
                 "requestId": {
                     "type": "string"
+                },
+                "traceId": {
+                    "type": "string"
                 }
             }
         },
+        "customtypes.StringMap": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
         "dto.Base64ImageUploadReq": {
             "type": "object",
             "properties": {
@@ -137,6 +408,131 @@ const docTemplateapp = `{
                 }
             }
         },
+        "dto.FeatureFlagGetReq": {
+            "type": "object",
+            "properties": {
+                "platformId": {
+                    "description": "未登录时由客户端传入平台，见 constant 中的 PlatformID；登录后以token中的平台为准",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SysDictBatchGetItemsReq": {
+            "type": "object",
+            "properties": {
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.SysDictGetItemsReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysDictGetItemsResp": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SysDictItem"
+                    }
+                },
+                "version": {
+                    "description": "内容版本，与响应头 ETag 一致，可在请求头 If-None-Match 中带上以跳过未变化的内容",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysDictItemPathReq": {
+            "type": "object",
+            "properties": {
+                "dictName": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SysDictItemTree": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SysDictItemTree"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dictName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "itemLabel": {
+                    "type": "string"
+                },
+                "itemValue": {
+                    "type": "string"
+                },
+                "parentValue": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "translations": {
+                    "$ref": "#/definitions/customtypes.StringMap"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
+        },
+        "dto.SysDictItemTreeReq": {
+            "type": "object",
+            "properties": {
+                "depth": {
+                    "description": "返回的层数，0表示不限",
+                    "type": "integer"
+                },
+                "dictName": {
+                    "type": "string"
+                },
+                "parentValue": {
+                    "description": "从该字典项的下级开始，为空时从顶级开始",
+                    "type": "string"
+                }
+            }
+        },
         "dto.UploadFileResp": {
             "type": "object",
             "properties": {
@@ -144,6 +540,55 @@ const docTemplateapp = `{
                     "type": "string"
                 }
             }
+        },
+        "models.SysDictItem": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dictName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "itemLabel": {
+                    "type": "string"
+                },
+                "itemValue": {
+                    "type": "string"
+                },
+                "parentValue": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "translations": {
+                    "$ref": "#/definitions/customtypes.StringMap"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string",
+                    "example": "0"
+                }
+            }
         }
     }
 }`
@@ -154,8 +599,8 @@ var SwaggerInfoapp = &swag.Spec{
 	Host:             "127.0.0.1:10002",
 	BasePath:         "/api/v1",
 	Schemes:          []string{},
-	Title:            "H5 API",
-	Description:      "xxx H5 API文档",
+	Title:            "APP API",
+	Description:      "xxx APP API文档",
 	InfoInstanceName: "app",
 	SwaggerTemplate:  docTemplateapp,
 	LeftDelim:        "{{",
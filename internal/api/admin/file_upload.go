@@ -61,7 +61,7 @@ func (e FileUpload) UploadImage(c *gin.Context) {
 	defer src.Close()
 	// 生成目标文件名
 	destination := generateDestination(file.Filename)
-	url, err := file_store.FileStore.UpLoad(destination, src)
+	url, err := file_store.GetFileStore().UpLoad(destination, src)
 	if err != nil {
 		e.Error(err)
 		return
@@ -101,7 +101,7 @@ func (e FileUpload) UploadBase64Image(c *gin.Context) {
 	src := strings.NewReader(string(imageData))
 	// 生成目标文件名
 	destination := generateDestination("base64.png")
-	url, err := file_store.FileStore.UpLoad(destination, src)
+	url, err := file_store.GetFileStore().UpLoad(destination, src)
 	if err != nil {
 		e.Error(err)
 		return
@@ -134,7 +134,7 @@ func (e FileUpload) UploadBlobImage(c *gin.Context) {
 	src := bytes.NewReader(req.Blob)
 	// 生成目标文件名
 	destination := generateDestination(req.FileName)
-	url, err := file_store.FileStore.UpLoad(destination, src)
+	url, err := file_store.GetFileStore().UpLoad(destination, src)
 	if err != nil {
 		e.Error(err)
 		return
@@ -182,8 +182,8 @@ func (e FileUpload) DownloadAndStore(context *gin.Context) {
 	}
 	src := bytes.NewReader(imageBytes)
 	fileName := utils.Int64ToString(utils.GenID()) + "." + res.Header.Get("Content-Type")[len("image/"):]
-	filePath := fmt.Sprintf("orderin/%s/%s", config.Config.Env.Profiles, fileName)
-	url, err := file_store.FileStore.UpLoad(filePath, src)
+	filePath := fmt.Sprintf("orderin/%s/%s", config.Get().Env.Profiles, fileName)
+	url, err := file_store.GetFileStore().UpLoad(filePath, src)
 	if err != nil {
 		log.ZError(context, "Upload image fail", err, "imageUrl", imageUrl)
 		e.Error(err)
@@ -209,5 +209,5 @@ func isImageFile(file *multipart.FileHeader) bool {
 func generateDestination(filename string) string {
 	// 可根据需要自定义生成目标文件名的逻辑
 	// 这里简单地以当前时间戳作为文件名
-	return fmt.Sprintf("xxxjz-app/%s/%d%s", config.Config.Env.Profiles, utils.GenID(), filepath.Ext(filename))
+	return fmt.Sprintf("xxxjz-app/%s/%d%s", config.Get().Env.Profiles, utils.GenID(), filepath.Ext(filename))
 }
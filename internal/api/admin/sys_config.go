@@ -49,8 +49,7 @@ func (e SysConfig) Add(context *gin.Context) {
 		e.Error(err)
 		return
 	}
-	//发送消息
-	components.Publish(application.AppContext.GetComponent(components.COMPONENT_REDIS).(redis.UniversalClient), components.CHANNEL_CONFIG_REFRESH, "{}")
+	e.publishRefresh()
 	e.OK(nil)
 }
 
@@ -83,8 +82,7 @@ func (e SysConfig) Update(context *gin.Context) {
 		e.Error(err)
 		return
 	}
-	//发送消息
-	components.Publish(application.AppContext.GetComponent(components.COMPONENT_REDIS).(redis.UniversalClient), components.CHANNEL_CONFIG_REFRESH, "{}")
+	e.publishRefresh()
 	e.OK(nil)
 }
 
@@ -106,12 +104,24 @@ func (e SysConfig) Delete(context *gin.Context) {
 		e.Error(err)
 		return
 	}
-	s.Delete(req.ID)
-	//发送消息
-	components.Publish(application.AppContext.GetComponent(components.COMPONENT_REDIS).(redis.UniversalClient), components.CHANNEL_CONFIG_REFRESH, "{}")
+	err = s.Delete(req.ID)
+	if err != nil {
+		log.ZError(e.Context, "删除配置失败", err)
+		e.Error(err)
+		return
+	}
+	e.publishRefresh()
 	e.OK(nil)
 }
 
+// publishRefresh 递增配置版本并通知所有实例重新加载，失败时各实例会在轮询时补齐
+func (e SysConfig) publishRefresh() {
+	rdb := application.AppContext.GetComponent(components.COMPONENT_REDIS).(redis.UniversalClient)
+	if err := components.PublishConfigRefresh(e.Context, rdb); err != nil {
+		log.ZError(e.Context, "发布配置刷新消息失败", err)
+	}
+}
+
 // @Summary 分页查询配置
 // @Description 分页查询配置
 // @Tags 配置管理
@@ -177,3 +187,171 @@ func (e SysConfig) PageQueryLog(context *gin.Context) {
 	}
 	e.PageOK(list, int(count), req.GetPageIndex(), req.GetPageSize())
 }
+
+// @Summary 查询配置定义
+// @Description 查询代码中注册的配置定义、缺省值、校验规则及当前值
+// @Tags 配置管理
+// @Accept json
+// @Produce json
+// @Success 200 {object} api.Response{data=[]dto.SysConfigDefinitionResp}
+// @Router /sys/config/get_definitions [post]
+// @Security RequireLogin
+func (e SysConfig) GetDefinitions(context *gin.Context) {
+	s := services.SysConfig{}
+	err := e.MakeContext(context).
+		MakeOrm().
+		MakeService(&s.Service).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	list, err := s.GetDefinitions()
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.OK(list)
+}
+
+// @Summary 查询各实例配置版本
+// @Description 查询当前配置版本号及各实例已加载的版本
+// @Tags 配置管理
+// @Accept json
+// @Produce json
+// @Success 200 {object} api.Response{data=dto.SysConfigVersionResp}
+// @Router /sys/config/get_versions [post]
+// @Security RequireLogin
+func (e SysConfig) GetVersions(context *gin.Context) {
+	e.MakeContext(context)
+	myConfig := application.AppContext.GetComponent(components.COMPONENT_MY_CONFIG).(*components.MyConfig)
+	version, instances, err := myConfig.Instances(e.Context)
+	if err != nil {
+		log.ZError(e.Context, "查询配置版本失败", err)
+		e.Error(err)
+		return
+	}
+	resp := dto.SysConfigVersionResp{Version: version, Instances: make([]dto.SysConfigInstanceResp, 0, len(instances))}
+	for _, instance := range instances {
+		resp.Instances = append(resp.Instances, dto.SysConfigInstanceResp{
+			Instance:   instance.Instance,
+			Version:    instance.Version,
+			LoadedAt:   instance.LoadedAt,
+			ReportedAt: instance.ReportedAt,
+			UpToDate:   instance.Version == version,
+		})
+	}
+	e.OK(resp)
+}
+
+// @Summary 比较配置版本
+// @Description 比较配置的两个版本，版本为某条配置日志修改后的值，日志id为0表示当前值
+// @Tags 配置管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysConfigDiffReq true "配置id及两个版本的日志id"
+// @Success 200 {object} api.Response{data=dto.SysConfigDiffResp}
+// @Router /sys/config/diff [post]
+// @Security RequireLogin
+func (e SysConfig) Diff(context *gin.Context) {
+	s := services.SysConfig{}
+	req := dto.SysConfigDiffReq{}
+	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	resp, err := s.Diff(&req)
+	if err != nil {
+		log.ZError(e.Context, "比较配置版本失败", err)
+		e.Error(err)
+		return
+	}
+	e.OK(resp)
+}
+
+// @Summary 回滚配置
+// @Description 把配置回滚到某条配置日志修改前或修改后的值，并记录一条新的配置日志
+// @Tags 配置管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysConfigRollbackReq true "回滚目标"
+// @Success 200 {object} api.Response
+// @Router /sys/config/rollback [post]
+// @Security RequireLogin
+func (e SysConfig) Rollback(context *gin.Context) {
+	s := services.SysConfig{}
+	req := dto.SysConfigRollbackReq{}
+	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	err = s.Rollback(&req)
+	if err != nil {
+		log.ZError(e.Context, "回滚配置失败", err)
+		e.Error(err)
+		return
+	}
+	e.publishRefresh()
+	e.OK(nil)
+}
+
+// @Summary 导出配置
+// @Description 导出配置快照，用于导入到其他环境
+// @Tags 配置管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysConfigExportReq false "要导出的配置名称，为空时导出全部"
+// @Success 200 {object} api.Response{data=dto.SysConfigSnapshot}
+// @Router /sys/config/export [post]
+// @Security RequireLogin
+func (e SysConfig) Export(context *gin.Context) {
+	s := services.SysConfig{}
+	req := dto.SysConfigExportReq{}
+	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	snapshot, err := s.Export(req.Names)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.OK(snapshot)
+}
+
+// @Summary 导入配置
+// @Description 导入配置快照，不存在的新增，有变化的更新，快照中没有的配置保持不变；dryRun为true时只返回将要发生的变更
+// @Tags 配置管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysConfigImportReq true "配置快照"
+// @Success 200 {object} api.Response{data=dto.SysConfigImportResp}
+// @Router /sys/config/import [post]
+// @Security RequireLogin
+func (e SysConfig) Import(context *gin.Context) {
+	s := services.SysConfig{}
+	req := dto.SysConfigImportReq{}
+	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	resp, err := s.Import(&req)
+	if err != nil {
+		log.ZError(e.Context, "导入配置失败", err)
+		e.Error(err)
+		return
+	}
+	if !resp.DryRun && resp.Created+resp.Updated > 0 {
+		e.publishRefresh()
+	}
+	e.OK(resp)
+}
@@ -1,15 +1,26 @@
 package admin
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"io"
+	"net/http"
+	"orderin-server/internal/components"
 	"orderin-server/internal/dto"
 	"orderin-server/internal/models"
 	"orderin-server/internal/services"
 	"orderin-server/pkg/common/api"
+	"orderin-server/pkg/common/application"
+	"orderin-server/pkg/common/clientconst"
 	mcontext "orderin-server/pkg/common/context"
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/i18n"
 	"orderin-server/pkg/common/log"
 	"orderin-server/pkg/common/utils"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
 type SysDict struct {
@@ -46,6 +57,7 @@ func (e SysDict) Add(context *gin.Context) {
 		e.Error(err)
 		return
 	}
+	e.invalidateCache(model.Name)
 	e.OK(nil)
 
 }
@@ -73,15 +85,32 @@ func (e SysDict) Update(context *gin.Context) {
 	currentUserId := mcontext.GetOpUserID(context)
 	model.UpdatedBy = &currentUserId
 
+	dict, err := s.GetById(req.ID)
+	if err != nil {
+		e.Error(err)
+		return
+	}
 	err = s.UpdateSelectiveById(model.ID, model)
+	if err == nil && req.Public != nil {
+		err = s.SetPublic(model.ID, *req.Public)
+	}
 	if err != nil {
 		log.ZError(e.Context, "修改字典失败", err)
 		e.Error(err)
 		return
 	}
+	e.invalidateCache(dict.Name)
 	e.OK(nil)
 }
 
+// invalidateCache 字典变更后使所有实例的缓存失效，失败时等待缓存过期
+func (e SysDict) invalidateCache(names ...string) {
+	dictCache := application.AppContext.GetComponent(components.COMPONENT_DICT_CACHE).(*components.DictCache)
+	if err := dictCache.Invalidate(e.Context, names...); err != nil {
+		log.ZError(e.Context, "使字典缓存失效失败", err, "names", names)
+	}
+}
+
 // @Summary 删除字典
 // @Description 根据id删除字典
 // @Tags 字典管理
@@ -100,7 +129,17 @@ func (e SysDict) Delete(context *gin.Context) {
 		e.Error(err)
 		return
 	}
-	s.Delete(req.ID)
+	dict, err := s.GetById(req.ID)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	if err = s.Delete(req.ID); err != nil {
+		log.ZError(e.Context, "删除字典失败", err)
+		e.Error(err)
+		return
+	}
+	e.invalidateCache(dict.Name)
 	e.OK(nil)
 }
 
@@ -166,6 +205,7 @@ func (e SysDict) AddItem(context *gin.Context) {
 		e.Error(err)
 		return
 	}
+	e.invalidateCache(model.DictName)
 	e.OK(nil)
 }
 
@@ -192,20 +232,27 @@ func (e SysDict) UpdateItem(context *gin.Context) {
 	currentUserId := mcontext.GetOpUserID(e.Context)
 	dictItem.UpdatedBy = &currentUserId
 
+	dbItem, err := s.GetItemById(req.ID)
+	if err != nil {
+		e.Error(err)
+		return
+	}
 	err = s.UpdateItem(dictItem)
 	if err != nil {
 		e.Error(err)
+		return
 	}
+	e.invalidateCache(dbItem.DictName)
 	e.OK(nil)
 }
 
-// @Summary 分页查询字典
-// @Description 分页查询字典
+// @Summary 分页查询字典项
+// @Description 分页查询字典项，tree为true时按树返回，list为dto.SysDictItemTree
 // @Tags 字典管理
 // @Accept json
 // @Produce json
-// @Param param body dto.SysDictPageQueryReq false "字典筛选条件"
-// @Success 200 {object} api.Response{data=api.PageData{List=models.SysDict}}
+// @Param param body dto.SysDictItemPageQueryReq false "字典项筛选条件"
+// @Success 200 {object} api.Response{data=api.PageData{List=models.SysDictItem}}
 // @Router /sys/dict/item/page_query [post]
 // @Security RequireLogin
 func (e SysDict) PageQueryItem(context *gin.Context) {
@@ -221,8 +268,18 @@ func (e SysDict) PageQueryItem(context *gin.Context) {
 		e.Error(err)
 		return
 	}
-	list := make([]models.SysDictItem, 0)
 	var count int64
+	if req.Tree {
+		tree := make([]*dto.SysDictItemTree, 0)
+		err = s.PageQueryItemTree(&req, &tree, &count)
+		if err != nil {
+			e.Error(err)
+			return
+		}
+		e.PageOK(tree, int(count), req.GetPageIndex(), req.GetPageSize())
+		return
+	}
+	list := make([]models.SysDictItem, 0)
 
 	err = s.PageQueryItem(&req, &list, &count)
 	if err != nil {
@@ -232,31 +289,92 @@ func (e SysDict) PageQueryItem(context *gin.Context) {
 	e.PageOK(list, int(count), req.GetPageIndex(), req.GetPageSize())
 }
 
+// @Summary 获取字典项树
+// @Description 获取字典项树，包括已禁用的字典项，可指定从某个字典项的下级开始及返回的层数
+// @Tags 字典管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysDictItemTreeReq true "字典名称"
+// @Success 200 {object} api.Response{data=[]dto.SysDictItemTree}
+// @Router /sys/dict/item/get_tree [post]
+// @Security RequireLogin
+func (e SysDict) GetItemTree(context *gin.Context) {
+	s := services.SysDict{}
+	req := dto.SysDictItemTreeReq{}
+	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	tree, err := s.GetItemTree(&req)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.OK(tree)
+}
+
+// @Summary 获取字典项的完整路径
+// @Description 根据值获取从顶级到该字典项的所有字典项
+// @Tags 字典管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysDictItemPathReq true "字典名称及字典项的值"
+// @Success 200 {object} api.Response{data=[]models.SysDictItem}
+// @Router /sys/dict/item/get_path [post]
+// @Security RequireLogin
+func (e SysDict) GetItemPath(context *gin.Context) {
+	s := services.SysDict{}
+	req := dto.SysDictItemPathReq{}
+	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	path, err := s.GetItemPath(req.DictName, req.Value)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.OK(path)
+}
+
 // @Summary 根据字典名称获取所有项目
 // @Description 根据字典名称获取所有项目
 // @Tags 字典管理
 // @Accept json
 // @Produce json
 // @Param param body dto.SysDictGetItemsReq true "字典名称"
+// @Param If-None-Match header string false "上次响应的ETag，内容未变化时返回304"
 // @Success 200 {object} api.Response{data=dto.SysDictGetItemsResp}
+// @Success 304 "内容未变化"
 // @Router /sys/dict/item/get_by_name [post]
 // @Security RequireLogin
 func (e SysDict) GetItemsByName(context *gin.Context) {
-	s := services.SysDict{}
 	req := dto.SysDictGetItemsReq{}
-	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	err := e.MakeContext(context).Bind(&req, binding.JSON).Errors
 	if err != nil {
 		log.ZError(e.Context, "", err)
 		e.Error(err)
 		return
 	}
-	list, err := s.GetItems(req.Name)
+	dictCache := application.AppContext.GetComponent(components.COMPONENT_DICT_CACHE).(*components.DictCache)
+	entry, err := dictCache.Get(e.Context, req.Name)
 	if err != nil {
 		log.ZError(e.Context, "", err)
 		e.Error(err)
 		return
 	}
-	e.OK(dto.SysDictGetItemsResp{List: list})
+	locale := i18n.Locale(e.Context)
+	etag := services.LocalizedETag(entry.ETag, locale)
+	// 标签随请求语言变化，缓存需按语言区分
+	e.Context.Header("Vary", "Accept-Language")
+	if e.NotModified(etag) {
+		return
+	}
+	e.OK(dto.SysDictGetItemsResp{List: services.LocalizeDictItems(entry.Items, locale), Version: strings.Trim(etag, `"`)})
 }
 
 // @Summary 根据字典名称数组获取所有项目
@@ -265,23 +383,179 @@ func (e SysDict) GetItemsByName(context *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param param body dto.SysDictGetItemsReq true "字典名称数组"
+// @Param If-None-Match header string false "上次响应的ETag，内容未变化时返回304"
 // @Success 200 {object} api.Response{data=map[string][]models.SysDictItem}
+// @Success 304 "内容未变化"
 // @Router /sys/dict/item/batch_get_by_names [post]
 // @Security RequireLogin
 func (e SysDict) BatchGetItemsByNames(context *gin.Context) {
-	s := services.SysDict{}
 	req := dto.SysDictBatchGetItemsReq{}
-	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	err := e.MakeContext(context).Bind(&req, binding.JSON).Errors
 	if err != nil {
 		log.ZError(e.Context, "", err)
 		e.Error(err)
 		return
 	}
-	mapping, err := s.BatchGetItems(req.Names)
+	dictCache := application.AppContext.GetComponent(components.COMPONENT_DICT_CACHE).(*components.DictCache)
+	entries, etag, err := dictCache.BatchGet(e.Context, req.Names)
+	locale := i18n.Locale(e.Context)
+	etag = services.LocalizedETag(etag, locale)
+	e.Context.Header("Vary", "Accept-Language")
 	if err != nil {
 		log.ZError(e.Context, "", err)
 		e.Error(err)
 		return
 	}
+	if e.NotModified(etag) {
+		return
+	}
+	mapping := make(map[string][]models.SysDictItem, len(entries))
+	for name, entry := range entries {
+		mapping[name] = services.LocalizeDictItems(entry.Items, locale)
+	}
 	e.OK(mapping)
 }
+
+// @Summary 导出字典
+// @Description 导出字典及字典项为json、yaml或xlsx文件，可用于环境间迁移或作为种子文件
+// @Tags 字典管理
+// @Accept json
+// @Produce octet-stream
+// @Param param body dto.SysDictExportReq true "要导出的字典名称及格式"
+// @Success 200 {file} file
+// @Router /sys/dict/export [post]
+// @Security RequireLogin
+func (e SysDict) Export(context *gin.Context) {
+	s := services.SysDict{}
+	req := dto.SysDictExportReq{}
+	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	bundle, err := s.ExportDicts(req.Names)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	data, err := services.EncodeDictBundle(bundle, req.Format)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	fileName := fmt.Sprintf("dicts-%s.%s", time.Now().Format("20060102150405"), req.Format)
+	context.Header("Content-Disposition", "attachment; filename="+fileName)
+	context.Data(http.StatusOK, dictContentTypes[req.Format], data)
+}
+
+// @Summary 获取前端常量
+// @Description 由字典和错误码生成前端枚举，与 orderin gen client-constants 的结果一致，可在构建时校验前端代码中的常量
+// @Tags 字典管理
+// @Accept json
+// @Produce json,plain
+// @Param param body dto.SysDictClientConstantsReq true "字典名称及格式"
+// @Success 200 {object} api.Response{data=clientconst.Constants}
+// @Router /sys/dict/client_constants [post]
+// @Security RequireLogin
+func (e SysDict) ClientConstants(context *gin.Context) {
+	s := services.SysDict{}
+	req := dto.SysDictClientConstantsReq{}
+	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	constants, err := s.ClientConstants(req.Names)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	switch req.Format {
+	case "ts":
+		context.Data(http.StatusOK, "text/plain; charset=utf-8", clientconst.TypeScript(constants))
+	case "go":
+		if req.GoPackage == "" {
+			req.GoPackage = "constants"
+		}
+		data, err := clientconst.Go(constants, req.GoPackage)
+		if err != nil {
+			e.Error(errs.NewCodeError(errs.ArgsError, "包名不合法"))
+			return
+		}
+		context.Data(http.StatusOK, "text/plain; charset=utf-8", data)
+	default:
+		e.OK(constants)
+	}
+}
+
+var dictContentTypes = map[string]string{
+	services.DictFormatJSON: "application/json",
+	services.DictFormatYAML: "application/x-yaml",
+	services.DictFormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// @Summary 导入字典
+// @Description 从json、yaml或xlsx文件导入字典，格式由文件扩展名决定。不存在的新增；已存在且内容不同的，upsert时覆盖，否则跳过并返回冲突
+// @Tags 字典管理
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "字典文件"
+// @Param upsert formData bool false "是否覆盖已存在的字典和字典项"
+// @Param dryRun formData bool false "是否只预览变更"
+// @Success 200 {object} api.Response{data=dto.SysDictImportResp}
+// @Router /sys/dict/import [post]
+// @Security RequireLogin
+func (e SysDict) Import(context *gin.Context) {
+	s := services.SysDict{}
+	req := dto.SysDictImportReq{}
+	err := e.MakeContext(context).Bind(&req, binding.Form).MakeOrm().MakeService(&s.Service).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	file, err := context.FormFile("file")
+	if err != nil {
+		e.Error(errs.NewCodeError(errs.ArgsError, "请上传字典文件"))
+		return
+	}
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+	if format == "yml" {
+		format = services.DictFormatYAML
+	}
+	if _, ok := dictContentTypes[format]; !ok {
+		e.Error(errs.NewCodeError(errs.ArgsError, "只支持json、yaml、xlsx文件"))
+		return
+	}
+	src, err := file.Open()
+	if err != nil {
+		e.Error(errs.NewCodeError(errs.ServerInternalError, "读取文件失败"))
+		return
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		e.Error(errs.NewCodeError(errs.ServerInternalError, "读取文件失败"))
+		return
+	}
+	bundle, err := services.DecodeDictBundle(data, format)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	resp, err := s.ImportDicts(bundle, req.Upsert, req.DryRun)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	if !resp.DryRun {
+		e.invalidateCache(resp.Dicts...)
+	}
+	e.OK(resp)
+}
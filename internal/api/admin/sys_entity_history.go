@@ -0,0 +1,48 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"orderin-server/internal/dto"
+	"orderin-server/internal/models"
+	"orderin-server/internal/services"
+	"orderin-server/pkg/common/api"
+	"orderin-server/pkg/common/log"
+)
+
+type SysEntityHistory struct {
+	api.Api
+}
+
+// @Summary 查询实体变更历史
+// @Description 按时间倒序查询任意实体的字段变更时间线，entityType如sys_user、sys_config
+// @Tags 变更历史
+// @Accept json
+// @Produce json
+// @Param param body dto.SysEntityHistoryTimelineReq true "实体类型和实体id"
+// @Success 200 {object} api.Response{data=api.PageData{List=models.SysEntityHistory}}
+// @Router /sys/entity_history/get_timeline [post]
+// @Security RequireLogin
+func (e SysEntityHistory) GetTimeline(context *gin.Context) {
+	s := services.SysEntityHistory{}
+	req := dto.SysEntityHistoryTimelineReq{}
+	err := e.MakeContext(context).
+		MakeOrm().
+		Bind(&req, binding.JSON).
+		MakeService(&s.Service).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	list := make([]models.SysEntityHistory, 0)
+	var count int64
+
+	err = s.Timeline(&req, &list, &count)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.PageOK(list, int(count), req.GetPageIndex(), req.GetPageSize())
+}
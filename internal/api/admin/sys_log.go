@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/redis/go-redis/v9"
+	"orderin-server/internal/components"
+	"orderin-server/internal/dto"
+	"orderin-server/pkg/common/api"
+	"orderin-server/pkg/common/application"
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/log"
+)
+
+type SysLog struct {
+	api.Api
+}
+
+// @Summary 修改日志级别
+// @Description 修改所有实例的日志级别，ttl大于0时到期自动恢复
+// @Tags 日志管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysLogSetLevelReq true "日志级别"
+// @Success 200 {object} api.Response
+// @Router /sys/log/set_level [post]
+// @Security RequireLogin
+func (e SysLog) SetLevel(context *gin.Context) {
+	req := dto.SysLogSetLevelReq{}
+	err := e.MakeContext(context).
+		Bind(&req, binding.JSON).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	if !req.Reset {
+		if err = log.CheckLevel(req.Module, req.Level); err != nil {
+			e.Error(errs.NewCodeError(errs.ArgsError, err.Error()))
+			return
+		}
+	}
+	rdb := application.AppContext.GetComponent(components.COMPONENT_REDIS).(redis.UniversalClient)
+	err = components.PublishLogLevel(rdb, components.LogLevelMessage{
+		Module: req.Module,
+		Level:  req.Level,
+		Ttl:    req.Ttl,
+		Reset:  req.Reset,
+	})
+	if err != nil {
+		log.ZError(e.Context, "广播日志级别失败", err)
+		e.Error(err)
+		return
+	}
+	e.OK(nil)
+}
+
+// @Summary 查询日志级别
+// @Description 查询当前实例各logger的日志级别
+// @Tags 日志管理
+// @Accept json
+// @Produce json
+// @Success 200 {object} api.Response{data=[]log.LevelInfo}
+// @Router /sys/log/get_levels [post]
+// @Security RequireLogin
+func (e SysLog) GetLevels(context *gin.Context) {
+	e.MakeContext(context)
+	e.OK(log.Levels())
+}
@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"orderin-server/internal/dto"
+	"orderin-server/internal/models"
+	"orderin-server/internal/services"
+	"orderin-server/pkg/common/api"
+	mcontext "orderin-server/pkg/common/context"
+	"orderin-server/pkg/common/log"
+)
+
+type SysLoginLog struct {
+	api.Api
+}
+
+// @Summary 分页查询登录日志
+// @Description 分页查询登录日志
+// @Tags 登录日志
+// @Accept json
+// @Produce json
+// @Param param body dto.SysLoginLogPageQueryReq false "登录日志筛选条件"
+// @Success 200 {object} api.Response{data=api.PageData{List=models.SysLoginLog}}
+// @Router /sys/login_log/page_query [post]
+// @Security RequireLogin
+func (e SysLoginLog) PageQuery(context *gin.Context) {
+	s := services.SysLoginLog{}
+	req := dto.SysLoginLogPageQueryReq{}
+	err := e.MakeContext(context).
+		MakeOrm().
+		Bind(&req, binding.JSON).
+		MakeService(&s.Service).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	list := make([]models.SysLoginLog, 0)
+	var count int64
+
+	err = s.PageQuery(&req, &list, &count)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.PageOK(list, int(count), req.GetPageIndex(), req.GetPageSize())
+}
+
+// @Summary 游标分页查询登录日志
+// @Description 按游标分页查询登录日志，适用于数据量大的场景。nextCursor 为空表示没有下一页，count 为 exact 或 estimate 时返回总数
+// @Tags 登录日志
+// @Accept json
+// @Produce json
+// @Param param body dto.SysLoginLogCursorQueryReq false "登录日志筛选条件"
+// @Success 200 {object} api.Response{data=api.CursorPageData{List=models.SysLoginLog}}
+// @Router /sys/login_log/cursor_query [post]
+// @Security RequireLogin
+func (e SysLoginLog) CursorQuery(context *gin.Context) {
+	s := services.SysLoginLog{}
+	req := dto.SysLoginLogCursorQueryReq{}
+	err := e.MakeContext(context).
+		MakeOrm().
+		Bind(&req, binding.JSON).
+		MakeService(&s.Service).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	list := make([]models.SysLoginLog, 0)
+	nextCursor, total, err := s.CursorQuery(&req, &list)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.CursorPageOK(list, nextCursor, total, req.GetPageSize())
+}
+
+// @Summary 分页查询我的登录日志
+// @Description 分页查询当前用户的登录日志
+// @Tags 登录日志
+// @Accept json
+// @Produce json
+// @Param param body dto.SysLoginLogPersonalPageQueryReq false "登录日志筛选条件"
+// @Success 200 {object} api.Response{data=api.PageData{List=models.SysLoginLog}}
+// @Router /sys/login_log/page_query_personal [post]
+// @Security RequireLogin
+func (e SysLoginLog) PageQueryPersonal(context *gin.Context) {
+	s := services.SysLoginLog{}
+	req := dto.SysLoginLogPersonalPageQueryReq{}
+	err := e.MakeContext(context).
+		MakeOrm().
+		Bind(&req, binding.JSON).
+		MakeService(&s.Service).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	list := make([]models.SysLoginLog, 0)
+	var count int64
+
+	err = s.PageQueryPersonal(mcontext.GetOpUserID(context), &req, &list, &count)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.PageOK(list, int(count), req.GetPageIndex(), req.GetPageSize())
+}
@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"orderin-server/internal/dto"
+	"orderin-server/internal/models"
+	"orderin-server/internal/services"
+	"orderin-server/pkg/common/api"
+	"orderin-server/pkg/common/log"
+)
+
+type SysOperationLog struct {
+	api.Api
+}
+
+// @Summary 分页查询操作日志
+// @Description 分页查询操作日志
+// @Tags 操作日志
+// @Accept json
+// @Produce json
+// @Param param body dto.SysOperationLogPageQueryReq false "操作日志筛选条件"
+// @Success 200 {object} api.Response{data=api.PageData{List=models.SysOperationLog}}
+// @Router /sys/operation_log/page_query [post]
+// @Security RequireLogin
+func (e SysOperationLog) PageQuery(context *gin.Context) {
+	s := services.SysOperationLog{}
+	req := dto.SysOperationLogPageQueryReq{}
+	err := e.MakeContext(context).
+		MakeOrm().
+		Bind(&req, binding.JSON).
+		MakeService(&s.Service).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	list := make([]models.SysOperationLog, 0)
+	var count int64
+
+	err = s.PageQuery(&req, &list, &count)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.PageOK(list, int(count), req.GetPageIndex(), req.GetPageSize())
+}
+
+// @Summary 游标分页查询操作日志
+// @Description 按游标分页查询操作日志，适用于数据量大的场景。nextCursor 为空表示没有下一页，count 为 exact 或 estimate 时返回总数
+// @Tags 操作日志
+// @Accept json
+// @Produce json
+// @Param param body dto.SysOperationLogCursorQueryReq false "操作日志筛选条件"
+// @Success 200 {object} api.Response{data=api.CursorPageData{List=models.SysOperationLog}}
+// @Router /sys/operation_log/cursor_query [post]
+// @Security RequireLogin
+func (e SysOperationLog) CursorQuery(context *gin.Context) {
+	s := services.SysOperationLog{}
+	req := dto.SysOperationLogCursorQueryReq{}
+	err := e.MakeContext(context).
+		MakeOrm().
+		Bind(&req, binding.JSON).
+		MakeService(&s.Service).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	list := make([]models.SysOperationLog, 0)
+	nextCursor, total, err := s.CursorQuery(&req, &list)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.CursorPageOK(list, nextCursor, total, req.GetPageSize())
+}
@@ -7,13 +7,18 @@ import (
 	"orderin-server/internal/models"
 	"orderin-server/internal/services"
 	"orderin-server/pkg/common/api"
-	mcontext "orderin-server/pkg/common/context"
 	"orderin-server/pkg/common/log"
-	"orderin-server/pkg/common/utils"
 )
 
 type SysRole struct {
-	api.Api
+	api.CrudApi[models.SysRole, dto.SysRolePageQueryReq, dto.SysRoleAddReq, dto.SysRoleUpdateReq]
+}
+
+// NewSysRole 角色接口，增删改查使用 services.SysRoleHooks
+func NewSysRole() SysRole {
+	e := SysRole{}
+	e.Hooks = services.SysRoleHooks
+	return e
 }
 
 // @Summary 添加角色
@@ -26,27 +31,7 @@ type SysRole struct {
 // @Router /sys/role/add [post]
 // @Security RequireLogin
 func (e SysRole) Add(context *gin.Context) {
-	s := services.SysRole{}
-	req := dto.SysRoleAddReq{}
-	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
-	if err != nil {
-		log.ZError(e.Context, "", err)
-		e.Error(err)
-		return
-	}
-
-	role := models.SysRole{}
-	utils.CopyStructFields(&role, req)
-	currentUserId := mcontext.GetOpUserID(context)
-	role.CreatedBy = &currentUserId
-
-	err = s.Insert(&role)
-	if err != nil {
-		log.ZError(e.Context, "添加角色失败", err)
-		e.Error(err)
-		return
-	}
-	e.OK(nil)
+	e.CrudApi.Add(context)
 }
 
 // @Summary 修改角色
@@ -59,26 +44,7 @@ func (e SysRole) Add(context *gin.Context) {
 // @Router /sys/role/update [post]
 // @Security RequireLogin
 func (e SysRole) Update(context *gin.Context) {
-	s := services.SysRole{}
-	req := dto.SysRoleUpdateReq{}
-	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
-	if err != nil {
-		log.ZError(e.Context, "", err)
-		e.Error(err)
-		return
-	}
-	role := models.SysRole{}
-	utils.CopyStructFields(&role, req)
-	currentUserId := mcontext.GetOpUserID(context)
-	role.UpdatedBy = &currentUserId
-
-	err = s.UpdateSelectiveById(role.ID, role)
-	if err != nil {
-		log.ZError(e.Context, "更新角色失败", err)
-		e.Error(err)
-		return
-	}
-	e.OK(nil)
+	e.CrudApi.Update(context)
 }
 
 // @Summary 删除角色
@@ -91,16 +57,20 @@ func (e SysRole) Update(context *gin.Context) {
 // @Router /sys/role/delete [post]
 // @Security RequireLogin
 func (e SysRole) Delete(context *gin.Context) {
-	s := services.SysRole{}
-	req := dto.SysRoleDeleteReq{}
-	err := e.MakeContext(context).Bind(&req, binding.JSON).MakeOrm().MakeService(&s.Service).Errors
-	if err != nil {
-		log.ZError(e.Context, "", err)
-		e.Error(err)
-		return
-	}
-	s.Delete(req.ID)
-	e.OK(nil)
+	e.CrudApi.Delete(context)
+}
+
+// @Summary 获取角色
+// @Description 根据id获取角色
+// @Tags 角色管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysRoleGetReq false "角色ID"
+// @Success 200 {object} api.Response{data=models.SysRole}
+// @Router /sys/role/get [post]
+// @Security RequireLogin
+func (e SysRole) Get(context *gin.Context) {
+	e.CrudApi.Get(context)
 }
 
 // @Summary 根据名字获取角色
@@ -144,27 +114,7 @@ func (e SysRole) GetByName(context *gin.Context) {
 // @Router /sys/role/page_query [post]
 // @Security RequireLogin
 func (e SysRole) PageQuery(context *gin.Context) {
-	s := services.SysRole{}
-	req := dto.SysRolePageQueryReq{}
-	err := e.MakeContext(context).
-		MakeOrm().
-		Bind(&req, binding.JSON).
-		MakeService(&s.Service).
-		Errors
-	if err != nil {
-		log.ZError(e.Context, "", err)
-		e.Error(err)
-		return
-	}
-	list := make([]models.SysRole, 0)
-	var count int64
-
-	err = s.PageQuery(&req, &list, &count)
-	if err != nil {
-		e.Error(err)
-		return
-	}
-	e.PageOK(list, int(count), req.GetPageIndex(), req.GetPageSize())
+	e.CrudApi.PageQuery(context)
 }
 
 // @Summary 给角色授予权限
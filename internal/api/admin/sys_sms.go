@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"go.opentelemetry.io/otel/attribute"
+	"orderin-server/internal/components"
 	"orderin-server/internal/dto"
 	"orderin-server/internal/models"
 	"orderin-server/internal/services"
@@ -13,7 +15,9 @@ import (
 	mcontext "orderin-server/pkg/common/context"
 	"orderin-server/pkg/common/errs"
 	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/prommetrics"
 	"orderin-server/pkg/common/sms"
+	"orderin-server/pkg/common/tracing"
 	"orderin-server/pkg/common/utils"
 	"strings"
 	"time"
@@ -156,26 +160,33 @@ func (e SysSms) sendValidateSms(phone string, scene string, smsLog *models.SysSm
 	template := "您的验证码为：${code}，请勿泄露于他人"
 	content := replaceTemplateVariable(template, variables)
 
-	smsLog.Vendor = string(sms.SmsVendorInstance.GetVendorType())
-	smsLog.TemplateCode = "SMS_296350564"
+	vendor := sms.GetSmsVendor()
+	signName := components.ConfigValue(e.Context, components.CONFIG_SMS_SIGN_NAME, "小西西家政")
+	smsLog.Vendor = string(vendor.GetVendorType())
+	smsLog.TemplateCode = components.ConfigValue(e.Context, components.CONFIG_SMS_VERIFY_CODE_TEMPLATE, "SMS_296350564")
 	smsLog.Content = content
 	smsLog.Scene = scene
 
 	//缓存验证码
-	err := e.VerifyCodeCache.StoreCode(phone, scene, code, 10*time.Minute)
+	ttl := components.ConfigValue(e.Context, components.CONFIG_SMS_VERIFY_CODE_TTL, 10)
+	err := e.VerifyCodeCache.StoreCode(phone, scene, code, time.Duration(ttl)*time.Minute)
 	if err != nil {
 		return errs.NewCodeError(errs.ServerInternalError, "短信发送失败").Wrap()
 	}
 	//发送短信
-	resp, err := sms.SmsVendorInstance.Send(phone, "小西西家政", "SMS_296350564", variables)
+	_, span := tracing.Start(e.Context, "sms.Send", attribute.String("sms.vendor", smsLog.Vendor), attribute.String("sms.template", smsLog.TemplateCode))
+	resp, err := vendor.Send(phone, signName, smsLog.TemplateCode, variables)
+	tracing.End(span, err)
 
 	json, _ := json.Marshal(resp)
 	log.ZInfo(e.Context, "短信发送结果", "resp", string(json))
 	if err != nil || resp == nil || resp.StatusCode == nil || *resp.StatusCode != 200 || resp.Code == nil || *resp.Code != "OK" {
+		prommetrics.SmsSendTotal.WithLabelValues(smsLog.Vendor, prommetrics.ResultFail).Inc()
 		smsLog.Status = "1"
 		smsLog.Remark = err.Error()
 		return errs.NewCodeError(errs.ServerInternalError, "短信发送失败").WithDetail(err.Error())
 	} else {
+		prommetrics.SmsSendTotal.WithLabelValues(smsLog.Vendor, prommetrics.ResultSuccess).Inc()
 		smsLog.Status = "2"
 		smsLog.Remark = "SUCCESS"
 	}
@@ -222,3 +233,34 @@ func (e SysSms) PageQuery(context *gin.Context) {
 	}
 	e.PageOK(list, int(count), req.GetPageIndex(), req.GetPageSize())
 }
+
+// @Summary 游标分页查询短信记录
+// @Description 按游标分页查询短信记录，适用于数据量大的场景。nextCursor 为空表示没有下一页，count 为 exact 或 estimate 时返回总数
+// @Tags 短信管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysSmsLogCursorQueryReq false "短信记录筛选条件"
+// @Success 200 {object} api.Response{data=api.CursorPageData{List=models.SysSmsLog}}
+// @Router /sys/sms/cursor_query [post]
+// @Security RequireLogin
+func (e SysSms) CursorQuery(context *gin.Context) {
+	s := services.SysSms{}
+	req := dto.SysSmsLogCursorQueryReq{}
+	err := e.MakeContext(context).
+		MakeOrm().
+		Bind(&req, binding.JSON).
+		MakeService(&s.Service).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	list := make([]models.SysSmsLog, 0)
+	nextCursor, total, err := s.CursorQuery(&req, &list)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.CursorPageOK(list, nextCursor, total, req.GetPageSize())
+}
@@ -1,11 +1,14 @@
 package admin
 
 import (
+	context2 "context"
+	"errors"
 	"fmt"
 	fmt2 "github.com/ArtisanCloud/PowerLibs/v3/fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/pquerna/otp/totp"
+	"gorm.io/gorm"
 	"net/url"
 	"orderin-server/internal/components"
 	"orderin-server/internal/dto"
@@ -21,7 +24,10 @@ import (
 	"orderin-server/pkg/common/customtypes"
 	"orderin-server/pkg/common/email"
 	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/i18n"
 	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/network"
+	"orderin-server/pkg/common/prommetrics"
 	"orderin-server/pkg/common/utils"
 	"orderin-server/pkg/common/weixin"
 	"time"
@@ -195,17 +201,35 @@ func (e SysUser) LoginByPassword(context *gin.Context) {
 		return
 	}
 	user, err := s.GetByUserName(req.Username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		e.recordLoginFail(context, 0, req.Username, constant.LoginMethodPassword, "账号不存在")
+		e.Error(errs.NewCodeError(errs.AccountOrPasswordError, "账号或密码错误"))
+		return
+	}
 	if err != nil {
 		log.ZError(e.Context, "根据用户名查询用户失败", err)
-		e.Error(errs.NewCodeError(errs.AccountOrPasswordError, "账号或密码错误"))
+		e.Error(err)
 		return
 	}
 	password := utils.Md5(req.Password)
 	if user.PasswordSalt != password {
+		e.recordLoginFail(context, user.ID, req.Username, constant.LoginMethodPassword, "密码错误")
 		e.Error(errs.NewCodeError(errs.AccountOrPasswordError, "账号或密码错误"))
 		return
 	}
-	resp, err := e.Login(context, s, user)
+	if user.EnableMFA && len(user.MFAKey) > 0 {
+		//开启了二步验证的用户密码正确后还需通过 login_by_mfa 校验TOTP密码
+		ticket := utils.Int64ToString(utils.GenID())
+		err = application.AppContext.GetComponent(components.COMPONENT_MY_CACHE).(*components.MyCache).SetMfaLoginTicket(ticket, user.ID, req.Username)
+		if err != nil {
+			log.ZError(e.Context, "保存二步验证凭证失败", err)
+			e.Error(err)
+			return
+		}
+		e.OK(dto.SysUserLoginResp{MfaRequired: true, MfaTicket: ticket})
+		return
+	}
+	resp, err := e.Login(context, s, user, req.Username, constant.LoginMethodPassword)
 	if err != nil {
 		log.ZError(e.Context, "", err)
 		e.Error(err)
@@ -214,7 +238,66 @@ func (e SysUser) LoginByPassword(context *gin.Context) {
 	e.OK(resp)
 }
 
-func (e SysUser) Login(context *gin.Context, userService services.SysUser, user *models.SysUser) (*dto.SysUserLoginResp, error) {
+// @Summary 二步验证登录
+// @Description 开启二步验证的用户账号密码登录后，用返回的凭证和TOTP密码完成登录
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param param body dto.SysUserLoginByMfaReq true "凭证和TOTP密码"
+// @Success 200 {object} api.Response{data=dto.SysUserLoginResp}
+// @Router /sys/user/login_by_mfa [post]
+func (e SysUser) LoginByMfa(context *gin.Context) {
+	s := services.SysUser{}
+	req := dto.SysUserLoginByMfaReq{}
+	err := e.MakeContext(context).
+		MakeOrm().
+		Bind(&req, binding.JSON).
+		MakeService(&s.Service).
+		Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	myCache := application.AppContext.GetComponent(components.COMPONENT_MY_CACHE).(*components.MyCache)
+	userId, account, err := myCache.GetMfaLoginTicket(req.Ticket)
+	if err != nil {
+		log.ZError(e.Context, "获取二步验证凭证失败", err)
+		e.Error(err)
+		return
+	}
+	if userId == 0 {
+		e.Error(errs.NewCodeError(errs.TokenExpiredError, "登录已过期，请重新登录"))
+		return
+	}
+	user, err := s.GetById(userId)
+	if err != nil {
+		log.ZError(e.Context, "获取用户信息失败", err)
+		e.Error(err)
+		return
+	}
+	totpCode, err := totp.GenerateCode(user.MFAKey, time.Now())
+	if err != nil {
+		log.ZError(e.Context, "生成TOTP密码失败", err)
+		e.Error(err)
+		return
+	}
+	if totpCode != req.Code {
+		e.recordLoginFail(context, user.ID, account, constant.LoginMethodMFA, "TOTP密码错误")
+		e.Error(errs.ErrMFACode)
+		return
+	}
+	myCache.DelMfaLoginTicket(req.Ticket)
+	resp, err := e.Login(context, s, user, account, constant.LoginMethodMFA)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	e.OK(resp)
+}
+
+func (e SysUser) Login(context *gin.Context, userService services.SysUser, user *models.SysUser, account string, method string) (*dto.SysUserLoginResp, error) {
 	now := customtypes.Time(time.Now())
 	updateUser := models.SysUser{
 		LastLoginIp:   mcontext.GetRemoteAddr(context),
@@ -224,7 +307,7 @@ func (e SysUser) Login(context *gin.Context, userService services.SysUser, user
 	token, err := e.AuthService.CreateToken(context, user.ID, user.SuperAdmin, constant.AdminPlatformID)
 	loginResp := dto.SysUserLoginResp{
 		Token:             token,
-		ExpireTimeSeconds: config.Config.TokenPolicy.Expire * 24 * 60 * 60,
+		ExpireTimeSeconds: config.Get().TokenPolicy.Expire * 24 * 60 * 60,
 	}
 	permissions, err := userService.GetPermissionsByUserId(user.ID)
 	if err != nil {
@@ -232,9 +315,80 @@ func (e SysUser) Login(context *gin.Context, userService services.SysUser, user
 		return nil, err
 	}
 	application.AppContext.GetComponent(components.COMPONENT_MY_CACHE).(*components.MyCache).SetMyPermissions(user.ID, permissions)
+	e.recordLoginSuccess(context, user, account, method)
 	return &loginResp, nil
 }
 
+func (e SysUser) newLoginLog(context *gin.Context, userId int64, account string, method string) *models.SysLoginLog {
+	ip := mcontext.GetRemoteAddr(context)
+	ua := context.Request.UserAgent()
+	if len(ua) > 500 {
+		ua = ua[:500]
+	}
+	uaInfo := network.ParseUserAgent(ua)
+	return &models.SysLoginLog{
+		UserID:    userId,
+		Account:   account,
+		Method:    method,
+		Ip:        ip,
+		Location:  network.LookupLocation(ip),
+		UserAgent: ua,
+		Device:    uaInfo.Device,
+		Os:        uaInfo.Os,
+		Browser:   uaInfo.Browser,
+	}
+}
+
+// recordLoginFail 记录登录失败日志，userId未知时传0
+func (e SysUser) recordLoginFail(context *gin.Context, userId int64, account string, method string, reason string) {
+	loginLog := e.newLoginLog(context, userId, account, method)
+	loginLog.FailReason = reason
+	prommetrics.LoginTotal.WithLabelValues(method, prommetrics.ResultFail).Inc()
+	loginLogService := services.SysLoginLog{}
+	loginLogService.Orm = e.Orm
+	loginLogService.Context = e.Context
+	if err := loginLogService.Insert(loginLog); err != nil {
+		log.ZError(e.Context, "记录登录日志失败", err)
+	}
+}
+
+// recordLoginSuccess 记录登录成功日志，新设备或新地点登录时邮件提醒用户
+func (e SysUser) recordLoginSuccess(context *gin.Context, user *models.SysUser, account string, method string) {
+	loginLog := e.newLoginLog(context, user.ID, account, method)
+	loginLog.Success = true
+	prommetrics.LoginTotal.WithLabelValues(method, prommetrics.ResultSuccess).Inc()
+	loginLogService := services.SysLoginLog{}
+	loginLogService.Orm = e.Orm
+	loginLogService.Context = e.Context
+	isNewDevice, err := loginLogService.IsNewDevice(loginLog)
+	if err != nil {
+		log.ZError(e.Context, "判断是否新设备登录失败", err)
+	}
+	if err = loginLogService.Insert(loginLog); err != nil {
+		log.ZError(e.Context, "记录登录日志失败", err)
+	}
+	vendor := email.GetEmailVendor()
+	if !isNewDevice || !config.Get().LoginLog.NewDeviceAlert || len(user.Email) == 0 || vendor == nil {
+		return
+	}
+	go func() {
+		request := email.EmailRequest{
+			To:               []string{user.Email},
+			Subject:          "新设备登录提醒",
+			TemplateFileName: "login_alert.html",
+			Params: map[string]string{
+				"time":     time.Now().Format(time.DateTime),
+				"ip":       loginLog.Ip,
+				"location": loginLog.Location,
+				"device":   fmt.Sprintf("%s %s %s", loginLog.Device, loginLog.Os, loginLog.Browser),
+			},
+		}
+		if err := vendor.Send(request); err != nil {
+			log.ZError(context2.Background(), "发送新设备登录提醒失败", err, "userId", user.ID)
+		}
+	}()
+}
+
 // @Summary 手机号登录
 // @Description 手机号登录
 // @Tags 用户管理
@@ -259,10 +413,14 @@ func (e SysUser) LoginByPhone(context *gin.Context) {
 	user, err := s.GetByPhone(req.Phone)
 	if err != nil {
 		log.ZError(e.Context, "根据手机号查询用户失败", err)
+		if codeErr := errs.ErrCode(err); codeErr != nil && codeErr.Code() == errs.AccountNotExistError {
+			e.recordLoginFail(context, 0, req.Phone, constant.LoginMethodPhone, "账号不存在")
+		}
 		e.Error(errs.NewCodeError(errs.ServerInternalError, "服务器错误").WithDetail(err.Error()))
 		return
 	}
 	if user == nil {
+		e.recordLoginFail(context, 0, req.Phone, constant.LoginMethodPhone, "账号不存在")
 		e.Error(errs.NewCodeError(errs.AccountNotExistError, "账号不存在"))
 		return
 	}
@@ -272,11 +430,12 @@ func (e SysUser) LoginByPhone(context *gin.Context) {
 		return
 	}
 	if !pass {
+		e.recordLoginFail(context, user.ID, req.Phone, constant.LoginMethodPhone, "验证码错误")
 		e.Error(errs.NewCodeError(errs.SmsCodeError, "验证码错误"))
 		return
 	}
 
-	resp, err := e.Login(context, s, user)
+	resp, err := e.Login(context, s, user, req.Phone, constant.LoginMethodPhone)
 	if err != nil {
 		log.ZError(e.Context, "", err)
 		e.Error(err)
@@ -474,6 +633,10 @@ func (e SysUser) PerfectInfo(context *gin.Context) {
 		e.Error(err)
 		return
 	}
+	if req.Language != "" && !i18n.Supported(req.Language) {
+		e.Error(errs.NewCodeError(errs.ArgsError, "不支持的语言："+req.Language))
+		return
+	}
 	currentUserId := mcontext.GetOpUserID(context)
 	updateUser := models.SysUser{}
 	utils.CopyStructFields(&updateUser, req)
@@ -485,6 +648,12 @@ func (e SysUser) PerfectInfo(context *gin.Context) {
 		e.Error(err)
 		return
 	}
+	if req.Language != "" {
+		myCache := application.AppContext.GetComponent(components.COMPONENT_MY_CACHE).(*components.MyCache)
+		if err := myCache.SetLanguage(currentUserId, req.Language); err != nil {
+			log.ZError(e.Context, "缓存偏好语言失败", err)
+		}
+	}
 	e.OK(nil)
 }
 
@@ -705,8 +874,8 @@ func (e SysUser) GetPersonalInfo(context *gin.Context) {
 func (e SysUser) GetOAuth2Url(context *gin.Context) {
 	e.MakeContext(context)
 
-	appId := config.Config.WxOfficialAccount.AppID
-	componentAppid := config.Config.WxOpenPlatform.AppID
+	appId := config.Get().WxOfficialAccount.AppID
+	componentAppid := config.Get().WxOpenPlatform.AppID
 	redirectURL := "https://" + context.Request.Host + "/api/v1/sys/user/bind_wechat"
 	encodedURL := url.QueryEscape(redirectURL)
 
@@ -730,8 +899,8 @@ func (e SysUser) GetOAuth2Url(context *gin.Context) {
 func (e SysUser) GetWxLoginUrl(context *gin.Context) {
 	e.MakeContext(context)
 
-	appId := config.Config.WxOfficialAccount.AppID
-	componentAppid := config.Config.WxOpenPlatform.AppID
+	appId := config.Get().WxOfficialAccount.AppID
+	componentAppid := config.Get().WxOpenPlatform.AppID
 	redirectURL := "https://" + context.Request.Host + "/api/v1/sys/user/login_by_wechat"
 	encodedURL := url.QueryEscape(redirectURL)
 
@@ -776,7 +945,7 @@ func (e SysUser) BindWechat(context *gin.Context) {
 		e.Error(err)
 		return
 	}
-	appId := config.Config.WxOfficialAccount.AppID
+	appId := config.Get().WxOfficialAccount.AppID
 	authorizer, err := authorizerService.GetByAppID(appId)
 	if err != nil {
 		log.ZError(e.Context, "", err)
@@ -834,7 +1003,7 @@ func (e SysUser) LoginByWechat(context *gin.Context) {
 		e.Error(err)
 		return
 	}
-	appId := config.Config.WxOfficialAccount.AppID
+	appId := config.Get().WxOfficialAccount.AppID
 	authorizer, err := authorizerService.GetByAppID(appId)
 	if err != nil {
 		log.ZError(e.Context, "", err)
@@ -861,7 +1030,9 @@ func (e SysUser) LoginByWechat(context *gin.Context) {
 		return
 	}
 	if sysUser == nil {
+		e.recordLoginFail(context, 0, openID, constant.LoginMethodWechat, "微信未绑定账号")
 		e.Error(errs.NewCodeError(errs.AccountNotExistError, "Cannot find account bind with current wechat"))
+		return
 	}
 	application.AppContext.GetComponent(components.COMPONENT_MY_CACHE).(*components.MyCache).SetWechatLoginResult(state, sysUser.ID)
 	e.OK(nil)
@@ -905,7 +1076,7 @@ func (e SysUser) GetWechatLoginResult(context *gin.Context) {
 		e.Error(err)
 		return
 	}
-	resp, err := e.Login(context, s, user)
+	resp, err := e.Login(context, s, user, user.Username, constant.LoginMethodWechat)
 	if err != nil {
 		log.ZError(e.Context, "", err)
 		e.Error(err)
@@ -993,7 +1164,7 @@ func (e SysUser) SendVerifyEmail(context *gin.Context) {
 			TemplateFileName: "verify_email.html",
 			Params:           variables,
 		}
-		err = email.EmailVendorInstance.Send(request)
+		err = email.GetEmailVendor().Send(request)
 		if err != nil {
 			log.ZError(e.Context, "", err)
 			e.Error(err)
@@ -1027,7 +1198,7 @@ func (e SysUser) SendVerifyEmail(context *gin.Context) {
 			TemplateFileName: "verify_email.html",
 			Params:           variables,
 		}
-		err = email.EmailVendorInstance.Send(request)
+		err = email.GetEmailVendor().Send(request)
 		if err != nil {
 			log.ZError(e.Context, "", err)
 			e.Error(err)
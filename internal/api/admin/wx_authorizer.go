@@ -44,7 +44,7 @@ func (e WxAuthorizer) PageQuery(context *gin.Context) {
 		MakeService(&s.Service).
 		Errors
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
@@ -69,7 +69,7 @@ func (e WxAuthorizer) PageQuery(context *gin.Context) {
 // @Security RequireLogin
 func (e WxAuthorizer) GetAuthorizeUrl(context *gin.Context) {
 	e.MakeContext(context)
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		e.Error(errs.NewCodeError(errs.UnSupportedOperation, "当前环境不支持该操作"))
 		return
 	}
@@ -80,7 +80,7 @@ func (e WxAuthorizer) GetAuthorizeUrl(context *gin.Context) {
 	}
 	fmt2.Dump(rs)
 	//拼接授权链接
-	componentAppid := config.Config.WxOpenPlatform.AppID
+	componentAppid := config.Get().WxOpenPlatform.AppID
 	redirectUrl := context.Request.Host + "/api/v1/wx/authorizer/authorize/redirect"
 	authorizeUrl := fmt.Sprintf("https://open.weixin.qq.com/wxaopen/safe/bindcomponent?action=bindcomponent&no_scan=1&component_appid=%s&pre_auth_code=%s&redirect_uri=%s&auth_type=1#wechat_redirect",
 		componentAppid, rs.PreAuthCode, redirectUrl)
@@ -98,14 +98,14 @@ func (e WxAuthorizer) GetAuthorizeUrl(context *gin.Context) {
 // @Success 200
 // @Router /wx/authorizer/authorize/redirect [get]
 func (e WxAuthorizer) AuthorizeRedirect(context *gin.Context) {
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		e.Error(errs.NewCodeError(errs.UnSupportedOperation, "当前环境不支持该操作"))
 		return
 	}
 	authCode := context.DefaultQuery("auth_code", "")
 	expiresIn := context.DefaultQuery("expires_in", "")
 
-	log.ZInfo(context, "授权回调", "authCode", authCode, "expiresIn", expiresIn)
+	log.Wechat.Info(context, "授权回调", "authCode", authCode, "expiresIn", expiresIn)
 	res, err := weixin.OpenPlatformApp.Base.HandleAuthorize(context.Request.Context(), authCode)
 	if err != nil {
 		panic(err)
@@ -114,14 +114,14 @@ func (e WxAuthorizer) AuthorizeRedirect(context *gin.Context) {
 	s := services.WxAuthorizer{}
 	err = e.MakeContext(context).MakeOrm().MakeService(&s.Service).Errors
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
 	duration := time.Duration(res.AuthorizationInfo.ExpiresIn) * time.Second
 
 	authorizer := &models.WxAuthorizer{
-		ComponentAppid:  config.Config.WxOpenPlatform.AppID,
+		ComponentAppid:  config.Get().WxOpenPlatform.AppID,
 		AuthorizerAppid: res.AuthorizationInfo.AuthorizerAppid,
 
 		AuthorizationCode:          authCode,
@@ -154,7 +154,7 @@ func (e WxAuthorizer) SyncInfo(context *gin.Context) {
 	s := services.WxAuthorizer{}
 	err := e.MakeContext(context).MakeOrm().Bind(&req, binding.JSON).MakeService(&s.Service).Errors
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
@@ -167,7 +167,7 @@ func (e WxAuthorizer) SyncInfo(context *gin.Context) {
 		e.Error(errs.NewCodeError(errs.RecordNotFoundError, "authorizer not found"))
 		return
 	}
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		e.Error(errs.NewCodeError(errs.UnSupportedOperation, "当前环境不支持该操作"))
 		return
 	}
@@ -197,22 +197,22 @@ func (e WxAuthorizer) CreateKfAccount(context *gin.Context) {
 		MakeService(&s.Service).
 		Errors
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
 	authorizer, err := s.GetByAppID(req.AppID)
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
 	if authorizer == nil {
-		log.ZError(e.Context, "authorizer not found", errs.NewCodeError(errs.RecordNotFoundError, "authorizer not found"), "appId", req.AppID)
+		log.Wechat.Error(e.Context, "authorizer not found", errs.NewCodeError(errs.RecordNotFoundError, "authorizer not found"), "appId", req.AppID)
 		e.Error(err)
 		return
 	}
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		e.Error(errs.NewCodeError(errs.UnSupportedOperation, "当前环境不支持该操作"))
 		return
 	}
@@ -220,7 +220,7 @@ func (e WxAuthorizer) CreateKfAccount(context *gin.Context) {
 
 	data, err := account.(*officialAccount.Application).CustomerService.Create(context, req.Account, req.Nickname)
 	if err != nil {
-		log.ZError(e.Context, "create customer service account fail", err, "account", req.Account, "nickname", req.Nickname)
+		log.Wechat.Error(e.Context, "create customer service account fail", err, "account", req.Account, "nickname", req.Nickname)
 		e.Error(err)
 		return
 	}
@@ -245,11 +245,11 @@ func (e WxAuthorizer) GetAllKfAccounts(context *gin.Context) {
 		MakeService(&s.Service).
 		Errors
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		e.Error(errs.NewCodeError(errs.UnSupportedOperation, "当前环境不支持该操作"))
 		return
 	}
@@ -257,7 +257,7 @@ func (e WxAuthorizer) GetAllKfAccounts(context *gin.Context) {
 
 	data, err := account.(*officialAccount.Application).CustomerService.List(context)
 	if err != nil {
-		log.ZError(e.Context, "get all customer service account fail", err, "appId", req.AppID)
+		log.Wechat.Error(e.Context, "get all customer service account fail", err, "appId", req.AppID)
 		e.Error(err)
 		return
 	}
@@ -282,11 +282,11 @@ func (e WxAuthorizer) DeleteKfAccount(context *gin.Context) {
 		MakeService(&s.Service).
 		Errors
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		e.Error(errs.NewCodeError(errs.UnSupportedOperation, "当前环境不支持该操作"))
 		return
 	}
@@ -294,7 +294,7 @@ func (e WxAuthorizer) DeleteKfAccount(context *gin.Context) {
 
 	data, err := account.(*officialAccount.Application).CustomerService.Delete(context, req.Account)
 	if err != nil {
-		log.ZError(e.Context, "delete customer service account fail", err, "appId", req.AppID, "account", req.Account)
+		log.Wechat.Error(e.Context, "delete customer service account fail", err, "appId", req.AppID, "account", req.Account)
 		e.Error(err)
 		return
 	}
@@ -319,11 +319,11 @@ func (e WxAuthorizer) SetDefaultKfAccount(context *gin.Context) {
 		MakeService(&s.Service).
 		Errors
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		e.Error(errs.NewCodeError(errs.UnSupportedOperation, "当前环境不支持该操作"))
 		return
 	}
@@ -353,11 +353,11 @@ func (e WxAuthorizer) DeleteMenu(context *gin.Context) {
 		MakeService(&s.Service).
 		Errors
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		e.Error(errs.NewCodeError(errs.UnSupportedOperation, "当前环境不支持该操作"))
 		return
 	}
@@ -365,7 +365,7 @@ func (e WxAuthorizer) DeleteMenu(context *gin.Context) {
 
 	data, err := account.(*officialAccount.Application).Menu.Delete(context)
 	if err != nil {
-		log.ZError(e.Context, "clear menu fail", err, "appId", req.AppID)
+		log.Wechat.Error(e.Context, "clear menu fail", err, "appId", req.AppID)
 		e.Error(err)
 		return
 	}
@@ -390,11 +390,11 @@ func (e WxAuthorizer) GetMenu(context *gin.Context) {
 		MakeService(&s.Service).
 		Errors
 	if err != nil {
-		log.ZError(e.Context, "", err)
+		log.Wechat.Error(e.Context, "", err)
 		e.Error(err)
 		return
 	}
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		e.Error(errs.NewCodeError(errs.UnSupportedOperation, "当前环境不支持该操作"))
 		return
 	}
@@ -402,7 +402,7 @@ func (e WxAuthorizer) GetMenu(context *gin.Context) {
 
 	data, err := account.(*officialAccount.Application).Menu.Get(context)
 	if err != nil {
-		log.ZError(e.Context, "get menu fail", err, "appId", req.AppID)
+		log.Wechat.Error(e.Context, "get menu fail", err, "appId", req.AppID)
 		e.Error(err)
 		return
 	}
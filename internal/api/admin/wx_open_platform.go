@@ -30,7 +30,7 @@ func (e WxOpenPlatformServer) Callback(context *gin.Context) {
 	println(string(requestXML))
 
 	query, _ := json.Marshal(context.Request.URL.Query())
-	log.ZInfo(context, "receive a callback", "query", string(query), "body", string(requestXML))
+	log.Wechat.Info(context, "receive a callback", "query", string(query), "body", string(requestXML))
 
 	var err error
 
@@ -77,11 +77,11 @@ func (e WxOpenPlatformServer) CallbackWithApp(context *gin.Context) {
 
 	query, _ := json.Marshal(context.Request.URL.Query())
 	appId := context.Param("appID")
-	log.ZInfo(context, "receive a app callback", "appId", appId, "query", string(query), "body", string(requestXML))
+	log.Wechat.Info(context, "receive a app callback", "appId", appId, "query", string(query), "body", string(requestXML))
 
 	account := application.AppContext.GetComponent(appId)
 	if account == nil {
-		log.ZError(context, "未找到对应的授权公众号", errors.New("wechat authorizer not found"), "appId", appId)
+		log.Wechat.Error(context, "未找到对应的授权公众号", errors.New("wechat authorizer not found"), "appId", appId)
 		return
 	}
 	rs, err := account.(*officialAccount.Application).Server.Notify(context.Request, func(event contract.EventInterface) interface{} {
@@ -89,11 +89,11 @@ func (e WxOpenPlatformServer) CallbackWithApp(context *gin.Context) {
 		return kernel.SUCCESS_EMPTY_RESPONSE
 	})
 	if err != nil {
-		log.ZError(context, "处理微信消息推送发生错误", err)
+		log.Wechat.Error(context, "处理微信消息推送发生错误", err)
 		return
 	}
 	err = helper.HttpResponseSend(rs, context.Writer)
 	if err != nil {
-		log.ZError(context, "处理微信消息推送发生错误", err)
+		log.Wechat.Error(context, "处理微信消息推送发生错误", err)
 	}
 }
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"orderin-server/internal/components"
+	"orderin-server/internal/dto"
+	"orderin-server/internal/models"
+	"orderin-server/internal/services"
+	"orderin-server/pkg/common/api"
+	"orderin-server/pkg/common/application"
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/i18n"
+	"orderin-server/pkg/common/log"
+	"strings"
+)
+
+// Dict 只读的公开字典，只返回后台标记为公开的字典
+type Dict struct {
+	api.Api
+}
+
+// @Summary 根据字典名称获取所有项目
+// @Description 根据字典名称获取所有项目，只能获取公开的字典
+// @Tags 字典
+// @Accept json
+// @Produce json
+// @Param param body dto.SysDictGetItemsReq true "字典名称"
+// @Param If-None-Match header string false "上次响应的ETag，内容未变化时返回304"
+// @Success 200 {object} api.Response{data=dto.SysDictGetItemsResp}
+// @Success 304 "内容未变化"
+// @Router /dict/item/get_by_name [post]
+func (e Dict) GetItemsByName(c *gin.Context) {
+	req := dto.SysDictGetItemsReq{}
+	err := e.MakeContext(c).Bind(&req, binding.JSON).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	dictCache := application.AppContext.GetComponent(components.COMPONENT_DICT_CACHE).(*components.DictCache)
+	entry, err := dictCache.Get(e.Context, req.Name)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	if !entry.Public {
+		e.Error(errs.NewCodeError(errs.RecordNotFoundError, "字典不存在："+req.Name))
+		return
+	}
+	locale := i18n.Locale(e.Context)
+	etag := services.LocalizedETag(entry.ETag, locale)
+	// 标签随请求语言变化，缓存需按语言区分
+	e.Context.Header("Vary", "Accept-Language")
+	if e.NotModified(etag) {
+		return
+	}
+	e.OK(dto.SysDictGetItemsResp{List: services.LocalizeDictItems(entry.Items, locale), Version: strings.Trim(etag, `"`)})
+}
+
+// @Summary 根据字典名称数组获取所有项目
+// @Description 根据字典名称数组获取所有项目，只能获取公开的字典
+// @Tags 字典
+// @Accept json
+// @Produce json
+// @Param param body dto.SysDictBatchGetItemsReq true "字典名称数组"
+// @Param If-None-Match header string false "上次响应的ETag，内容未变化时返回304"
+// @Success 200 {object} api.Response{data=map[string][]models.SysDictItem}
+// @Success 304 "内容未变化"
+// @Router /dict/item/batch_get_by_names [post]
+func (e Dict) BatchGetItemsByNames(c *gin.Context) {
+	req := dto.SysDictBatchGetItemsReq{}
+	err := e.MakeContext(c).Bind(&req, binding.JSON).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	dictCache := application.AppContext.GetComponent(components.COMPONENT_DICT_CACHE).(*components.DictCache)
+	entries, etag, err := dictCache.BatchGet(e.Context, req.Names)
+	locale := i18n.Locale(e.Context)
+	etag = services.LocalizedETag(etag, locale)
+	e.Context.Header("Vary", "Accept-Language")
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	mapping := make(map[string][]models.SysDictItem, len(entries))
+	for name, entry := range entries {
+		if !entry.Public {
+			e.Error(errs.NewCodeError(errs.RecordNotFoundError, "字典不存在："+name))
+			return
+		}
+		mapping[name] = services.LocalizeDictItems(entry.Items, locale)
+	}
+	if e.NotModified(etag) {
+		return
+	}
+	e.OK(mapping)
+}
+
+// @Summary 获取字典项树
+// @Description 获取字典项树，用于级联选择，只能获取公开的字典，不包括已禁用的字典项及其下级
+// @Tags 字典
+// @Accept json
+// @Produce json
+// @Param param body dto.SysDictItemTreeReq true "字典名称"
+// @Success 200 {object} api.Response{data=[]dto.SysDictItemTree}
+// @Router /dict/item/get_tree [post]
+func (e Dict) GetItemTree(c *gin.Context) {
+	req := dto.SysDictItemTreeReq{}
+	err := e.MakeContext(c).Bind(&req, binding.JSON).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	entry, err := e.getPublic(req.DictName)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	if req.ParentValue != "" {
+		if _, ok := services.DictItemPath(entry.Items, req.ParentValue); !ok {
+			e.Error(errs.NewCodeError(errs.RecordNotFoundError, "字典项不存在"))
+			return
+		}
+	}
+	items := services.LocalizeDictItems(entry.Items, i18n.Locale(e.Context))
+	e.OK(services.BuildDictItemTree(items, req.ParentValue, req.Depth))
+}
+
+// @Summary 获取字典项的完整路径
+// @Description 根据值获取从顶级到该字典项的所有字典项，只能获取公开的字典
+// @Tags 字典
+// @Accept json
+// @Produce json
+// @Param param body dto.SysDictItemPathReq true "字典名称及字典项的值"
+// @Success 200 {object} api.Response{data=[]models.SysDictItem}
+// @Router /dict/item/get_path [post]
+func (e Dict) GetItemPath(c *gin.Context) {
+	req := dto.SysDictItemPathReq{}
+	err := e.MakeContext(c).Bind(&req, binding.JSON).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	entry, err := e.getPublic(req.DictName)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	path, ok := services.DictItemPath(services.LocalizeDictItems(entry.Items, i18n.Locale(e.Context)), req.Value)
+	if !ok {
+		e.Error(errs.NewCodeError(errs.RecordNotFoundError, "字典项不存在"))
+		return
+	}
+	e.OK(path)
+}
+
+// getPublic 从缓存获取公开的字典
+func (e Dict) getPublic(name string) (*components.DictEntry, error) {
+	dictCache := application.AppContext.GetComponent(components.COMPONENT_DICT_CACHE).(*components.DictCache)
+	entry, err := dictCache.Get(e.Context, name)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		return nil, err
+	}
+	if !entry.Public {
+		return nil, errs.NewCodeError(errs.RecordNotFoundError, "字典不存在："+name)
+	}
+	return entry, nil
+}
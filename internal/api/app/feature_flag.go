@@ -0,0 +1,49 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"orderin-server/internal/components"
+	"orderin-server/internal/dto"
+	"orderin-server/pkg/common/api"
+	"orderin-server/pkg/common/constant"
+	"orderin-server/pkg/common/featureflag"
+	"orderin-server/pkg/common/ginmiddleware"
+	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/tokenverify"
+)
+
+type FeatureFlag struct {
+	api.Api
+}
+
+// @Summary 查询功能开关
+// @Description 返回所有功能开关对调用者是否开启。请求头带token时按登录用户判断，否则按匿名用户及传入的平台判断
+// @Tags 功能开关
+// @Accept json
+// @Produce json
+// @Param param body dto.FeatureFlagGetReq false "平台"
+// @Success 200 {object} api.Response{data=map[string]bool}
+// @Router /feature/get_flags [post]
+func (e FeatureFlag) GetFlags(c *gin.Context) {
+	req := dto.FeatureFlagGetReq{}
+	err := e.MakeContext(c).Bind(&req, binding.JSON).Errors
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	target := featureflag.Target{PlatformID: req.PlatformID}
+	if token := c.GetHeader(constant.Token); token != "" {
+		claims, err := tokenverify.GetClaimFromToken(token, ginmiddleware.Secret())
+		if err != nil {
+			log.ZWarn(e.Context, "parse token error", err)
+			e.Error(err)
+			return
+		}
+		target.UserID = claims.UserID
+		target.PlatformID = claims.PlatformID
+		target = featureflag.Resolve(e.Context, target)
+	}
+	e.OK(components.EvaluateFeatures(e.Context, target))
+}
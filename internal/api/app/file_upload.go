@@ -58,7 +58,7 @@ func (e FileUpload) UploadImage(c *gin.Context) {
 	defer src.Close()
 	// 生成目标文件名
 	destination := generateDestination(file.Filename)
-	url, err := file_store.FileStore.UpLoad(destination, src)
+	url, err := file_store.GetFileStore().UpLoad(destination, src)
 	if err != nil {
 		e.Error(err)
 		return
@@ -98,7 +98,7 @@ func (e FileUpload) UploadBase64Image(c *gin.Context) {
 	src := strings.NewReader(string(imageData))
 	// 生成目标文件名
 	destination := generateDestination("base64.png")
-	url, err := file_store.FileStore.UpLoad(destination, src)
+	url, err := file_store.GetFileStore().UpLoad(destination, src)
 	if err != nil {
 		e.Error(err)
 		return
@@ -127,5 +127,5 @@ func isImageFile(file *multipart.FileHeader) bool {
 func generateDestination(filename string) string {
 	// 可根据需要自定义生成目标文件名的逻辑
 	// 这里简单地以当前时间戳作为文件名
-	return fmt.Sprintf("xxxjz-app/%s/%d%s", config.Config.Env.Profiles, utils.GenID(), filepath.Ext(filename))
+	return fmt.Sprintf("xxxjz-app/%s/%d%s", config.Get().Env.Profiles, utils.GenID(), filepath.Ext(filename))
 }
@@ -0,0 +1,35 @@
+package components
+
+import "orderin-server/pkg/common/dynconfig"
+
+// 动态配置项，值在 sys_configs 表中维护，通过 ConfigValue/ConfigJSON 读取
+const (
+	CONFIG_SMS_SIGN_NAME            = "sms.sign_name"
+	CONFIG_SMS_VERIFY_CODE_TEMPLATE = "sms.verify_code_template"
+	CONFIG_SMS_VERIFY_CODE_TTL      = "sms.verify_code_ttl"
+)
+
+func init() {
+	dynconfig.Register(
+		dynconfig.Definition{
+			Name:        CONFIG_SMS_SIGN_NAME,
+			ValueType:   dynconfig.TypeString,
+			Default:     "小西西家政",
+			Description: "短信签名",
+		},
+		dynconfig.Definition{
+			Name:        CONFIG_SMS_VERIFY_CODE_TEMPLATE,
+			ValueType:   dynconfig.TypeString,
+			Default:     "SMS_296350564",
+			Description: "验证码短信模板编号",
+		},
+		dynconfig.Definition{
+			Name:        CONFIG_SMS_VERIFY_CODE_TTL,
+			ValueType:   dynconfig.TypeNumber,
+			Default:     "10",
+			Description: "验证码有效期，单位分钟",
+			Min:         dynconfig.Float(1),
+			Max:         dynconfig.Float(60),
+		},
+	)
+}
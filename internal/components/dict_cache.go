@@ -0,0 +1,157 @@
+package components
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"orderin-server/internal/models"
+	"orderin-server/internal/services"
+	"orderin-server/pkg/common/application"
+	"orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/log"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	COMPONENT_DICT_CACHE = "dict_cache"
+
+	dictCacheKeyPrefix = "dict:"
+)
+
+// DictEntry 缓存的字典项，ETag 由内容计算，各实例一致。Items 为共享数据，不可修改
+type DictEntry struct {
+	Name   string               `json:"name"`
+	Public bool                 `json:"public"`
+	Items  []models.SysDictItem `json:"items"`
+	ETag   string               `json:"etag"`
+}
+
+// DictCache 字典项两级缓存：本地 LRU + redis，字典变更时通过 redis 通知所有实例失效
+type DictCache struct {
+	db       *gorm.DB
+	rdb      redis.UniversalClient
+	local    *expirable.LRU[string, *DictEntry]
+	redisTtl time.Duration
+}
+
+func RegisterDictCache(db *gorm.DB, rdb redis.UniversalClient) {
+	c := config.Get().DictCache
+	dictCache := NewDictCache(relation.Primary(db), rdb, c.LocalSize, c.LocalTtl, c.RedisTtl)
+	application.AppContext.RegisterComponent(COMPONENT_DICT_CACHE, dictCache)
+	log.ZInfo(context.Background(), "dict_cache注册成功")
+}
+
+func NewDictCache(db *gorm.DB, rdb redis.UniversalClient, localSize int, localTtl int, redisTtl int) *DictCache {
+	return &DictCache{
+		db:       db,
+		rdb:      rdb,
+		local:    expirable.NewLRU[string, *DictEntry](localSize, nil, time.Duration(localTtl)*time.Second),
+		redisTtl: time.Duration(redisTtl) * time.Second,
+	}
+}
+
+// Get 依次从本地、redis、数据库读取字典项
+func (e *DictCache) Get(ctx context.Context, name string) (*DictEntry, error) {
+	if entry, ok := e.local.Get(name); ok {
+		return entry, nil
+	}
+	key := dictCacheKeyPrefix + name
+	data, err := e.rdb.Get(ctx, key).Bytes()
+	if err == nil {
+		var entry DictEntry
+		if err = json.Unmarshal(data, &entry); err == nil {
+			e.local.Add(name, &entry)
+			return &entry, nil
+		}
+		log.ZWarn(ctx, "invalid dict cache, reload it", err, "name", name)
+	} else if !errors.Is(err, redis.Nil) {
+		log.ZWarn(ctx, "get dict cache fail", err, "name", name)
+	}
+
+	s := services.SysDict{}
+	// 失效后立即重新加载，走主库避免把从库上的旧数据写回 redis；ctx 会覆盖 db 上的主库标记，需重新设置
+	s.Orm = relation.Primary(e.db.WithContext(ctx))
+	s.Context = ctx
+	items, err := s.GetItems(name)
+	if err != nil {
+		return nil, err
+	}
+	dict, err := s.GetByName(name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	entry := newDictEntry(name, dict != nil && !dict.Deleted && dict.Public, items)
+	if data, err := json.Marshal(entry); err == nil {
+		if err := e.rdb.Set(ctx, key, data, e.redisTtl).Err(); err != nil {
+			log.ZWarn(ctx, "set dict cache fail", err, "name", name)
+		}
+	}
+	e.local.Add(name, entry)
+	return entry, nil
+}
+
+// BatchGet 批量读取字典项，返回各字典及整体的 ETag
+func (e *DictCache) BatchGet(ctx context.Context, names []string) (map[string]*DictEntry, string, error) {
+	result := make(map[string]*DictEntry, len(names))
+	etags := make([]string, 0, len(names))
+	for _, name := range names {
+		entry, err := e.Get(ctx, name)
+		if err != nil {
+			return nil, "", err
+		}
+		result[name] = entry
+		etags = append(etags, name+"="+entry.ETag)
+	}
+	sort.Strings(etags)
+	return result, etag([]byte(strings.Join(etags, ","))), nil
+}
+
+// Invalidate 删除 redis 缓存并通知所有实例删除本地缓存
+func (e *DictCache) Invalidate(ctx context.Context, names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, dictCacheKeyPrefix+name)
+		e.local.Remove(name)
+	}
+	if err := e.rdb.Del(ctx, keys...).Err(); err != nil {
+		return err
+	}
+	data, _ := json.Marshal(names)
+	return Publish(e.rdb, CHANNEL_DICT_REFRESH, string(data))
+}
+
+// removeLocal 收到失效消息后删除本地缓存
+func (e *DictCache) removeLocal(ctx context.Context, payload string) {
+	var names []string
+	if err := json.Unmarshal([]byte(payload), &names); err != nil {
+		log.ZError(ctx, "解析字典失效消息失败", err, "payload", payload)
+		return
+	}
+	for _, name := range names {
+		e.local.Remove(name)
+	}
+}
+
+func newDictEntry(name string, public bool, items []models.SysDictItem) *DictEntry {
+	if items == nil {
+		items = make([]models.SysDictItem, 0)
+	}
+	data, _ := json.Marshal(items)
+	return &DictEntry{Name: name, Public: public, Items: items, ETag: etag(data)}
+}
+
+func etag(data []byte) string {
+	sum := sha1.Sum(data)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
@@ -0,0 +1,38 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"gorm.io/gorm"
+	"orderin-server/internal/models"
+	"orderin-server/pkg/common/db/history"
+	"orderin-server/pkg/common/log"
+)
+
+// RegisterEntityHistory 为实现了 history.Tracked 的模型开启变更历史记录
+func RegisterEntityHistory(db *gorm.DB) {
+	err := history.Register(db, writeEntityHistory)
+	if err != nil {
+		log.ZError(context.Background(), "entity_history注册失败", err)
+		return
+	}
+	log.ZInfo(context.Background(), "entity_history注册成功")
+}
+
+func writeEntityHistory(tx *gorm.DB, records []history.Record) error {
+	list := make([]models.SysEntityHistory, 0, len(records))
+	for _, record := range records {
+		changes, err := json.Marshal(record.Changes)
+		if err != nil {
+			return err
+		}
+		list = append(list, models.SysEntityHistory{
+			EntityType: record.EntityType,
+			EntityID:   record.EntityID,
+			Action:     record.Action,
+			Changes:    changes,
+			CreatedBy:  record.OperatorID,
+		})
+	}
+	return tx.Create(&list).Error
+}
@@ -0,0 +1,93 @@
+package components
+
+import (
+	"context"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"gorm.io/gorm"
+	"orderin-server/internal/services"
+	"orderin-server/pkg/common/application"
+	"orderin-server/pkg/common/dynconfig"
+	"orderin-server/pkg/common/featureflag"
+	"orderin-server/pkg/common/log"
+	"sync/atomic"
+	"time"
+)
+
+// featureRules 由某一版本的动态配置解析出的开关规则
+type featureRules struct {
+	snapshot *ConfigSnapshot
+	rules    map[string]*featureflag.Rule
+}
+
+var currentFeatureRules atomic.Pointer[featureRules]
+
+// IsEnabled 判断开关对 ctx 中的用户是否开启，对象见 featureflag.TargetFromContext
+func IsEnabled(ctx context.Context, flag string) bool {
+	return loadFeatureRules(ctx)[flag].IsEnabled(ctx, flag)
+}
+
+// EvaluateFeatures 判断所有开关对 target 是否开启
+func EvaluateFeatures(ctx context.Context, target featureflag.Target) map[string]bool {
+	rules := loadFeatureRules(ctx)
+	result := make(map[string]bool, len(rules))
+	for flag, rule := range rules {
+		result[flag] = rule.Evaluate(flag, target)
+	}
+	return result
+}
+
+// registerFeatureTarget 判断开关时按用户id补充角色，角色在本地缓存一分钟。系统中没有租户，Tenant 为空
+func registerFeatureTarget(db *gorm.DB) {
+	roles := expirable.NewLRU[int64, []string](10000, nil, time.Minute)
+	featureflag.SetTargetFunc(func(ctx context.Context, target *featureflag.Target) {
+		if cached, ok := roles.Get(target.UserID); ok {
+			target.Roles = cached
+			return
+		}
+		s := services.SysUser{}
+		s.Orm = db.WithContext(ctx)
+		s.Context = ctx
+		names, err := s.GetRolesByUserId(target.UserID)
+		if err != nil {
+			log.ZWarn(ctx, "get user roles for feature flag fail", err, "userId", target.UserID)
+			return
+		}
+		roles.Add(target.UserID, names)
+		target.Roles = names
+	})
+}
+
+// loadFeatureRules 动态配置版本不变时复用已解析的规则
+func loadFeatureRules(ctx context.Context) map[string]*featureflag.Rule {
+	var snapshot *ConfigSnapshot
+	if myConfig, ok := application.AppContext.GetComponent(COMPONENT_MY_CONFIG).(*MyConfig); ok && myConfig != nil {
+		snapshot = myConfig.Snapshot()
+	}
+	if cached := currentFeatureRules.Load(); cached != nil && cached.snapshot == snapshot {
+		return cached.rules
+	}
+
+	rules := map[string]*featureflag.Rule{}
+	parse := func(name, value string) {
+		flag, ok := featureflag.FlagName(name)
+		if !ok {
+			return
+		}
+		rule, err := featureflag.Parse(value)
+		if err != nil {
+			log.ZWarn(ctx, "invalid feature flag rule, ignore it", err, "name", name)
+			return
+		}
+		rules[flag] = rule
+	}
+	for _, def := range dynconfig.Definitions() {
+		parse(def.Name, def.Default)
+	}
+	if snapshot != nil {
+		for name, value := range snapshot.Values {
+			parse(name, value)
+		}
+	}
+	currentFeatureRules.Store(&featureRules{snapshot: snapshot, rules: rules})
+	return rules
+}
@@ -0,0 +1,31 @@
+package components
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"orderin-server/internal/models"
+	"orderin-server/pkg/common/i18n"
+	"orderin-server/pkg/common/log"
+)
+
+// registerUserLocale 错误文案等按登录用户的偏好语言翻译，偏好语言缓存在 redis 中，没有缓存时从数据库加载
+func registerUserLocale(db *gorm.DB, myCache *MyCache) {
+	i18n.SetUserLocaleFunc(func(ctx context.Context, userID int64) string {
+		language, ok, err := myCache.GetLanguage(userID)
+		if err != nil {
+			log.ZWarn(ctx, "get user language from cache fail", err, "userId", userID)
+		}
+		if ok {
+			return language
+		}
+		var user models.SysUser
+		if err := db.WithContext(ctx).Select("language").Where("id = ?", userID).Take(&user).Error; err != nil {
+			log.ZWarn(ctx, "get user language fail", err, "userId", userID)
+			return ""
+		}
+		if err := myCache.SetLanguage(userID, user.Language); err != nil {
+			log.ZWarn(ctx, "set user language cache fail", err, "userId", userID)
+		}
+		return user.Language
+	})
+}
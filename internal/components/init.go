@@ -8,6 +8,7 @@ import (
 	"orderin-server/internal/services"
 	"orderin-server/pkg/common/application"
 	"orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/db/relation"
 	"orderin-server/pkg/common/log"
 	"orderin-server/pkg/common/weixin"
 )
@@ -31,19 +32,29 @@ func RegisterComponents(db *gorm.DB, rdb redis.UniversalClient) {
 	myCache := MyCache{rdb: rdb}
 	application.AppContext.RegisterComponent(COMPONENT_MY_CACHE, &myCache)
 	log.ZInfo(context.Background(), "my_cache注册成功")
+	registerUserLocale(db, &myCache)
 
 	configService := services.SysConfig{}
-	configService.Orm = db
+	//重新加载时读取刚修改的配置，走主库避免从库延迟读到旧值
+	configService.Orm = relation.Primary(db)
 	configService.Context = context.Background()
-	myConfig := MyConfig{ConfigService: configService, ConfigMap: make(map[string]string)}
-	myConfig.ReloadAll()
-	application.AppContext.RegisterComponent(COMPONENT_MY_CONFIG, &myConfig)
+	myConfig := NewMyConfig(configService, rdb, config.Get().DynamicConfig.PollInterval)
+	if err := myConfig.ReloadAll(); err != nil {
+		panic(err)
+	}
+	myConfig.StartPolling()
+	application.AppContext.RegisterComponent(COMPONENT_MY_CONFIG, myConfig)
 	log.ZInfo(context.Background(), "my_config注册成功")
 
+	RegisterDictCache(db, rdb)
+	registerFeatureTarget(db)
+
+	Subscribe(rdb, CHANNEL_LOG_LEVEL, CHANNEL_CONFIG_REFRESH, CHANNEL_DICT_REFRESH)
+
 }
 
 func RegisterAuthorizers(db *gorm.DB) {
-	if config.Config.Env.Profiles == "dev" {
+	if config.Get().Env.Profiles == "dev" {
 		return
 	}
 
@@ -0,0 +1,48 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/redis/go-redis/v9"
+	"orderin-server/pkg/common/log"
+	"time"
+)
+
+// LogLevelMessage 通过redis广播给所有实例的日志级别变更
+type LogLevelMessage struct {
+	Module string `json:"module"`
+	Level  string `json:"level"`
+	Ttl    int64  `json:"ttl"` //有效期（秒），0表示一直有效
+	Reset  bool   `json:"reset"`
+}
+
+// PublishLogLevel 校验后广播日志级别变更，各实例（包括自身）收到消息后生效
+func PublishLogLevel(rdb redis.UniversalClient, message LogLevelMessage) error {
+	if !message.Reset {
+		if err := log.CheckLevel(message.Module, message.Level); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return Publish(rdb, CHANNEL_LOG_LEVEL, string(data))
+}
+
+func applyLogLevel(ctx context.Context, payload string) {
+	var message LogLevelMessage
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		log.ZError(ctx, "解析日志级别消息失败", err, "payload", payload)
+		return
+	}
+	var err error
+	if message.Reset {
+		err = log.ResetLevel(message.Module)
+	} else {
+		err = log.SetLevel(message.Module, message.Level, time.Duration(message.Ttl)*time.Second)
+	}
+	if err != nil {
+		log.ZError(ctx, "修改日志级别失败", err, "payload", payload)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
 	"orderin-server/pkg/common/utils"
+	"strings"
 	"time"
 )
 
@@ -53,3 +54,43 @@ func (e *MyCache) GetWechatLoginResult(state string) (string, error) {
 	return result, err
 
 }
+
+// SetMfaLoginTicket 保存账号密码校验通过、等待二步验证的登录凭证，5分钟内有效
+func (e *MyCache) SetMfaLoginTicket(ticket string, id int64, account string) error {
+	key := "mfa_login:" + ticket
+	return e.rdb.Set(context.Background(), key, utils.Int64ToString(id)+":"+account, 5*time.Minute).Err()
+}
+
+// GetMfaLoginTicket 获取登录凭证对应的用户和登录账号，凭证不存在或已过期时 id 为 0
+func (e *MyCache) GetMfaLoginTicket(ticket string) (id int64, account string, err error) {
+	key := "mfa_login:" + ticket
+	result, err := e.rdb.Get(context.Background(), key).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	idStr, account, _ := strings.Cut(result, ":")
+	return utils.StringToInt64(idStr), account, nil
+}
+
+func (e *MyCache) DelMfaLoginTicket(ticket string) {
+	e.rdb.Del(context.Background(), "mfa_login:"+ticket)
+}
+
+// SetLanguage 缓存用户的偏好语言，空字符串表示没有设置
+func (e *MyCache) SetLanguage(id int64, language string) error {
+	key := "language:" + utils.Int64ToString(id)
+	return e.rdb.Set(context.Background(), key, language, 7*24*time.Hour).Err()
+}
+
+// GetLanguage 获取缓存的用户偏好语言，没有缓存时 ok 为 false
+func (e *MyCache) GetLanguage(id int64) (language string, ok bool, err error) {
+	key := "language:" + utils.Int64ToString(id)
+	language, err = e.rdb.Get(context.Background(), key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+	return language, err == nil, err
+}
@@ -2,40 +2,200 @@ package components
 
 import (
 	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
 	"orderin-server/internal/services"
+	"orderin-server/pkg/common/application"
+	"orderin-server/pkg/common/dynconfig"
+	"orderin-server/pkg/common/log"
 	"orderin-server/pkg/common/utils"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 const (
 	CONFIG_NAME = "example_config_name"
+
+	// 全局配置版本号，每次增删改配置时递增
+	configVersionKey = "sys_config:version"
+	// 各实例已加载的配置版本，field 为实例标识
+	configInstancesKey = "sys_config:instances"
+
+	defaultConfigPollInterval = 30
 )
 
+// ConfigSnapshot 某一版本的动态配置，加载后只读
+type ConfigSnapshot struct {
+	Version  int64
+	Values   map[string]string
+	LoadedAt time.Time
+}
+
+// ConfigInstance 实例已加载的配置版本，定时上报到 redis
+type ConfigInstance struct {
+	Instance   string    `json:"instance"`
+	Version    int64     `json:"version"`
+	LoadedAt   time.Time `json:"loadedAt"`
+	ReportedAt time.Time `json:"reportedAt"`
+}
+
 type MyConfig struct {
 	ConfigService services.SysConfig
-	ConfigMap     map[string]string
+	rdb           redis.UniversalClient
+	instance      string
+	pollInterval  time.Duration
+	snapshot      atomic.Pointer[ConfigSnapshot]
+	mu            sync.Mutex
+}
+
+func NewMyConfig(configService services.SysConfig, rdb redis.UniversalClient, pollInterval int) *MyConfig {
+	if pollInterval <= 0 {
+		pollInterval = defaultConfigPollInterval
+	}
+	hostname, _ := os.Hostname()
+	e := &MyConfig{
+		ConfigService: configService,
+		rdb:           rdb,
+		instance:      hostname + "-" + strconv.Itoa(os.Getpid()),
+		pollInterval:  time.Duration(pollInterval) * time.Second,
+	}
+	e.snapshot.Store(&ConfigSnapshot{Values: map[string]string{}})
+	return e
+}
+
+// Snapshot 当前生效的配置
+func (e *MyConfig) Snapshot() *ConfigSnapshot {
+	return e.snapshot.Load()
 }
 
-func (e MyConfig) ReloadAll() {
+// ReloadAll 重新加载所有配置，先读版本号再读库，版本号只会偏旧，下次轮询时再补齐
+func (e *MyConfig) ReloadAll() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ctx := context.Background()
+	version, err := e.currentVersion(ctx)
+	if err != nil {
+		return err
+	}
 	configs, err := e.ConfigService.GetAll()
 	if err != nil {
-		panic(err)
+		return err
 	}
 	configMap := make(map[string]string)
+	var undefined []string
 	if configs != nil {
 		for _, config := range *configs {
 			configMap[config.Name] = config.Value
+			if _, ok := dynconfig.Lookup(config.Name); !ok {
+				undefined = append(undefined, config.Name)
+			}
 		}
 	}
-	e.ConfigMap = configMap
+	if len(undefined) > 0 {
+		log.ZWarn(ctx, "sys_configs contains undefined config names", nil, "names", undefined)
+	}
+	old := e.snapshot.Swap(&ConfigSnapshot{Version: version, Values: configMap, LoadedAt: time.Now()})
+	log.ZInfo(ctx, "动态配置已加载", "oldVersion", old.Version, "version", version)
+	e.report(ctx)
+	return nil
 }
 
-func (e MyConfig) GetConfigByName(ctx context.Context, name string) (*string, error) {
-	value := e.ConfigMap[name]
+// ReloadIfChanged 版本号变化时才重新加载
+func (e *MyConfig) ReloadIfChanged() error {
+	version, err := e.currentVersion(context.Background())
+	if err != nil {
+		return err
+	}
+	if version == e.Snapshot().Version {
+		e.report(context.Background())
+		return nil
+	}
+	return e.ReloadAll()
+}
+
+// StartPolling 定时比对版本号，作为 redis 订阅消息丢失时的兜底
+func (e *MyConfig) StartPolling() {
+	go func() {
+		ticker := time.NewTicker(e.pollInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := e.ReloadIfChanged(); err != nil {
+				log.ZError(context.Background(), "poll dynamic config fail", err)
+			}
+		}
+	}()
+}
+
+func (e *MyConfig) currentVersion(ctx context.Context) (int64, error) {
+	version, err := e.rdb.Get(ctx, configVersionKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return version, err
+}
+
+// report 上报本实例已加载的版本
+func (e *MyConfig) report(ctx context.Context) {
+	snapshot := e.Snapshot()
+	data, _ := json.Marshal(ConfigInstance{
+		Instance:   e.instance,
+		Version:    snapshot.Version,
+		LoadedAt:   snapshot.LoadedAt,
+		ReportedAt: time.Now(),
+	})
+	if err := e.rdb.HSet(ctx, configInstancesKey, e.instance, string(data)).Err(); err != nil {
+		log.ZWarn(ctx, "report dynamic config version fail", err)
+	}
+}
+
+// Instances 返回当前版本号及各实例已加载的版本，超过3个轮询周期未上报的实例视为已下线并清理
+func (e *MyConfig) Instances(ctx context.Context) (int64, []ConfigInstance, error) {
+	version, err := e.currentVersion(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	values, err := e.rdb.HGetAll(ctx, configInstancesKey).Result()
+	if err != nil {
+		return 0, nil, err
+	}
+	instances := make([]ConfigInstance, 0, len(values))
+	var expired []string
+	for field, value := range values {
+		var instance ConfigInstance
+		if err := json.Unmarshal([]byte(value), &instance); err != nil || time.Since(instance.ReportedAt) > 3*e.pollInterval {
+			expired = append(expired, field)
+			continue
+		}
+		instances = append(instances, instance)
+	}
+	if len(expired) > 0 {
+		e.rdb.HDel(ctx, configInstancesKey, expired...)
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Instance < instances[j].Instance })
+	return version, instances, nil
+}
+
+// PublishConfigRefresh 递增配置版本号并通知所有实例重新加载
+func PublishConfigRefresh(ctx context.Context, rdb redis.UniversalClient) error {
+	version, err := rdb.Incr(ctx, configVersionKey).Result()
+	if err != nil {
+		return err
+	}
+	return Publish(rdb, CHANNEL_CONFIG_REFRESH, strconv.FormatInt(version, 10))
+}
+
+func (e *MyConfig) GetConfigByName(ctx context.Context, name string) (*string, error) {
+	value := e.Snapshot().Values[name]
 	return &value, nil
 }
 
-func (e MyConfig) GetConfigByNameWithDefaultValue(ctx context.Context, name string, defaultValue string) *string {
+func (e *MyConfig) GetConfigByNameWithDefaultValue(ctx context.Context, name string, defaultValue string) *string {
 	value, err := e.GetConfigByName(ctx, name)
 	if value == nil || err != nil || *value == "" {
 		return &defaultValue
@@ -43,7 +203,7 @@ func (e MyConfig) GetConfigByNameWithDefaultValue(ctx context.Context, name stri
 	return value
 }
 
-func (e MyConfig) GetIntConfigByName(ctx context.Context, name string) (*int, error) {
+func (e *MyConfig) GetIntConfigByName(ctx context.Context, name string) (*int, error) {
 	value, err := e.GetConfigByName(ctx, name)
 	if err != nil {
 		return nil, err
@@ -55,7 +215,7 @@ func (e MyConfig) GetIntConfigByName(ctx context.Context, name string) (*int, er
 	return &intValue, err
 }
 
-func (e MyConfig) GetBoolConfigByName(ctx context.Context, name string) (*bool, error) {
+func (e *MyConfig) GetBoolConfigByName(ctx context.Context, name string) (*bool, error) {
 	value, err := e.GetConfigByName(ctx, name)
 	if err != nil {
 		return nil, err
@@ -63,11 +223,84 @@ func (e MyConfig) GetBoolConfigByName(ctx context.Context, name string) (*bool,
 	if value == nil {
 		return nil, nil
 	}
-	var boolValue *bool
-	if strings.ToLower(*value) == "true" {
-		*boolValue = true
-	} else if strings.ToLower(*value) == "false" {
-		*boolValue = false
+	var boolValue bool
+	switch strings.ToLower(*value) {
+	case "true":
+		boolValue = true
+	case "false":
+		boolValue = false
+	default:
+		return nil, nil
+	}
+	return &boolValue, nil
+}
+
+// ConfigScalar ConfigValue 支持的类型
+type ConfigScalar interface {
+	~string | ~bool | ~int | ~int32 | ~int64 | ~float64
+}
+
+// rawConfig 依次取数据库中的值、注册的缺省值
+func rawConfig(name string) (string, bool) {
+	if myConfig, ok := application.AppContext.GetComponent(COMPONENT_MY_CONFIG).(*MyConfig); ok && myConfig != nil {
+		if value, ok := myConfig.Snapshot().Values[name]; ok && value != "" {
+			return value, true
+		}
+	}
+	if def, ok := dynconfig.Lookup(name); ok && def.Default != "" {
+		return def.Default, true
+	}
+	return "", false
+}
+
+// ConfigValue 读取动态配置，未配置或无法转换为 T 时返回 fallback
+func ConfigValue[T ConfigScalar](ctx context.Context, name string, fallback T) T {
+	raw, ok := rawConfig(name)
+	if !ok {
+		return fallback
+	}
+	var result T
+	var err error
+	raw = strings.TrimSpace(raw)
+	switch p := any(&result).(type) {
+	case *string:
+		*p = raw
+	case *bool:
+		*p, err = strconv.ParseBool(raw)
+	case *int:
+		*p, err = strconv.Atoi(raw)
+	case *int32:
+		var n int64
+		n, err = strconv.ParseInt(raw, 10, 32)
+		*p = int32(n)
+	case *int64:
+		*p, err = strconv.ParseInt(raw, 10, 64)
+	case *float64:
+		*p, err = strconv.ParseFloat(raw, 64)
+	default:
+		// 自定义类型（如 type Status string）按底层类型转换
+		err = json.Unmarshal([]byte(strconv.Quote(raw)), &result)
+		if err != nil {
+			err = json.Unmarshal([]byte(raw), &result)
+		}
+	}
+	if err != nil {
+		log.ZWarn(ctx, "invalid dynamic config value, use fallback", err, "name", name, "value", raw)
+		return fallback
+	}
+	return result
+}
+
+// ConfigJSON 读取 json 类型的动态配置并反序列化为 T，未配置或格式错误时返回 fallback
+func ConfigJSON[T any](ctx context.Context, name string, fallback T) T {
+	raw, ok := rawConfig(name)
+	if !ok {
+		return fallback
+	}
+	var result T
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		log.ZWarn(ctx, "invalid dynamic config json, use fallback", err, "name", name)
+		return fallback
 	}
-	return boolValue, nil
+	return result
 }
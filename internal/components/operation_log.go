@@ -0,0 +1,154 @@
+package components
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"orderin-server/internal/models"
+	"orderin-server/internal/services"
+	"orderin-server/pkg/common/application"
+	"orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/log"
+	"sync"
+	"time"
+)
+
+const (
+	COMPONENT_OPERATION_LOG = "operation_log"
+
+	defaultOperationLogBatchSize     = 100
+	defaultOperationLogFlushInterval = 3
+	summaryReloadInterval            = 5 * time.Minute
+)
+
+// OperationLogWriter 异步批量写入操作日志
+type OperationLogWriter struct {
+	service       services.SysOperationLog
+	ch            chan models.SysOperationLog
+	batchSize     int
+	flushInterval time.Duration
+	retentionDays int
+
+	mu            sync.RWMutex
+	summaries     map[string]string
+	summaryLoadAt time.Time
+}
+
+func RegisterOperationLogWriter(db *gorm.DB) {
+	if !config.Get().OperationLog.Enable {
+		return
+	}
+	writer := NewOperationLogWriter(db, config.Get().OperationLog.BatchSize, config.Get().OperationLog.FlushInterval, config.Get().OperationLog.RetentionDays)
+	writer.Start()
+	application.AppContext.RegisterComponent(COMPONENT_OPERATION_LOG, writer)
+	log.ZInfo(context.Background(), "operation_log注册成功")
+}
+
+func NewOperationLogWriter(db *gorm.DB, batchSize int, flushInterval int, retentionDays int) *OperationLogWriter {
+	if batchSize <= 0 {
+		batchSize = defaultOperationLogBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultOperationLogFlushInterval
+	}
+	s := services.SysOperationLog{}
+	s.Orm = db
+	s.Context = context.Background()
+	return &OperationLogWriter{
+		service:       s,
+		ch:            make(chan models.SysOperationLog, batchSize*10),
+		batchSize:     batchSize,
+		flushInterval: time.Duration(flushInterval) * time.Second,
+		retentionDays: retentionDays,
+	}
+}
+
+func (e *OperationLogWriter) Start() {
+	go e.loop()
+	if e.retentionDays > 0 {
+		go e.purgeLoop()
+	}
+}
+
+// Write 投递一条日志，队列满时直接丢弃，避免阻塞请求
+func (e *OperationLogWriter) Write(operationLog models.SysOperationLog) {
+	select {
+	case e.ch <- operationLog:
+	default:
+		log.ZWarn(context.Background(), "operation log queue is full, drop it", nil, "path", operationLog.Path, "requestId", operationLog.RequestId)
+	}
+}
+
+func (e *OperationLogWriter) loop() {
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+	buffer := make([]models.SysOperationLog, 0, e.batchSize)
+	for {
+		select {
+		case operationLog := <-e.ch:
+			buffer = append(buffer, operationLog)
+			if len(buffer) >= e.batchSize {
+				e.flush(buffer)
+				buffer = make([]models.SysOperationLog, 0, e.batchSize)
+			}
+		case <-ticker.C:
+			if len(buffer) > 0 {
+				e.flush(buffer)
+				buffer = make([]models.SysOperationLog, 0, e.batchSize)
+			}
+		}
+	}
+}
+
+func (e *OperationLogWriter) flush(buffer []models.SysOperationLog) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.ZError(context.Background(), "flush operation logs panic", nil, "err", r)
+		}
+	}()
+	for i := range buffer {
+		buffer[i].Summary = e.getSummary(buffer[i].Path)
+	}
+	if err := e.service.BatchInsert(buffer, e.batchSize); err != nil {
+		log.ZError(context.Background(), "flush operation logs fail", err, "size", len(buffer))
+	}
+}
+
+func (e *OperationLogWriter) getSummary(path string) string {
+	e.mu.RLock()
+	summary, ok := e.summaries[path]
+	expired := time.Since(e.summaryLoadAt) > summaryReloadInterval
+	e.mu.RUnlock()
+	if ok || !expired {
+		return summary
+	}
+	summaries, err := e.service.GetPermissionSummaries()
+	if err != nil {
+		log.ZError(context.Background(), "load permission summaries fail", err)
+		return ""
+	}
+	e.mu.Lock()
+	e.summaries = summaries
+	e.summaryLoadAt = time.Now()
+	e.mu.Unlock()
+	return summaries[path]
+}
+
+// purgeLoop 每天清理一次超过保留天数的日志
+func (e *OperationLogWriter) purgeLoop() {
+	e.purge()
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		e.purge()
+	}
+}
+
+func (e *OperationLogWriter) purge() {
+	before := time.Now().AddDate(0, 0, -e.retentionDays)
+	count, err := e.service.DeleteBefore(before, 1000)
+	if err != nil {
+		log.ZError(context.Background(), "purge operation logs fail", err, "before", before)
+		return
+	}
+	log.ZInfo(context.Background(), "purge operation logs success", "before", before, "count", count)
+}
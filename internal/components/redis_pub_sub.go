@@ -10,30 +10,42 @@ import (
 
 const (
 	CHANNEL_CONFIG_REFRESH = "config_refresh"
+	CHANNEL_LOG_LEVEL      = "log_level"
+	CHANNEL_DICT_REFRESH   = "dict_refresh"
 )
 
-func Subscribe(rdb redis.UniversalClient, channelName string) {
+func Subscribe(rdb redis.UniversalClient, channelNames ...string) {
 	ctx := context.Background()
 	// 订阅频道
-	pubsub := rdb.Subscribe(ctx, channelName)
+	pubsub := rdb.Subscribe(ctx, channelNames...)
 	channel := pubsub.Channel()
 	go func() {
-		log.ZInfo(ctx, "channel订阅成功", "channel", channelName)
+		log.ZInfo(ctx, "channel订阅成功", "channel", channelNames)
 		for msg := range channel {
-			defer func() {
-				if err := recover(); err != nil {
-					log.ZError(ctx, "处理消息发生错误", errors.New("Server Error"), "err", err)
-				}
-			}()
-			log.ZInfo(ctx, "channel收到一条消息", "channel", msg.Channel, "payload", msg.Payload)
-			switch msg.Channel {
-			case CHANNEL_CONFIG_REFRESH:
-				application.AppContext.GetComponent(COMPONENT_MY_CONFIG).(*MyConfig).ReloadAll()
-			}
+			handleMessage(ctx, msg)
 		}
 	}()
 }
 
+func handleMessage(ctx context.Context, msg *redis.Message) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.ZError(ctx, "处理消息发生错误", errors.New("Server Error"), "err", err)
+		}
+	}()
+	log.ZInfo(ctx, "channel收到一条消息", "channel", msg.Channel, "payload", msg.Payload)
+	switch msg.Channel {
+	case CHANNEL_CONFIG_REFRESH:
+		if err := application.AppContext.GetComponent(COMPONENT_MY_CONFIG).(*MyConfig).ReloadIfChanged(); err != nil {
+			log.ZError(ctx, "重新加载动态配置失败", err, "payload", msg.Payload)
+		}
+	case CHANNEL_LOG_LEVEL:
+		applyLogLevel(ctx, msg.Payload)
+	case CHANNEL_DICT_REFRESH:
+		application.AppContext.GetComponent(COMPONENT_DICT_CACHE).(*DictCache).removeLocal(ctx, msg.Payload)
+	}
+}
+
 func Publish(rdb redis.UniversalClient, channelName string, message interface{}) error {
 	ctx := context.Background()
 	return rdb.Publish(ctx, channelName, message).Err()
@@ -0,0 +1,5 @@
+package dto
+
+type FeatureFlagGetReq struct {
+	PlatformID int `json:"platformId"` // 未登录时由客户端传入平台，见 constant 中的 PlatformID；登录后以token中的平台为准
+}
@@ -1,6 +1,11 @@
 package dto
 
-import "orderin-server/pkg/common/dto"
+import (
+	"orderin-server/pkg/common/customtypes"
+	"orderin-server/pkg/common/dto"
+	"orderin-server/pkg/common/dynconfig"
+	"time"
+)
 
 type SysConfigAddReq struct {
 	Name        string `json:"name" vd:"@:len($)>0 && len($)<50"`
@@ -33,3 +38,85 @@ type SysConfigLogPageQueryReq struct {
 	dto.Pagination `search:"-"`
 	ConfigID       int64 `json:"configId,string" vd:"@:$>0" search:"type:exact;column:config_id;table:sys_config_logs"`
 }
+
+type SysConfigDefinitionResp struct {
+	dynconfig.Definition
+	Value      string `json:"value"`      // 数据库中的值，未配置时为空
+	Configured bool   `json:"configured"` // 是否已在数据库中配置
+}
+
+type SysConfigVersionResp struct {
+	Version   int64                   `json:"version"` // 当前配置版本号
+	Instances []SysConfigInstanceResp `json:"instances"`
+}
+
+type SysConfigInstanceResp struct {
+	Instance   string    `json:"instance"` // 实例标识，主机名-进程号
+	Version    int64     `json:"version"`  // 实例已加载的配置版本号
+	LoadedAt   time.Time `json:"loadedAt"`
+	ReportedAt time.Time `json:"reportedAt"`
+	UpToDate   bool      `json:"upToDate"` // 是否已加载最新版本
+}
+
+type SysConfigDiffReq struct {
+	ConfigID  int64 `json:"configId,string" vd:"@:$>0"`
+	FromLogID int64 `json:"fromLogId,string"` // 日志id，为0表示当前值
+	ToLogID   int64 `json:"toLogId,string"`   // 日志id，为0表示当前值
+}
+
+// SysConfigRevision 配置的某个版本，即某条日志修改后的值
+type SysConfigRevision struct {
+	LogID     int64             `json:"logId,string"` // 为0表示当前值
+	Value     string            `json:"value"`
+	ChangedAt *customtypes.Time `json:"changedAt"`
+}
+
+type SysConfigDiffResp struct {
+	ConfigID  int64              `json:"configId,string"`
+	Name      string             `json:"name"`
+	ValueType string             `json:"valueType"`
+	From      SysConfigRevision  `json:"from"`
+	To        SysConfigRevision  `json:"to"`
+	Changes   []dynconfig.Change `json:"changes"`
+}
+
+type SysConfigRollbackReq struct {
+	LogID  int64 `json:"logId,string" vd:"@:$>0"`
+	Before bool  `json:"before"` // 为true时回滚到该日志修改之前的值，否则回滚到该日志修改之后的值
+}
+
+type SysConfigExportReq struct {
+	Names []string `json:"names"` // 为空时导出全部
+}
+
+// SysConfigSnapshot 配置快照，用于在环境之间迁移配置
+type SysConfigSnapshot struct {
+	ExportedAt customtypes.Time        `json:"exportedAt"`
+	Configs    []SysConfigSnapshotItem `json:"configs"`
+}
+
+type SysConfigSnapshotItem struct {
+	Name        string `json:"name" vd:"@:len($)>0 && len($)<50"`
+	Value       string `json:"value" vd:"@:len($)>0"`
+	ValueType   string `json:"valueType"`
+	Description string `json:"description" vd:"@:len($)<100"`
+}
+
+type SysConfigImportReq struct {
+	Configs []SysConfigSnapshotItem `json:"configs" vd:"@:len($)>0"`
+	DryRun  bool                    `json:"dryRun"` // 为true时只返回将要发生的变更，不写入
+}
+
+type SysConfigImportResp struct {
+	DryRun    bool                  `json:"dryRun"`
+	Created   int                   `json:"created"`
+	Updated   int                   `json:"updated"`
+	Unchanged int                   `json:"unchanged"`
+	Items     []SysConfigImportItem `json:"items"`
+}
+
+type SysConfigImportItem struct {
+	Name    string             `json:"name"`
+	Action  string             `json:"action"` // create|update|unchanged
+	Changes []dynconfig.Change `json:"changes"`
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"orderin-server/internal/models"
+	"orderin-server/pkg/common/customtypes"
 	"orderin-server/pkg/common/dto"
 )
 
@@ -9,11 +10,13 @@ type SysDictAddReq struct {
 	Name        string `json:"name" vd:"@:len($)>0 && len($)<50"`
 	Label       string `json:"label" vd:"@:len($)>0"`
 	Description string `json:"description" vd:"@:len($)<100"`
+	Public      bool   `json:"public"` // 是否公开，公开的字典可通过app-api匿名读取
 }
 
 type SysDictUpdateReq struct {
 	ID          int64  `json:"id" vd:"@:$>0"`
 	Description string `json:"description" vd:"@:len($)<100"`
+	Public      *bool  `json:"public"` // 为空时不修改
 }
 
 type SysDictDeleteReq struct {
@@ -34,6 +37,8 @@ type SysDictItemPageQueryReq struct {
 	dto.Pagination `search:"-"`
 	DictName       string `json:"dictName" vd:"@:len($)>0 && len($)<50" search:"type:exact;column:dict_name;table:sys_dict_items"`
 	Keyword        string `json:"keyword" search:"type:contains;column:item_label,item_value,description;table:sys_dict_items"`
+	Tree           bool   `json:"tree" search:"-"`              // 为true时按树返回：匹配的字典项连同其上级组成树，按顶级字典项分页
+	Depth          int    `json:"depth" search:"-" vd:"@:$>=0"` // 树模式下返回的层数，0表示不限
 	DictItemOrder
 }
 
@@ -42,22 +47,26 @@ type DictItemOrder struct {
 }
 
 type SysDictItemAddReq struct {
-	DictName    string `json:"dictName" vd:"@:len($)>0 && len($)<50"`
-	ItemLabel   string `json:"itemLabel" vd:"@:len($)>0"`
-	ItemValue   string `json:"itemValue" vd:"@:len($)>0"`
-	Description string `json:"description" vd:"@:len($)<50"`
-	IsDefault   bool   `json:"isDefault"`
-	Sort        int    `json:"sort"`
+	DictName     string                `json:"dictName" vd:"@:len($)>0 && len($)<50"`
+	ItemLabel    string                `json:"itemLabel" vd:"@:len($)>0"`
+	ItemValue    string                `json:"itemValue" vd:"@:len($)>0"`
+	ParentValue  string                `json:"parentValue"`  // 上级字典项的值，为空表示顶级
+	Translations customtypes.StringMap `json:"translations"` // 各语言的标签，如{"en-US":"Normal"}
+	Description  string                `json:"description" vd:"@:len($)<50"`
+	IsDefault    bool                  `json:"isDefault"`
+	Sort         int                   `json:"sort"`
 }
 
 type SysDictItemUpdateReq struct {
-	ID          int64  `json:"id" vd:"@:$>0"`
-	ItemLabel   string `json:"itemLabel" vd:"@:len($)>0"`
-	ItemValue   string `json:"itemValue" vd:"@:len($)>0"`
-	Description string `json:"description" vd:"@:len($)<50"`
-	Status      int    `json:"status" vd:"@:in($,1,2)"`
-	IsDefault   bool   `json:"isDefault"`
-	Sort        int    `json:"sort"`
+	ID           int64                 `json:"id" vd:"@:$>0"`
+	ItemLabel    string                `json:"itemLabel" vd:"@:len($)>0"`
+	ItemValue    string                `json:"itemValue" vd:"@:len($)>0"`
+	ParentValue  string                `json:"parentValue"`  // 上级字典项的值，为空表示顶级
+	Translations customtypes.StringMap `json:"translations"` // 各语言的标签，为空时不修改，传{}时清空
+	Description  string                `json:"description" vd:"@:len($)<50"`
+	Status       int                   `json:"status" vd:"@:in($,1,2)"`
+	IsDefault    bool                  `json:"isDefault"`
+	Sort         int                   `json:"sort"`
 }
 
 type SysDictGetItemsReq struct {
@@ -68,6 +77,80 @@ type SysDictBatchGetItemsReq struct {
 	Names []string `json:"names" vd:"@:len($)>0"`
 }
 
+type SysDictItemTreeReq struct {
+	DictName    string `json:"dictName" vd:"@:len($)>0 && len($)<50"`
+	ParentValue string `json:"parentValue"`       // 从该字典项的下级开始，为空时从顶级开始
+	Depth       int    `json:"depth" vd:"@:$>=0"` // 返回的层数，0表示不限
+}
+
+type SysDictItemPathReq struct {
+	DictName string `json:"dictName" vd:"@:len($)>0 && len($)<50"`
+	Value    string `json:"value" vd:"@:len($)>0"`
+}
+
+type SysDictItemTree struct {
+	models.SysDictItem
+	Children []*SysDictItemTree `json:"children"`
+}
+
 type SysDictGetItemsResp struct {
-	List []models.SysDictItem `json:"list"`
+	List    []models.SysDictItem `json:"list"`
+	Version string               `json:"version"` // 内容版本，与响应头 ETag 一致，可在请求头 If-None-Match 中带上以跳过未变化的内容
+}
+
+// SysDictBundle 字典及其字典项，用于导入导出和种子文件
+type SysDictBundle struct {
+	Dicts []SysDictBundleDict `json:"dicts" yaml:"dicts"`
+}
+
+type SysDictBundleDict struct {
+	Name        string              `json:"name" yaml:"name"`
+	Label       string              `json:"label" yaml:"label"`
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
+	Public      bool                `json:"public,omitempty" yaml:"public,omitempty"`
+	Items       []SysDictBundleItem `json:"items" yaml:"items"`
+}
+
+type SysDictBundleItem struct {
+	Label        string            `json:"label" yaml:"label"`
+	Value        string            `json:"value" yaml:"value"`
+	Parent       string            `json:"parent,omitempty" yaml:"parent,omitempty"`             // 上级字典项的值
+	Translations map[string]string `json:"translations,omitempty" yaml:"translations,omitempty"` // 各语言的标签
+	Description  string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Status       int               `json:"status,omitempty" yaml:"status,omitempty"` // 1正常 2禁用，为空时为1
+	IsDefault    bool              `json:"isDefault,omitempty" yaml:"isDefault,omitempty"`
+	Sort         int               `json:"sort" yaml:"sort"`
+}
+
+type SysDictExportReq struct {
+	Names  []string `json:"names"`                                    // 为空时导出全部
+	Format string   `json:"format" vd:"@:in($,'json','yaml','xlsx')"` // json|yaml|xlsx
+}
+
+type SysDictClientConstantsReq struct {
+	Names     []string `json:"names"`                                   // 为空时包括全部字典
+	Format    string   `json:"format" vd:"@:in($,'','json','ts','go')"` // json|ts|go，默认json
+	GoPackage string   `json:"goPackage"`                               // format为go时的包名，默认constants
+}
+
+type SysDictImportReq struct {
+	Upsert bool `form:"upsert"` // 为true时覆盖已存在且内容不同的字典和字典项，否则跳过并报告冲突
+	DryRun bool `form:"dryRun"` // 为true时只返回将要发生的变更，不写入
+}
+
+type SysDictImportResp struct {
+	DryRun      bool                    `json:"dryRun"`
+	DictCreated int                     `json:"dictCreated"`
+	DictUpdated int                     `json:"dictUpdated"`
+	ItemCreated int                     `json:"itemCreated"`
+	ItemUpdated int                     `json:"itemUpdated"`
+	Unchanged   int                     `json:"unchanged"` // 内容一致的字典和字典项个数
+	Conflicts   []SysDictImportConflict `json:"conflicts"` // 未覆盖的冲突
+	Dicts       []string                `json:"dicts"`     // 有变更的字典名称
+}
+
+type SysDictImportConflict struct {
+	DictName  string `json:"dictName"`
+	ItemValue string `json:"itemValue,omitempty"` // 为空表示字典本身冲突
+	Reason    string `json:"reason"`
 }
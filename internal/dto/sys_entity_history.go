@@ -0,0 +1,10 @@
+package dto
+
+import "orderin-server/pkg/common/dto"
+
+type SysEntityHistoryTimelineReq struct {
+	dto.Pagination `search:"-"`
+	EntityType     string `json:"entityType" vd:"@:len($)>0" search:"type:exact;column:entity_type;table:sys_entity_histories"`
+	EntityID       string `json:"entityId" vd:"@:len($)>0" search:"type:exact;column:entity_id;table:sys_entity_histories"`
+	Action         string `json:"action" search:"type:exact;column:action;table:sys_entity_histories"`
+}
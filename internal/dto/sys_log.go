@@ -0,0 +1,8 @@
+package dto
+
+type SysLogSetLevelReq struct {
+	Module string `json:"module"`          //root|sql|http|wechat，为空表示root
+	Level  string `json:"level"`           //debug|info|warn|error
+	Ttl    int64  `json:"ttl" vd:"@:$>=0"` //有效期（秒），到期后恢复为配置文件中的级别，0表示一直有效
+	Reset  bool   `json:"reset"`           //撤销运行时设置，恢复为配置文件中的级别
+}
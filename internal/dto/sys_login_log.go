@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"orderin-server/pkg/common/customtypes"
+	"orderin-server/pkg/common/dto"
+)
+
+type SysLoginLogPageQueryReq struct {
+	dto.Pagination `search:"-"`
+	SysLoginLogFilter
+}
+
+type SysLoginLogCursorQueryReq struct {
+	dto.CursorPagination `search:"-"`
+	SysLoginLogFilter
+}
+
+type SysLoginLogFilter struct {
+	UserID         int64            `json:"userId,string" search:"type:exact;column:user_id;table:sys_login_logs"`
+	Account        string           `json:"account" search:"type:exact;column:account;table:sys_login_logs"`
+	Method         string           `json:"method" search:"type:exact;column:method;table:sys_login_logs"`
+	Success        *bool            `json:"success" search:"type:exact;column:success;table:sys_login_logs"`
+	Ip             string           `json:"ip" search:"type:exact;column:ip;table:sys_login_logs"`
+	CreatedAtStart customtypes.Time `json:"createdAtStart" search:"type:gt;column:created_at;table:sys_login_logs"`
+	CreatedAtEnd   customtypes.Time `json:"createdAtEnd" search:"type:lt;column:created_at;table:sys_login_logs"`
+
+	LoginLogOrder
+}
+
+type SysLoginLogPersonalPageQueryReq struct {
+	dto.Pagination `search:"-"`
+	Success        *bool            `json:"success" search:"type:exact;column:success;table:sys_login_logs"`
+	CreatedAtStart customtypes.Time `json:"createdAtStart" search:"type:gt;column:created_at;table:sys_login_logs"`
+	CreatedAtEnd   customtypes.Time `json:"createdAtEnd" search:"type:lt;column:created_at;table:sys_login_logs"`
+
+	LoginLogOrder
+}
+
+type LoginLogOrder struct {
+	LoginLogIdOrder string `json:"idOrder" search:"type:order;column:id;table:sys_login_logs" `
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"orderin-server/pkg/common/customtypes"
+	"orderin-server/pkg/common/dto"
+)
+
+type SysOperationLogPageQueryReq struct {
+	dto.Pagination `search:"-"`
+	SysOperationLogFilter
+}
+
+type SysOperationLogCursorQueryReq struct {
+	dto.CursorPagination `search:"-"`
+	SysOperationLogFilter
+}
+
+type SysOperationLogFilter struct {
+	UserID         int64            `json:"userId,string" search:"type:exact;column:user_id;table:sys_operation_logs"`
+	Path           string           `json:"path" search:"type:contains;column:path;table:sys_operation_logs"`
+	RequestId      string           `json:"requestId" search:"type:exact;column:request_id;table:sys_operation_logs"`
+	RespCode       *int             `json:"respCode" search:"type:exact;column:resp_code;table:sys_operation_logs"`
+	CreatedAtStart customtypes.Time `json:"createdAtStart" search:"type:gt;column:created_at;table:sys_operation_logs"`
+	CreatedAtEnd   customtypes.Time `json:"createdAtEnd" search:"type:lt;column:created_at;table:sys_operation_logs"`
+
+	OperationLogOrder
+}
+
+type OperationLogOrder struct {
+	OperationLogIdOrder string `json:"idOrder" search:"type:order;column:id;table:sys_operation_logs" `
+}
@@ -19,6 +19,10 @@ type SysRoleDeleteReq struct {
 	ID int64 `json:"id,string" vd:"@:$>0"`
 }
 
+type SysRoleGetReq struct {
+	ID int64 `json:"id,string" vd:"@:$>0"`
+}
+
 type SysRoleGetByNameReq struct {
 	Name string `json:"name" vd:"@:len($)>1 && len($)<20"`
 }
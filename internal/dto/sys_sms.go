@@ -16,6 +16,15 @@ type SysSmsSendToMyselfReq struct {
 
 type SysSmsLogPageQueryReq struct {
 	dto.Pagination `search:"-"`
+	SysSmsLogFilter
+}
+
+type SysSmsLogCursorQueryReq struct {
+	dto.CursorPagination `search:"-"`
+	SysSmsLogFilter
+}
+
+type SysSmsLogFilter struct {
 	Phone          string           `json:"phone" search:"type:exact;column:phone;table:sys_sms_logs"`
 	TemplateCode   string           `json:"templateId" search:"type:exact;column:template_code;table:sys_sms_logs"`
 	CreatedAtStart customtypes.Time `json:"createdAtStart" search:"type:gt;column:created_at;table:sys_sms_logs"`
@@ -60,6 +60,7 @@ type SysUserPerfectInfoReq struct {
 	Wechat         string `json:"wechat"`
 	WechatNickname string `json:"wechatNickname"`
 	WechatQrCode   string `json:"wechatQrCode"`
+	Language       string `json:"language"` // 偏好语言，如zh-CN、en-US
 }
 
 type SysUserBindMFAReq struct {
@@ -83,6 +84,11 @@ type SysUserLoginByPasswordReq struct {
 	Password string `json:"password" vd:"@:regexp('^(?=.*[A-Za-z])(?=.*\d)(?=.*[@$!%*#?&])[A-Za-z\d@$!%*#?&]{8,}$')"`
 }
 
+type SysUserLoginByMfaReq struct {
+	Ticket string `json:"ticket" vd:"@:len($)>0"` //账号密码登录返回的二步验证凭证
+	Code   string `json:"code" vd:"@:regexp('^[0-9]{6}$')"`
+}
+
 type SysUserLoginByPhoneReq struct {
 	Phone   string `json:"phone" vd:"@:phone($)"`
 	SmsCode string `json:"smsCode" vd:"@:regexp('^[0-9]{6}$')"`
@@ -122,6 +128,8 @@ type SysUserAddResp struct {
 type SysUserLoginResp struct {
 	Token             string `json:"token"`
 	ExpireTimeSeconds int64  `json:"expireTimeSeconds"`
+	MfaRequired       bool   `json:"mfaRequired,omitempty"` //为true时需用mfaTicket调用login_by_mfa完成登录
+	MfaTicket         string `json:"mfaTicket,omitempty"`   //二步验证凭证，5分钟内有效
 }
 
 type SysUserPersonalInfoResp struct {
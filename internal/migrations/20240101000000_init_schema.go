@@ -0,0 +1,277 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"orderin-server/pkg/common/db/migrate"
+)
+
+// 初始表结构。表结构是该版本模型的快照，与 internal/models 无关，修改模型时需新增迁移，不能修改这里。
+// 已有的库中表已存在，执行时只补齐缺少的字段和索引
+func init() {
+	register(migrate.Migration{
+		Version: "20240101000000",
+		Name:    "init_schema",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(initSchemaModels()...); err != nil {
+				return err
+			}
+			// 早期的库中表已存在但没有联合唯一索引
+			for _, index := range []struct {
+				model interface{}
+				name  string
+			}{
+				{&initSysRoleUser{}, "uni_role_id_user_id"},
+				{&initSysRolePermission{}, "uni_role_id_permission_id"},
+			} {
+				if tx.Migrator().HasIndex(index.model, index.name) {
+					continue
+				}
+				if err := tx.Migrator().CreateIndex(index.model, index.name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			models := initSchemaModels()
+			for i := len(models) - 1; i >= 0; i-- {
+				if err := tx.Migrator().DropTable(models[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func initSchemaModels() []interface{} {
+	return []interface{}{&initSysUser{}, &initSysRole{}, &initSysPermission{}, &initSysRoleUser{},
+		&initSysRolePermission{}, &initSysConfig{}, &initSysConfigLog{}, &initSysDict{}, &initSysDictItem{}, &initSysSmsLog{},
+		&initWxAuthorizer{}, &initWxAuthorizerMedia{}, &initSysOperationLog{}, &initSysLoginLog{}, &initSysEntityHistory{}}
+}
+
+type initSnowID struct {
+	ID int64 `gorm:"column:id;primaryKey;autoIncrement:false"`
+}
+
+type initIncrID struct {
+	ID int64 `gorm:"column:id;primaryKey;autoIncrement:true"`
+}
+
+type initCreatedModel struct {
+	CreatedAt time.Time `gorm:"column:created_at;comment:创建时间;autoCreateTime;not null"`
+	CreatedBy *int64    `gorm:"column:created_by;comment:创建人;"`
+}
+
+type initBaseModel struct {
+	CreatedAt time.Time `gorm:"column:created_at;comment:创建时间;autoCreateTime;not null"`
+	CreatedBy *int64    `gorm:"column:created_by;comment:创建人;"`
+	UpdatedAt time.Time `gorm:"column:updated_at;comment:更新时间;autoUpdateTime"`
+	UpdatedBy *int64    `gorm:"column:updated_by;comment:更新人"`
+}
+
+type initLogicalDeleted struct {
+	Deleted   bool       `gorm:"column:deleted;default:0;comment:是否删除;not null"`
+	DeletedBy *int64     `gorm:"column:deleted_by;comment:删除人;"`
+	DeletedAt *time.Time `gorm:"column:deleted_at;comment:删除时间;"`
+}
+
+type initSysUser struct {
+	SnowID            initSnowID    `gorm:"embedded"`
+	Username          string        `gorm:"column:username;type:varchar(20);comment:账户名;unique;not null"`
+	PasswordSalt      string        `gorm:"column:password_salt;type:varchar(64);comment:密码加盐;not null"`
+	PasswordResetTime *time.Time    `gorm:"column:password_reset_time;comment:密码重置时间"`
+	Avatar            string        `gorm:"column:avatar;type:varchar(255);default:'';comment:头像;"`
+	Nickname          string        `gorm:"column:nickname;type:varchar(50);comment:昵称"`
+	RealName          string        `gorm:"column:real_name;type:varchar(50);comment:真实姓名"`
+	Phone             string        `gorm:"column:phone;type:varchar(20);comment:手机号"`
+	Email             string        `gorm:"column:email;type:varchar(100);comment:邮箱"`
+	OpenId            string        `gorm:"column:open_id;type:varchar(50);comment:微信openid"`
+	Wechat            string        `gorm:"column:wechat;type:varchar(50);comment:微信号"`
+	WechatNickname    string        `gorm:"column:wechat_nickname;type:varchar(50);comment:微信昵称"`
+	WechatQrCode      string        `gorm:"column:wechat_qr_code;type:varchar(255);comment:微信二维码"`
+	EnableMFA         bool          `gorm:"column:enable_mfa;default:0;comment:是否开启二步验证"`
+	MFAKey            string        `gorm:"column:mfa_key;type:varchar(100);default:'';comment:多因素认证密钥"`
+	RecoverCode       string        `gorm:"column:recover_code;type:varchar(10);comment:恢复代码，用于重置二步验证"`
+	Status            int           `gorm:"column:status;type:integer;default:1;comment:状态：1正常，2禁用"`
+	LastLoginTime     *time.Time    `gorm:"column:last_login_time;comment:最近登录时间"`
+	LastLoginIp       string        `gorm:"column:last_login_ip;type:varchar(50);comment:最近登录ip"`
+	SuperAdmin        bool          `gorm:"column:super_admin;default:0;comment:是否超级管理员"`
+	Language          string        `gorm:"column:language;type:varchar(16);default:'';comment:偏好语言，如zh-CN、en-US，为空时按请求头Accept-Language"`
+	Base              initBaseModel `gorm:"embedded"`
+}
+
+type initSysRole struct {
+	SnowID      initSnowID    `gorm:"embedded"`
+	Name        string        `gorm:"column:name;type:varchar(20);comment:角色名称;unique;not null"`
+	Description string        `gorm:"column:description;type:varchar(50);comment:角色描述;"`
+	Base        initBaseModel `gorm:"embedded"`
+}
+
+type initSysPermission struct {
+	SnowID      initSnowID    `gorm:"embedded"`
+	ParentID    int64         `gorm:"column:parent_id;comment:父权限ID"`
+	Type        string        `gorm:"column:type;type:varchar(10);comment:类型，API,Page,Button"`
+	Anon        bool          `gorm:"column:anon;default:0;comment:可匿名访问;not null"`
+	Auth        bool          `gorm:"column:auth;default:1;comment:是否需要鉴权;not null"`
+	Name        string        `gorm:"column:name;type:varchar(100);comment:名称;unique;not null"`
+	Description string        `gorm:"column:description;type:varchar(50);comment:描述;"`
+	Base        initBaseModel `gorm:"embedded"`
+}
+
+type initSysRoleUser struct {
+	SnowID initSnowID    `gorm:"embedded"`
+	RoleID int64         `gorm:"column:role_id;uniqueIndex:uni_role_id_user_id;comment:角色ID"`
+	UserID int64         `gorm:"column:user_id;uniqueIndex:uni_role_id_user_id;comment:用户ID"`
+	Base   initBaseModel `gorm:"embedded"`
+}
+
+type initSysRolePermission struct {
+	SnowID       initSnowID    `gorm:"embedded"`
+	RoleID       int64         `gorm:"column:role_id;uniqueIndex:uni_role_id_permission_id;comment:角色ID"`
+	PermissionID int64         `gorm:"column:permission_id;uniqueIndex:uni_role_id_permission_id;comment:权限ID"`
+	Base         initBaseModel `gorm:"embedded"`
+}
+
+type initSysConfig struct {
+	SnowID      initSnowID    `gorm:"embedded"`
+	Name        string        `gorm:"column:name;type:varchar(50);comment:配置名称;not null"`
+	Value       string        `gorm:"column:value;type:text;comment:配置值;"`
+	ValueType   string        `gorm:"column:value_type;type:varchar(20);comment:值类型，number｜bool｜string｜json;not null"`
+	Description string        `gorm:"column:description;type:varchar(100);comment:配置描述;not null"`
+	Base        initBaseModel `gorm:"embedded"`
+}
+
+type initSysConfigLog struct {
+	SnowID        initSnowID       `gorm:"embedded"`
+	ConfigID      int64            `gorm:"column:config_id;comment:配置id;not null"`
+	OldValue      string           `gorm:"column:old_value;type:text;comment:旧值;"`
+	NewValue      string           `gorm:"column:new_value;type:text;comment:新值;"`
+	RollbackLogID *int64           `gorm:"column:rollback_log_id;comment:回滚所依据的日志id"`
+	Created       initCreatedModel `gorm:"embedded"`
+}
+
+type initSysDict struct {
+	IncrID         initIncrID         `gorm:"embedded"`
+	Label          string             `gorm:"column:label;type:varchar(50);comment:标签;"`
+	Name           string             `gorm:"column:name;type:varchar(50);comment:字典名称;unique"`
+	Description    string             `gorm:"column:description;type:varchar(100);comment:字典描述;"`
+	Public         bool               `gorm:"column:public;default:0;comment:是否公开，公开的字典可通过app-api匿名读取;"`
+	Base           initBaseModel      `gorm:"embedded"`
+	LogicalDeleted initLogicalDeleted `gorm:"embedded"`
+}
+
+type initSysDictItem struct {
+	IncrID       initIncrID    `gorm:"embedded"`
+	DictName     string        `gorm:"column:dict_name;type:varchar(50);comment:字典名称;"`
+	ItemLabel    string        `gorm:"column:item_label;type:varchar(50);comment:标签;"`
+	Translations string        `gorm:"column:translations;type:json;comment:各语言的标签，key为语言如en-US;"`
+	ItemValue    string        `gorm:"column:item_value;type:varchar(200);comment:值;"`
+	ParentValue  string        `gorm:"column:parent_value;type:varchar(200);default:'';comment:上级字典项的值，为空表示顶级;"`
+	Description  string        `gorm:"column:description;type:varchar(50);default:'';comment:字典项描述;"`
+	Status       int           `gorm:"column:status;type:integer;comment:状态;"`
+	IsDefault    bool          `gorm:"column:is_default;default:0;comment:是否默认;"`
+	Sort         int           `gorm:"column:sort;type:integer;comment:排序;"`
+	Base         initBaseModel `gorm:"embedded"`
+}
+
+type initSysSmsLog struct {
+	SnowID       initSnowID       `gorm:"embedded"`
+	TemplateCode string           `gorm:"column:template_code;type:varchar(50);comment:短信模板;"`
+	Vendor       string           `gorm:"column:vendor;type:varchar(50);comment:短信服务商;"`
+	Scene        string           `gorm:"column:scene;type:varchar(20);comment:短信场景;not null"`
+	Phone        string           `gorm:"column:phone;type:varchar(11);comment:手机号;not null"`
+	Content      string           `gorm:"column:content;type:varchar(255);comment:短信内容;not null"`
+	Status       string           `gorm:"column:status;type:char(1);comment:状态;not null"`
+	Remark       string           `gorm:"column:remark;type:varchar(100);comment:备注;not null"`
+	Created      initCreatedModel `gorm:"embedded"`
+}
+
+type initWxAuthorizer struct {
+	SnowID                     initSnowID    `gorm:"embedded"`
+	ComponentAppid             string        `gorm:"column:component_appid;type:varchar(100);comment:第三方平台 appid"`
+	AuthorizerAppid            string        `gorm:"column:authorizer_appid;type:varchar(100);comment:授权方 appid"`
+	DefaultKfAccount           string        `gorm:"column:default_kf_account;type:varchar(100);comment:默认的客服账号"`
+	NickName                   string        `gorm:"column:nick_name;type:varchar(100);comment:授权方昵称"`
+	HeadImg                    string        `gorm:"column:Head_img;type:varchar(255);comment:授权方头像"`
+	UserName                   string        `gorm:"column:user_name;type:varchar(100);comment:授权方原始ID"`
+	QrcodeUrl                  string        `gorm:"column:qrcode_url;type:varchar(255);comment:授权方二维码图片的 URL"`
+	ServiceType                string        `gorm:"column:service_type;type:varchar(100);comment:服务类型"`
+	VerifyType                 string        `gorm:"column:verify_type;type:varchar(100);comment:认证类型"`
+	PrincipalName              string        `gorm:"column:principal_name;type:varchar(100);comment:主体名称"`
+	AuthorizationCode          string        `gorm:"column:authorization_code;type:varchar(200);comment:授权码"`
+	AuthorizationCodeExpiresAt time.Time     `gorm:"column:authorization_code_expires_at;comment:令牌过期时间"`
+	AuthorizerAccessToken      string        `gorm:"column:authorizer_access_token;type:varchar(200);comment:授权方令牌"`
+	AccessTokenExpiresAt       time.Time     `gorm:"column:access_token_expires_at;comment:令牌过期时间"`
+	AuthorizerRefreshToken     string        `gorm:"column:authorizer_refresh_token;type:varchar(200);comment:刷新令牌"`
+	Base                       initBaseModel `gorm:"embedded"`
+}
+
+type initWxAuthorizerMedia struct {
+	SnowID    initSnowID    `gorm:"embedded"`
+	AppId     string        `gorm:"column:app_id;type:varchar(100);comment:公众号appId"`
+	UserID    *int64        `gorm:"column:user_id;comment:用户id;"`
+	Category  string        `gorm:"column:category;type:varchar(20);comment:分类"`
+	MediaType string        `gorm:"column:media_type;type:varchar(20);comment:媒体类型"`
+	MediaID   string        `gorm:"column:me;type:varchar(50);comment:媒体id"`
+	Base      initBaseModel `gorm:"embedded"`
+}
+
+type initSysOperationLog struct {
+	SnowID    initSnowID `gorm:"embedded"`
+	UserID    int64      `gorm:"column:user_id;comment:操作人;index"`
+	Platform  string     `gorm:"column:platform;type:varchar(20);comment:平台;"`
+	Ip        string     `gorm:"column:ip;type:varchar(50);comment:ip;"`
+	RequestId string     `gorm:"column:request_id;type:varchar(64);comment:请求id;"`
+	Method    string     `gorm:"column:method;type:varchar(10);comment:请求方法;"`
+	Path      string     `gorm:"column:path;type:varchar(100);comment:权限路径;index"`
+	Summary   string     `gorm:"column:summary;type:varchar(50);comment:接口描述;"`
+	Body      string     `gorm:"column:body;type:text;comment:请求体(已脱敏);"`
+	RespCode  int        `gorm:"column:resp_code;comment:响应码;"`
+	Latency   int64      `gorm:"column:latency;comment:耗时(ms);"`
+	CreatedAt time.Time  `gorm:"column:created_at;comment:创建时间;autoCreateTime;index;not null"`
+}
+
+type initSysLoginLog struct {
+	SnowID     initSnowID `gorm:"embedded"`
+	UserID     int64      `gorm:"column:user_id;comment:用户id;index"`
+	Account    string     `gorm:"column:account;type:varchar(100);comment:登录账号(用户名/手机号);"`
+	Method     string     `gorm:"column:method;type:varchar(20);comment:登录方式，password|phone|wechat|mfa;"`
+	Success    bool       `gorm:"column:success;default:0;comment:是否成功;not null"`
+	FailReason string     `gorm:"column:fail_reason;type:varchar(100);comment:失败原因;"`
+	Ip         string     `gorm:"column:ip;type:varchar(50);comment:ip;"`
+	Location   string     `gorm:"column:location;type:varchar(100);comment:登录地点;"`
+	UserAgent  string     `gorm:"column:user_agent;type:varchar(500);comment:User-Agent;"`
+	Device     string     `gorm:"column:device;type:varchar(20);comment:设备类型;"`
+	Os         string     `gorm:"column:os;type:varchar(50);comment:操作系统;"`
+	Browser    string     `gorm:"column:browser;type:varchar(50);comment:浏览器;"`
+	CreatedAt  time.Time  `gorm:"column:created_at;comment:创建时间;autoCreateTime;index;not null"`
+}
+
+type initSysEntityHistory struct {
+	SnowID     initSnowID `gorm:"embedded"`
+	EntityType string     `gorm:"column:entity_type;type:varchar(50);comment:实体类型;index:idx_entity;not null"`
+	EntityID   string     `gorm:"column:entity_id;type:varchar(50);comment:实体id;index:idx_entity;not null"`
+	Action     string     `gorm:"column:action;type:varchar(20);comment:操作，create|update|delete;not null"`
+	Changes    string     `gorm:"column:changes;type:text;comment:字段变更，json数组;"`
+	CreatedBy  int64      `gorm:"column:created_by;comment:操作人;"`
+	CreatedAt  time.Time  `gorm:"column:created_at;comment:创建时间;autoCreateTime;not null"`
+}
+
+func (initSysUser) TableName() string           { return "sys_users" }
+func (initSysRole) TableName() string           { return "sys_roles" }
+func (initSysPermission) TableName() string     { return "sys_permissions" }
+func (initSysRoleUser) TableName() string       { return "sys_role_users" }
+func (initSysRolePermission) TableName() string { return "sys_role_permissions" }
+func (initSysConfig) TableName() string         { return "sys_configs" }
+func (initSysConfigLog) TableName() string      { return "sys_config_logs" }
+func (initSysDict) TableName() string           { return "sys_dicts" }
+func (initSysDictItem) TableName() string       { return "sys_dict_items" }
+func (initSysSmsLog) TableName() string         { return "sys_sms_logs" }
+func (initWxAuthorizer) TableName() string      { return "wx_authorizers" }
+func (initWxAuthorizerMedia) TableName() string { return "wx_authorizer_media" }
+func (initSysOperationLog) TableName() string   { return "sys_operation_logs" }
+func (initSysLoginLog) TableName() string       { return "sys_login_logs" }
+func (initSysEntityHistory) TableName() string  { return "sys_entity_histories" }
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"embed"
+
+	"gorm.io/gorm"
+	"orderin-server/pkg/common/db/migrate"
+)
+
+// SqlDir SQL 迁移文件所在目录，orderin migrate create 在这里创建文件
+const SqlDir = "internal/migrations/sql"
+
+//go:embed sql
+var sqlFS embed.FS
+
+var goMigrations []migrate.Migration
+
+// register 注册 go 编写的迁移，在迁移文件的 init 中调用
+func register(m migrate.Migration) {
+	goMigrations = append(goMigrations, m)
+}
+
+// All 返回 sql 目录中的迁移和 go 编写的迁移
+func All() ([]migrate.Migration, error) {
+	migrations, err := migrate.LoadSQL(sqlFS, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return append(migrations, goMigrations...), nil
+}
+
+// NewMigrator 创建执行全部迁移的 Migrator
+func NewMigrator(db *gorm.DB) (*migrate.Migrator, error) {
+	migrations, err := All()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(db, migrations)
+}
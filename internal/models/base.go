@@ -27,7 +27,7 @@ type BaseModel struct {
 }
 
 type LogicalDeleted struct {
-	Deleted   bool              `gorm:"column:deleted;type:tinyint(1);default:0;comment:是否删除;not null" json:"del,omitempty"`
+	Deleted   bool              `gorm:"column:deleted;default:0;comment:是否删除;not null" json:"del,omitempty"`
 	DeletedBy *int64            `gorm:"column:deleted_by;comment:删除人;" json:"deletedBy,string"`
 	DeletedAt *customtypes.Time `gorm:"column:deleted_at;comment:删除时间;" json:"deletedAt"`
 }
@@ -3,16 +3,23 @@ package models
 type SysConfig struct {
 	SnowID
 	Name        string `gorm:"column:name;type:varchar(50);comment:配置名称;not null" json:"name"`
-	Value       string `gorm:"column:value;type:tinytext;comment:配置值;" json:"value"`
+	Value       string `gorm:"column:value;type:text;comment:配置值;" json:"value"`
 	ValueType   string `gorm:"column:value_type;type:varchar(20);comment:值类型，number｜bool｜string｜json;not null" json:"valueType"`
 	Description string `gorm:"column:description;type:varchar(100);comment:配置描述;not null" json:"description"`
+	Defined     bool   `gorm:"-" json:"defined"` // 是否已在代码中注册定义
 	BaseModel
 }
 
 type SysConfigLog struct {
 	SnowID
 	ConfigID int64  `gorm:"column:config_id;comment:配置id;not null" json:"configId"`
-	OldValue string `gorm:"column:old_value;type:tinytext;comment:旧值;" json:"oldValue"`
-	NewValue string `gorm:"column:new_value;type:tinytext;comment:新值;" json:"newValue"`
+	OldValue string `gorm:"column:old_value;type:text;comment:旧值;" json:"oldValue"`
+	NewValue string `gorm:"column:new_value;type:text;comment:新值;" json:"newValue"`
+	// 由回滚产生时为回滚所依据的日志id
+	RollbackLogID *int64 `gorm:"column:rollback_log_id;comment:回滚所依据的日志id" json:"rollbackLogId,string"`
 	CreatedModel
 }
+
+func (SysConfig) HistoryEntity() string {
+	return "sys_config"
+}
@@ -1,22 +1,35 @@
 package models
 
+import "orderin-server/pkg/common/customtypes"
+
 type SysDict struct {
 	IncrID
 	Label       string `gorm:"column:label;type:varchar(50);comment:标签;" json:"label"`
 	Name        string `gorm:"column:name;type:varchar(50);comment:字典名称;unique" json:"name"`
 	Description string `gorm:"column:description;type:varchar(100);comment:字典描述;" json:"description"`
+	Public      bool   `gorm:"column:public;default:0;comment:是否公开，公开的字典可通过app-api匿名读取;" json:"public"`
 	BaseModel
 	LogicalDeleted
 }
 
 type SysDictItem struct {
 	IncrID
-	DictName    string `gorm:"column:dict_name;type:varchar(50);comment:字典名称;" json:"dictName"`
-	ItemLabel   string `gorm:"column:item_label;type:varchar(50);comment:标签;" json:"itemLabel"`
-	ItemValue   string `gorm:"column:item_value;type:varchar(200);comment:值;" json:"itemValue"`
-	Description string `gorm:"column:description;type:varchar(50);default:'';comment:字典项描述;" json:"description"`
-	Status      int    `gorm:"column:status;type:int(11);comment:状态;" json:"status"`
-	IsDefault   bool   `gorm:"column:is_default;type:tinyint(1);default:0;comment:是否默认;" json:"isDefault"`
-	Sort        int    `gorm:"column:sort;type:int(11);comment:排序;" json:"sort"`
+	DictName     string                `gorm:"column:dict_name;type:varchar(50);comment:字典名称;" json:"dictName"`
+	ItemLabel    string                `gorm:"column:item_label;type:varchar(50);comment:标签;" json:"itemLabel"`
+	Translations customtypes.StringMap `gorm:"column:translations;type:json;comment:各语言的标签，key为语言如en-US;" json:"translations"`
+	ItemValue    string                `gorm:"column:item_value;type:varchar(200);comment:值;" json:"itemValue"`
+	ParentValue  string                `gorm:"column:parent_value;type:varchar(200);default:'';comment:上级字典项的值，为空表示顶级;" json:"parentValue"`
+	Description  string                `gorm:"column:description;type:varchar(50);default:'';comment:字典项描述;" json:"description"`
+	Status       int                   `gorm:"column:status;type:integer;comment:状态;" json:"status"`
+	IsDefault    bool                  `gorm:"column:is_default;default:0;comment:是否默认;" json:"isDefault"`
+	Sort         int                   `gorm:"column:sort;type:integer;comment:排序;" json:"sort"`
 	BaseModel
 }
+
+func (SysDict) HistoryEntity() string {
+	return "sys_dict"
+}
+
+func (SysDictItem) HistoryEntity() string {
+	return "sys_dict_item"
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"encoding/json"
+	"orderin-server/pkg/common/customtypes"
+)
+
+type SysEntityHistory struct {
+	SnowID
+	EntityType string           `gorm:"column:entity_type;type:varchar(50);comment:实体类型;index:idx_entity;not null" json:"entityType"`
+	EntityID   string           `gorm:"column:entity_id;type:varchar(50);comment:实体id;index:idx_entity;not null" json:"entityId"`
+	Action     string           `gorm:"column:action;type:varchar(20);comment:操作，create|update|delete;not null" json:"action"`
+	Changes    json.RawMessage  `gorm:"column:changes;type:text;comment:字段变更，json数组;" json:"changes" swaggertype:"array,object"`
+	CreatedBy  int64            `gorm:"column:created_by;comment:操作人;" json:"createdBy,string"`
+	CreatedAt  customtypes.Time `gorm:"column:created_at;comment:创建时间;autoCreateTime;not null" json:"createdAt"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "orderin-server/pkg/common/customtypes"
+
+type SysLoginLog struct {
+	SnowID
+	UserID     int64            `gorm:"column:user_id;comment:用户id;index" json:"userId,string"`
+	Account    string           `gorm:"column:account;type:varchar(100);comment:登录账号(用户名/手机号);" json:"account"`
+	Method     string           `gorm:"column:method;type:varchar(20);comment:登录方式，password|phone|wechat|mfa;" json:"method"`
+	Success    bool             `gorm:"column:success;default:0;comment:是否成功;not null" json:"success"`
+	FailReason string           `gorm:"column:fail_reason;type:varchar(100);comment:失败原因;" json:"failReason"`
+	Ip         string           `gorm:"column:ip;type:varchar(50);comment:ip;" json:"ip"`
+	Location   string           `gorm:"column:location;type:varchar(100);comment:登录地点;" json:"location"`
+	UserAgent  string           `gorm:"column:user_agent;type:varchar(500);comment:User-Agent;" json:"userAgent"`
+	Device     string           `gorm:"column:device;type:varchar(20);comment:设备类型;" json:"device"`
+	Os         string           `gorm:"column:os;type:varchar(50);comment:操作系统;" json:"os"`
+	Browser    string           `gorm:"column:browser;type:varchar(50);comment:浏览器;" json:"browser"`
+	CreatedAt  customtypes.Time `gorm:"column:created_at;comment:创建时间;autoCreateTime;index;not null" json:"createdAt"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "orderin-server/pkg/common/customtypes"
+
+type SysOperationLog struct {
+	SnowID
+	UserID    int64            `gorm:"column:user_id;comment:操作人;index" json:"userId,string"`
+	Platform  string           `gorm:"column:platform;type:varchar(20);comment:平台;" json:"platform"`
+	Ip        string           `gorm:"column:ip;type:varchar(50);comment:ip;" json:"ip"`
+	RequestId string           `gorm:"column:request_id;type:varchar(64);comment:请求id;" json:"requestId"`
+	Method    string           `gorm:"column:method;type:varchar(10);comment:请求方法;" json:"method"`
+	Path      string           `gorm:"column:path;type:varchar(100);comment:权限路径;index" json:"path"`
+	Summary   string           `gorm:"column:summary;type:varchar(50);comment:接口描述;" json:"summary"`
+	Body      string           `gorm:"column:body;type:text;comment:请求体(已脱敏);" json:"body"`
+	RespCode  int              `gorm:"column:resp_code;comment:响应码;" json:"respCode"`
+	Latency   int64            `gorm:"column:latency;comment:耗时(ms);" json:"latency"`
+	CreatedAt customtypes.Time `gorm:"column:created_at;comment:创建时间;autoCreateTime;index;not null" json:"createdAt"`
+}
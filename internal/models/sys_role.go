@@ -20,3 +20,7 @@ type SysRolePermission struct {
 	PermissionID int64 `gorm:"column:permission_id;uniqueIndex:uni_role_id_permission_id;comment:权限ID" json:"permissionId,omitempty"`
 	BaseModel
 }
+
+func (SysRole) HistoryEntity() string {
+	return "sys_role"
+}
@@ -6,22 +6,26 @@ import (
 	"github.com/redis/go-redis/v9"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"gorm.io/gorm"
 	"net/http"
 	docs "orderin-server/docs/admin-api"
 	"orderin-server/internal/api/admin"
+	"orderin-server/pkg/common/api"
 	"orderin-server/pkg/common/auth"
 	"orderin-server/pkg/common/cache"
 	"orderin-server/pkg/common/config"
 	"orderin-server/pkg/common/constant"
 	"orderin-server/pkg/common/ginmiddleware"
 	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/tracing"
 )
 
 var (
 	AnonUrls = []string{
 		"/api/v1/sys/user/login_by_password",
 		"/api/v1/sys/user/login_by_phone",
+		"/api/v1/sys/user/login_by_mfa",
 		"/api/v1/sys/user/bind_wechat",
 		"/api/v1/sys/user/get_wechat_login_url",
 		"/api/v1/sys/user/login_by_wechat",
@@ -37,6 +41,7 @@ var (
 		"/api/v1/sys/user/perfect_info",
 		"/api/v1/sys/user/bind_mfa",
 		"/api/v1/sys/user/get_oauth2_url",
+		"/api/v1/sys/login_log/page_query_personal",
 	}
 )
 
@@ -55,29 +60,35 @@ var (
 // @host 127.0.0.1:10000
 // @BasePath /api/v1
 func NewAdminGinRouter(db *gorm.DB, rdb redis.UniversalClient) *gin.Engine {
-	if config.Config.Env.Profiles == "prod" {
+	if config.Get().Env.Profiles == "prod" {
 		gin.SetMode(gin.ReleaseMode)
 	} else {
 		gin.SetMode(gin.DebugMode)
 	}
 	r := gin.New()
+	//gin.Context.Value 回退到 Request.Context，使 span 能通过 gin.Context 传递
+	r.ContextWithFallback = true
 	//if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 	//	_ = v.RegisterValidation("required_if", ginmiddleware.RequiredIf)
 	//}
-	log.ZInfo(context.Background(), "load config", "config", config.Config)
+	log.ZInfo(context.Background(), "load config", "config", log.Redact(config.Get()))
 
 	authService := auth.NewAuthService(
 		cache.NewTokenCache(rdb),
-		config.Config.Secret,
-		config.Config.TokenPolicy.Expire,
+		config.Get().Secret,
+		config.Get().TokenPolicy.Expire,
 	)
 
 	r.Use(gin.Recovery())
+	r.Use(otelgin.Middleware(tracing.ServiceName()))
+	if config.Get().Prometheus.Enable {
+		r.Use(ginmiddleware.Metrics())
+	}
 	r.Use(ginmiddleware.CorsHandler())
 	r.Use(ginmiddleware.Sentinel())
 
-	if config.Config.Env.Profiles != "prod" {
-		if config.Config.Env.Profiles == "test" {
+	if config.Get().Env.Profiles != "prod" {
+		if config.Get().Env.Profiles == "test" {
 			docs.SwaggerInfoadmin.Host = "https://test-admin-api.xxx.com"
 		}
 		//配置swagger
@@ -98,6 +109,8 @@ func NewAdminGinRouter(db *gorm.DB, rdb redis.UniversalClient) *gin.Engine {
 	base.Use(ginmiddleware.Logger())
 	base.Use(ginmiddleware.RequestId(constant.RequestId))
 	base.Use(ginmiddleware.WithContextDb(db))
+	//记录操作日志
+	base.Use(ginmiddleware.OperationLog())
 	//解析token，排除匿名接口
 	base.Use(ginmiddleware.ParseToken(authService, rdb, AnonUrls))
 	//验证权限，排除个人接口
@@ -122,6 +135,7 @@ func NewAdminGinRouter(db *gorm.DB, rdb redis.UniversalClient) *gin.Engine {
 	{
 		userGroup.POST("/login_by_password", userApi.LoginByPassword)
 		userGroup.POST("/login_by_phone", userApi.LoginByPhone)
+		userGroup.POST("/login_by_mfa", userApi.LoginByMfa)
 		userGroup.POST("/logout", userApi.Logout)
 
 		userGroup.POST("/page_query", userApi.PageQuery)
@@ -167,14 +181,11 @@ func NewAdminGinRouter(db *gorm.DB, rdb redis.UniversalClient) *gin.Engine {
 		permissionGroup.POST("/get_tree", permissionApi.GetTree)
 	}
 
-	roleApi := admin.SysRole{}
+	roleApi := admin.NewSysRole()
 	roleGroup := base.Group("/sys/role")
 	{
-		roleGroup.POST("/add", roleApi.Add)
-		roleGroup.POST("/update", roleApi.Update)
-		roleGroup.POST("/delete", roleApi.Delete)
+		api.RegisterCrud(roleGroup, roleApi)
 		roleGroup.POST("/get_by_name", roleApi.GetByName)
-		roleGroup.POST("/page_query", roleApi.PageQuery)
 		roleGroup.POST("/bind_permissions", roleApi.BindPermissions)
 		roleGroup.POST("/get_permission_tree", roleApi.GetPermissionTree)
 		roleGroup.POST("/bind_users", roleApi.BindUsers)
@@ -189,6 +200,12 @@ func NewAdminGinRouter(db *gorm.DB, rdb redis.UniversalClient) *gin.Engine {
 		configGroup.POST("/delete", configApi.Delete)
 		configGroup.POST("/page_query", configApi.PageQuery)
 		configGroup.POST("/page_query_log", configApi.PageQueryLog)
+		configGroup.POST("/get_definitions", configApi.GetDefinitions)
+		configGroup.POST("/get_versions", configApi.GetVersions)
+		configGroup.POST("/diff", configApi.Diff)
+		configGroup.POST("/rollback", configApi.Rollback)
+		configGroup.POST("/export", configApi.Export)
+		configGroup.POST("/import", configApi.Import)
 	}
 
 	dictApi := admin.SysDict{}
@@ -201,8 +218,13 @@ func NewAdminGinRouter(db *gorm.DB, rdb redis.UniversalClient) *gin.Engine {
 		dictGroup.POST("/item/add", dictApi.AddItem)
 		dictGroup.POST("/item/update", dictApi.UpdateItem)
 		dictGroup.POST("/item/page_query", dictApi.PageQueryItem)
+		dictGroup.POST("/item/get_tree", dictApi.GetItemTree)
+		dictGroup.POST("/item/get_path", dictApi.GetItemPath)
 		dictGroup.POST("/item/get_by_name", dictApi.GetItemsByName)
 		dictGroup.POST("/item/batch_get_by_names", dictApi.BatchGetItemsByNames)
+		dictGroup.POST("/export", dictApi.Export)
+		dictGroup.POST("/import", dictApi.Import)
+		dictGroup.POST("/client_constants", dictApi.ClientConstants)
 	}
 
 	smsApi := admin.SysSms{VerifyCodeCache: verifyCodeCache}
@@ -211,6 +233,35 @@ func NewAdminGinRouter(db *gorm.DB, rdb redis.UniversalClient) *gin.Engine {
 		smsGroup.POST("/send", smsApi.Send)
 		smsGroup.POST("/send_to_myself", smsApi.SendToMyself)
 		smsGroup.POST("/page_query", smsApi.PageQuery)
+		smsGroup.POST("/cursor_query", smsApi.CursorQuery)
+	}
+
+	operationLogApi := admin.SysOperationLog{}
+	operationLogGroup := base.Group("/sys/operation_log")
+	{
+		operationLogGroup.POST("/page_query", operationLogApi.PageQuery)
+		operationLogGroup.POST("/cursor_query", operationLogApi.CursorQuery)
+	}
+
+	loginLogApi := admin.SysLoginLog{}
+	loginLogGroup := base.Group("/sys/login_log")
+	{
+		loginLogGroup.POST("/page_query", loginLogApi.PageQuery)
+		loginLogGroup.POST("/cursor_query", loginLogApi.CursorQuery)
+		loginLogGroup.POST("/page_query_personal", loginLogApi.PageQueryPersonal)
+	}
+
+	entityHistoryApi := admin.SysEntityHistory{}
+	entityHistoryGroup := base.Group("/sys/entity_history")
+	{
+		entityHistoryGroup.POST("/get_timeline", entityHistoryApi.GetTimeline)
+	}
+
+	logApi := admin.SysLog{}
+	logGroup := base.Group("/sys/log")
+	{
+		logGroup.POST("/set_level", logApi.SetLevel)
+		logGroup.POST("/get_levels", logApi.GetLevels)
 	}
 
 	openWxApi := admin.WxOpenPlatformServer{}
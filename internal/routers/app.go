@@ -6,6 +6,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"gorm.io/gorm"
 	"net/http"
 	docs "orderin-server/docs/app-api"
@@ -14,6 +15,7 @@ import (
 	"orderin-server/pkg/common/constant"
 	"orderin-server/pkg/common/ginmiddleware"
 	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/tracing"
 )
 
 // @title APP API
@@ -27,20 +29,26 @@ import (
 // @host 127.0.0.1:10002
 // @BasePath /api/v1
 func NewH5GinRouter(db *gorm.DB, rdb redis.UniversalClient) *gin.Engine {
-	if config.Config.Env.Profiles == "prod" {
+	if config.Get().Env.Profiles == "prod" {
 		gin.SetMode(gin.ReleaseMode)
 	} else {
 		gin.SetMode(gin.DebugMode)
 	}
 	r := gin.New()
-	log.ZInfo(context.Background(), "load config", "config", config.Config)
+	//gin.Context.Value 回退到 Request.Context，使 span 能通过 gin.Context 传递
+	r.ContextWithFallback = true
+	log.ZInfo(context.Background(), "load config", "config", log.Redact(config.Get()))
 
 	r.Use(gin.Recovery())
+	r.Use(otelgin.Middleware(tracing.ServiceName()))
+	if config.Get().Prometheus.Enable {
+		r.Use(ginmiddleware.Metrics())
+	}
 	r.Use(ginmiddleware.CorsHandler())
 	r.Use(ginmiddleware.Sentinel())
 
-	if config.Config.Env.Profiles != "prod" {
-		if config.Config.Env.Profiles == "test" {
+	if config.Get().Env.Profiles != "prod" {
+		if config.Get().Env.Profiles == "test" {
 			docs.SwaggerInfoapp.Host = "https://test-app-api.xiaoxixijz.com"
 		}
 		//配置swagger
@@ -65,5 +73,20 @@ func NewH5GinRouter(db *gorm.DB, rdb redis.UniversalClient) *gin.Engine {
 		uploadGroup.POST("/upload_base64_image", uploadApi.UploadBase64Image)
 	}
 
+	dictApi := app.Dict{}
+	dictGroup := base.Group("/dict")
+	{
+		dictGroup.POST("/item/get_by_name", dictApi.GetItemsByName)
+		dictGroup.POST("/item/batch_get_by_names", dictApi.BatchGetItemsByNames)
+		dictGroup.POST("/item/get_tree", dictApi.GetItemTree)
+		dictGroup.POST("/item/get_path", dictApi.GetItemPath)
+	}
+
+	featureFlagApi := app.FeatureFlag{}
+	featureGroup := base.Group("/feature")
+	{
+		featureGroup.POST("/get_flags", featureFlagApi.GetFlags)
+	}
+
 	return r
 }
@@ -0,0 +1,8 @@
+package seeds
+
+import _ "embed"
+
+// Dicts 字典种子文件，格式同字典导出的yaml
+//
+//go:embed dicts.yaml
+var Dicts []byte
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"orderin-server/pkg/common/clientconst"
+)
+
+// ClientConstants 由字典（包括已禁用的字典项）及错误码生成前端常量，names 为空时包括所有字典
+func (e SysDict) ClientConstants(names []string) (*clientconst.Constants, error) {
+	bundle, err := e.ExportDicts(names)
+	if err != nil {
+		return nil, err
+	}
+	constants := &clientconst.Constants{
+		Enums:      make([]clientconst.Enum, 0, len(bundle.Dicts)),
+		ErrorCodes: clientconst.ErrorCodes(),
+	}
+	for _, dict := range bundle.Dicts {
+		items := make([]clientconst.EnumItem, 0, len(dict.Items))
+		for _, item := range dict.Items {
+			items = append(items, clientconst.EnumItem{Value: item.Value, Label: item.Label, Translations: item.Translations})
+		}
+		constants.Enums = append(constants.Enums, clientconst.NewEnum(dict.Name, dict.Label, items))
+	}
+	constants.Sort()
+	return constants, nil
+}
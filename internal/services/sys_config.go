@@ -6,19 +6,41 @@ import (
 	"orderin-server/internal/dto"
 	"orderin-server/internal/models"
 	mcontext "orderin-server/pkg/common/context"
+	"orderin-server/pkg/common/customtypes"
 	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/dynconfig"
 	"orderin-server/pkg/common/errs"
 	"orderin-server/pkg/common/log"
 	"orderin-server/pkg/common/service"
+	"time"
 )
 
 type SysConfig struct {
 	service.Service
 }
 
+// checkValue 已注册的配置按定义校验，并以定义中的类型为准；未注册的只校验类型
+func (e SysConfig) checkValue(config *models.SysConfig) error {
+	if def, ok := dynconfig.Lookup(config.Name); ok {
+		if err := def.Check(config.Value); err != nil {
+			return errs.NewCodeError(errs.ArgsError, "配置值不合法："+err.Error())
+		}
+		config.ValueType = def.ValueType
+		return nil
+	}
+	log.ZWarn(e.Context, "config name is not defined in code", nil, "name", config.Name)
+	if err := dynconfig.CheckType(config.ValueType, config.Value); err != nil {
+		return errs.NewCodeError(errs.ArgsError, "配置值不合法："+err.Error())
+	}
+	return nil
+}
+
 func (e SysConfig) Insert(config *models.SysConfig) error {
 	var err error
 	var i int64
+	if err = e.checkValue(config); err != nil {
+		return err
+	}
 	err = e.Orm.Model(&config).Where("name = ?", config.Name).Count(&i).Error
 	if err != nil {
 		log.ZError(e.Context, "db error", err)
@@ -33,9 +55,8 @@ func (e SysConfig) Insert(config *models.SysConfig) error {
 	return err
 }
 
-func (e SysConfig) UpdateSelectiveById(id int64, config models.SysConfig) error {
+func (e SysConfig) UpdateSelectiveById(id int64, config models.SysConfig) (err error) {
 	var dbRecord models.SysConfig
-	var err error
 
 	result := e.Orm.First(&dbRecord, id)
 	err = result.Error
@@ -48,38 +69,39 @@ func (e SysConfig) UpdateSelectiveById(id int64, config models.SysConfig) error
 	if result.RowsAffected == 0 {
 		return errs.NewCodeError(errs.RecordNotFoundError, "配置不存在")
 	}
+	config.Name = dbRecord.Name
+	config.ValueType = dbRecord.ValueType
+	if err = e.checkValue(&config); err != nil {
+		return err
+	}
 
 	// 开始事务
 	tx := e.Orm.Begin()
-	// 使用 defer 设置事务的回滚或提交
-	defer func() {
-		if r := recover(); r != nil {
-			// 发生了 panic，回滚事务
-			tx.Rollback()
-			log.ZError(e.Context, "Transaction rolled back", nil, "err", r)
-		} else if tx.Error != nil {
-			// 发生了错误，回滚事务
-			tx.Rollback()
-			log.ZError(e.Context, "Transaction rolled back", tx.Error)
-
-		} else {
-			// 没有发生错误，提交事务
-			tx.Commit()
-			log.ZInfo(e.Context, "Transaction committed")
-		}
-	}()
+	defer e.EndTx(tx, &err)
 
-	config.ID = id
-	err = tx.Model(&config).Updates(&config).Error
+	err = e.saveValue(tx, dbRecord, config, nil)
+	return err
+}
+
+// saveValue 更新配置，值有变化时记录日志，rollbackLogId 不为空表示由回滚产生
+func (e SysConfig) saveValue(tx *gorm.DB, dbRecord models.SysConfig, config models.SysConfig, rollbackLogId *int64) error {
+	config.ID = dbRecord.ID
+	// 值可以改为空字符串，需显式指定更新的字段；描述为空时不修改
+	columns := []interface{}{"value_type", "updated_at", "updated_by"}
+	if config.Description != "" {
+		columns = append(columns, "description")
+	}
+	err := tx.Model(&config).Select("value", columns...).Updates(&config).Error
 	if err != nil {
 		return err
 	}
 
 	if dbRecord.Value != config.Value {
 		log := models.SysConfigLog{
-			ConfigID: id,
-			OldValue: dbRecord.Value,
-			NewValue: config.Value,
+			ConfigID:      dbRecord.ID,
+			OldValue:      dbRecord.Value,
+			NewValue:      config.Value,
+			RollbackLogID: rollbackLogId,
 		}
 		currentUserId := mcontext.GetOpUserID(e.Context)
 		log.CreatedBy = &currentUserId
@@ -91,22 +113,25 @@ func (e SysConfig) UpdateSelectiveById(id int64, config models.SysConfig) error
 	return nil
 }
 
-func (e SysConfig) Delete(id int64) {
-	e.Orm.Delete(models.SysConfig{}, id)
+func (e SysConfig) crud() service.CrudService[models.SysConfig, dto.SysConfigPageQueryReq] {
+	return service.CrudService[models.SysConfig, dto.SysConfigPageQueryReq]{Service: e.Service}
 }
 
-func (e SysConfig) PageQuery(req *dto.SysConfigPageQueryReq, list *[]models.SysConfig, count *int64) error {
-	err := e.Orm.
-		Scopes(
-			relation.MakeCondition(*req),
-			relation.Paginate(req.GetPageSize(), req.GetPageIndex()),
-		).
-		Find(list).Limit(-1).Offset(-1).
-		Count(count).Error
+func (e SysConfig) Delete(id int64) error {
+	err := e.crud().Delete(id)
 	if err != nil {
-		log.ZError(e.Context, "page query sys_configs fail", err)
+		log.ZError(e.Context, "delete sys_config fail", err)
+	}
+	return err
+}
+
+func (e SysConfig) PageQuery(req *dto.SysConfigPageQueryReq, list *[]models.SysConfig, count *int64) error {
+	if err := e.crud().PageQuery(req, list, count); err != nil {
 		return err
 	}
+	for i := range *list {
+		_, (*list)[i].Defined = dynconfig.Lookup((*list)[i].Name)
+	}
 	return nil
 }
 
@@ -130,3 +155,207 @@ func (e SysConfig) GetAll() (*[]models.SysConfig, error) {
 	err := e.Orm.Find(&configs).Error
 	return &configs, err
 }
+
+// GetDefinitions 返回代码中注册的所有配置定义及数据库中的当前值
+func (e SysConfig) GetDefinitions() ([]dto.SysConfigDefinitionResp, error) {
+	configs, err := e.GetAll()
+	if err != nil {
+		log.ZError(e.Context, "query sys_configs fail", err)
+		return nil, err
+	}
+	values := make(map[string]string, len(*configs))
+	for _, config := range *configs {
+		values[config.Name] = config.Value
+	}
+	definitions := dynconfig.Definitions()
+	result := make([]dto.SysConfigDefinitionResp, 0, len(definitions))
+	for _, def := range definitions {
+		value, ok := values[def.Name]
+		result = append(result, dto.SysConfigDefinitionResp{Definition: def, Value: value, Configured: ok})
+	}
+	return result, nil
+}
+
+func (e SysConfig) getById(id int64) (models.SysConfig, error) {
+	var config models.SysConfig
+	err := e.Orm.First(&config, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return config, errs.NewCodeError(errs.RecordNotFoundError, "配置不存在")
+	}
+	return config, err
+}
+
+// revision 返回日志修改后的值，logId 为0时返回当前值
+func (e SysConfig) revision(config models.SysConfig, logId int64) (dto.SysConfigRevision, error) {
+	if logId == 0 {
+		return dto.SysConfigRevision{Value: config.Value, ChangedAt: &config.UpdatedAt}, nil
+	}
+	var configLog models.SysConfigLog
+	err := e.Orm.Where("id = ? AND config_id = ?", logId, config.ID).First(&configLog).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.SysConfigRevision{}, errs.NewCodeError(errs.RecordNotFoundError, "配置日志不存在")
+		}
+		return dto.SysConfigRevision{}, err
+	}
+	return dto.SysConfigRevision{LogID: configLog.ID, Value: configLog.NewValue, ChangedAt: &configLog.CreatedAt}, nil
+}
+
+// Diff 比较配置的两个版本
+func (e SysConfig) Diff(req *dto.SysConfigDiffReq) (*dto.SysConfigDiffResp, error) {
+	config, err := e.getById(req.ConfigID)
+	if err != nil {
+		return nil, err
+	}
+	from, err := e.revision(config, req.FromLogID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := e.revision(config, req.ToLogID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SysConfigDiffResp{
+		ConfigID:  config.ID,
+		Name:      config.Name,
+		ValueType: config.ValueType,
+		From:      from,
+		To:        to,
+		Changes:   dynconfig.Diff(config.ValueType, from.Value, to.Value),
+	}, nil
+}
+
+// Rollback 把配置回滚到某条日志修改前或修改后的值，并记录一条新的日志
+func (e SysConfig) Rollback(req *dto.SysConfigRollbackReq) (err error) {
+	var configLog models.SysConfigLog
+	err = e.Orm.First(&configLog, req.LogID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.NewCodeError(errs.RecordNotFoundError, "配置日志不存在")
+		}
+		return err
+	}
+	dbRecord, err := e.getById(configLog.ConfigID)
+	if err != nil {
+		return err
+	}
+	config := models.SysConfig{Name: dbRecord.Name, ValueType: dbRecord.ValueType, Value: configLog.NewValue}
+	if req.Before {
+		config.Value = configLog.OldValue
+	}
+	if config.Value == dbRecord.Value {
+		return errs.NewCodeError(errs.ArgsError, "配置当前值与回滚目标一致，无需回滚")
+	}
+	if err = e.checkValue(&config); err != nil {
+		return err
+	}
+	currentUserId := mcontext.GetOpUserID(e.Context)
+	config.UpdatedBy = &currentUserId
+
+	tx := e.Orm.Begin()
+	defer e.EndTx(tx, &err)
+
+	err = e.saveValue(tx, dbRecord, config, &configLog.ID)
+	return err
+}
+
+// Export 导出配置快照，names 为空时导出全部
+func (e SysConfig) Export(names []string) (*dto.SysConfigSnapshot, error) {
+	var configs []models.SysConfig
+	db := e.Orm.Order("name")
+	if len(names) > 0 {
+		db = db.Where("name IN ?", names)
+	}
+	if err := db.Find(&configs).Error; err != nil {
+		log.ZError(e.Context, "query sys_configs fail", err)
+		return nil, err
+	}
+	snapshot := &dto.SysConfigSnapshot{
+		ExportedAt: customtypes.Time(time.Now()),
+		Configs:    make([]dto.SysConfigSnapshotItem, 0, len(configs)),
+	}
+	for _, config := range configs {
+		snapshot.Configs = append(snapshot.Configs, dto.SysConfigSnapshotItem{
+			Name:        config.Name,
+			Value:       config.Value,
+			ValueType:   config.ValueType,
+			Description: config.Description,
+		})
+	}
+	return snapshot, nil
+}
+
+// Import 导入配置快照：不存在的新增，值或描述不同的更新，快照中没有的配置保持不变。
+// 先校验全部配置，任一不合法则整体不导入；dryRun 时只返回将要发生的变更
+func (e SysConfig) Import(req *dto.SysConfigImportReq) (resp *dto.SysConfigImportResp, err error) {
+	existing := map[string]models.SysConfig{}
+	configs, err := e.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range *configs {
+		existing[config.Name] = config
+	}
+
+	resp = &dto.SysConfigImportResp{DryRun: req.DryRun, Items: make([]dto.SysConfigImportItem, 0, len(req.Configs))}
+	toSave := make([]models.SysConfig, 0, len(req.Configs))
+	seen := map[string]bool{}
+	for _, item := range req.Configs {
+		if seen[item.Name] {
+			return nil, errs.NewCodeError(errs.ArgsError, "配置"+item.Name+"重复")
+		}
+		seen[item.Name] = true
+		config := models.SysConfig{Name: item.Name, Value: item.Value, ValueType: item.ValueType, Description: item.Description}
+		dbRecord, ok := existing[item.Name]
+		if ok && config.ValueType == "" {
+			config.ValueType = dbRecord.ValueType
+		}
+		if ok && config.Description == "" {
+			config.Description = dbRecord.Description
+		}
+		if err = e.checkValue(&config); err != nil {
+			if codeErr, ok := err.(errs.CodeError); ok {
+				return nil, errs.NewCodeError(errs.ArgsError, "配置"+item.Name+"："+codeErr.Msg())
+			}
+			return nil, err
+		}
+		importItem := dto.SysConfigImportItem{Name: item.Name, Changes: make([]dynconfig.Change, 0)}
+		if ok {
+			importItem.Changes = dynconfig.Diff(config.ValueType, dbRecord.Value, config.Value)
+		}
+		switch {
+		case !ok:
+			importItem.Action = "create"
+			resp.Created++
+		case dbRecord.Value == config.Value && dbRecord.Description == config.Description && dbRecord.ValueType == config.ValueType:
+			importItem.Action = "unchanged"
+			resp.Unchanged++
+		default:
+			importItem.Action = "update"
+			resp.Updated++
+		}
+		resp.Items = append(resp.Items, importItem)
+		toSave = append(toSave, config)
+	}
+	if req.DryRun || resp.Created+resp.Updated == 0 {
+		return resp, nil
+	}
+
+	currentUserId := mcontext.GetOpUserID(e.Context)
+	tx := e.Orm.Begin()
+	defer e.EndTx(tx, &err)
+	for i, config := range toSave {
+		switch resp.Items[i].Action {
+		case "create":
+			config.CreatedBy = &currentUserId
+			err = tx.Create(&config).Error
+		case "update":
+			config.UpdatedBy = &currentUserId
+			err = e.saveValue(tx, existing[config.Name], config, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
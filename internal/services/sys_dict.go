@@ -11,7 +11,6 @@ import (
 	"orderin-server/pkg/common/log"
 	"orderin-server/pkg/common/service"
 	"sort"
-	"time"
 )
 
 type SysDict struct {
@@ -76,28 +75,35 @@ func (e SysDict) UpdateSelectiveById(id int64, dict models.SysDict) error {
 	return e.Orm.Model(&dict).Updates(&dict).Error
 }
 
-func (e SysDict) Delete(id int64) {
-	e.Orm.Model(&models.SysDict{}).Where("id = ?", id).Updates(map[string]interface{}{
-		"deleted":    true,
-		"deleted_by": mcontext.GetOpUserID(e.Context),
-		"deleted_at": time.Now(),
-	})
+// SetPublic 修改是否公开，false 无法通过 UpdateSelectiveById 更新
+func (e SysDict) SetPublic(id int64, public bool) error {
+	return e.Orm.Model(&models.SysDict{}).Where("id = ?", id).Update("public", public).Error
 }
 
-func (e SysDict) PageQuery(req *dto.SysDictPageQueryReq, list *[]models.SysDict, count *int64) error {
-	err := e.Orm.
-		Scopes(
-			relation.MakeCondition(*req),
-			relation.NotDeleted(),
-			relation.Paginate(req.GetPageSize(), req.GetPageIndex()),
-		).
-		Find(list).Limit(-1).Offset(-1).
-		Count(count).Error
+func (e SysDict) GetById(id int64) (*models.SysDict, error) {
+	var dict models.SysDict
+	err := e.Orm.First(&dict, id).Error
 	if err != nil {
-		log.ZError(e.Context, "page query sys_dicts fail", err)
-		return err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NewCodeError(errs.RecordNotFoundError, "字典不存在")
+		}
+		return nil, err
 	}
-	return nil
+	return &dict, nil
+}
+
+func (e SysDict) crud() service.CrudService[models.SysDict, dto.SysDictPageQueryReq] {
+	return service.CrudService[models.SysDict, dto.SysDictPageQueryReq]{Service: e.Service}
+}
+
+// Delete 逻辑删除字典
+func (e SysDict) Delete(id int64) error {
+	return e.crud().Delete(id)
+}
+
+// PageQuery 分页查询未删除的字典
+func (e SysDict) PageQuery(req *dto.SysDictPageQueryReq, list *[]models.SysDict, count *int64) error {
+	return e.crud().PageQuery(req, list, count)
 }
 
 func (e SysDict) PageQueryItem(req *dto.SysDictItemPageQueryReq, list *[]models.SysDictItem, count *int64) error {
@@ -128,12 +134,54 @@ func (e SysDict) AddItem(dictItem models.SysDictItem) error {
 		err = errs.NewCodeError(errs.DuplicateKeyError, "字典项已存在")
 		return err
 	}
+	if err = e.checkItemParent(dictItem.DictName, dictItem.ItemValue, dictItem.ParentValue); err != nil {
+		return err
+	}
+	if err = validateTranslations(dictItem.Translations); err != nil {
+		return err
+	}
 	dictItem.Status = 1
 	return e.Orm.Create(&dictItem).Error
 }
 
-func (e SysDict) UpdateItem(dictItem models.SysDictItem) error {
-	return e.Orm.Model(&dictItem).Updates(&dictItem).Error
+// UpdateItem 修改字典项，修改值时同步修改下级字典项的上级值
+func (e SysDict) UpdateItem(dictItem models.SysDictItem) (err error) {
+	if err = validateTranslations(dictItem.Translations); err != nil {
+		return err
+	}
+	dbItem, err := e.GetItemById(dictItem.ID)
+	if err != nil {
+		return err
+	}
+	if dictItem.ItemValue != dbItem.ItemValue {
+		var i int64
+		err = e.Orm.Model(&models.SysDictItem{}).Where("dict_name = ? and item_value = ?", dbItem.DictName, dictItem.ItemValue).Count(&i).Error
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			return errs.NewCodeError(errs.DuplicateKeyError, "字典项已存在")
+		}
+	}
+	if err = e.checkItemParent(dbItem.DictName, dbItem.ItemValue, dictItem.ParentValue); err != nil {
+		return err
+	}
+
+	tx := e.Orm.Begin()
+	defer e.EndTx(tx, &err)
+	// 上级值为空表示改为顶级，Updates 会忽略零值，单独更新
+	if err = tx.Model(&dictItem).Updates(&dictItem).Error; err != nil {
+		return err
+	}
+	if err = tx.Model(&models.SysDictItem{}).Where("id = ?", dictItem.ID).Update("parent_value", dictItem.ParentValue).Error; err != nil {
+		return err
+	}
+	if dictItem.ItemValue != dbItem.ItemValue {
+		err = tx.Model(&models.SysDictItem{}).
+			Where("dict_name = ? and parent_value = ?", dbItem.DictName, dbItem.ItemValue).
+			Update("parent_value", dictItem.ItemValue).Error
+	}
+	return err
 }
 
 func (e SysDict) GetItems(name string) ([]models.SysDictItem, error) {
@@ -175,18 +223,6 @@ func (e SysDict) BatchGetItems(names []string) (*map[string][]models.SysDictItem
 	return &mapping, err
 }
 
-func (e SysDict) BatchAddDictAndItems(name string, label string, labels []string, values []string) {
-	e.Insert(models.SysDict{Name: name, Label: label})
-	for i, label := range labels {
-		e.AddItem(models.SysDictItem{
-			DictName:  name,
-			ItemLabel: label,
-			ItemValue: values[i],
-			Sort:      i + 1,
-		})
-	}
-}
-
 func (e SysDict) GetItemById(id int64) (*models.SysDictItem, error) {
 	var item models.SysDictItem
 	result := e.Orm.First(&item, id)
@@ -0,0 +1,499 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"gopkg.in/yaml.v3"
+	"orderin-server/internal/dto"
+	"orderin-server/internal/models"
+	mcontext "orderin-server/pkg/common/context"
+	"orderin-server/pkg/common/errs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 字典导入导出格式
+const (
+	DictFormatJSON = "json"
+	DictFormatYAML = "yaml"
+	DictFormatXLSX = "xlsx"
+
+	dictSheet     = "dicts"
+	dictItemSheet = "items"
+	// 字典项译文的列名为 label.<语言>，如 label.en-US
+	translationColumnPrefix = "label."
+)
+
+var (
+	dictSheetHeader     = []string{"name", "label", "description", "public"}
+	dictItemSheetHeader = []string{"dictName", "label", "value", "parentValue", "description", "status", "isDefault", "sort"}
+)
+
+// EncodeDictBundle 按格式序列化字典，xlsx 分为 dicts、items 两个工作表
+func EncodeDictBundle(bundle *dto.SysDictBundle, format string) ([]byte, error) {
+	switch format {
+	case DictFormatJSON:
+		return json.MarshalIndent(bundle, "", "  ")
+	case DictFormatYAML:
+		return yaml.Marshal(bundle)
+	case DictFormatXLSX:
+		f := excelize.NewFile()
+		defer f.Close()
+		if err := f.SetSheetName("Sheet1", dictSheet); err != nil {
+			return nil, err
+		}
+		if _, err := f.NewSheet(dictItemSheet); err != nil {
+			return nil, err
+		}
+		locales := bundleLocales(bundle)
+		itemHeader := append([]string{}, dictItemSheetHeader...)
+		for _, locale := range locales {
+			itemHeader = append(itemHeader, translationColumnPrefix+locale)
+		}
+		dictRows := [][]interface{}{toRow(dictSheetHeader)}
+		itemRows := [][]interface{}{toRow(itemHeader)}
+		for _, dict := range bundle.Dicts {
+			dictRows = append(dictRows, []interface{}{dict.Name, dict.Label, dict.Description, dict.Public})
+			for _, item := range dict.Items {
+				row := []interface{}{dict.Name, item.Label, item.Value, item.Parent, item.Description, item.Status, item.IsDefault, item.Sort}
+				for _, locale := range locales {
+					row = append(row, item.Translations[locale])
+				}
+				itemRows = append(itemRows, row)
+			}
+		}
+		for sheet, rows := range map[string][][]interface{}{dictSheet: dictRows, dictItemSheet: itemRows} {
+			for i := range rows {
+				cell, _ := excelize.CoordinatesToCellName(1, i+1)
+				if err := f.SetSheetRow(sheet, cell, &rows[i]); err != nil {
+					return nil, err
+				}
+			}
+		}
+		buf, err := f.WriteToBuffer()
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, errs.NewCodeError(errs.ArgsError, "不支持的格式："+format)
+	}
+}
+
+// DecodeDictBundle 按格式解析字典
+func DecodeDictBundle(data []byte, format string) (*dto.SysDictBundle, error) {
+	var bundle dto.SysDictBundle
+	var err error
+	switch format {
+	case DictFormatJSON:
+		err = json.Unmarshal(data, &bundle)
+	case DictFormatYAML:
+		err = yaml.Unmarshal(data, &bundle)
+	case DictFormatXLSX:
+		err = decodeDictXlsx(data, &bundle)
+	default:
+		return nil, errs.NewCodeError(errs.ArgsError, "不支持的格式："+format)
+	}
+	if err != nil {
+		return nil, errs.NewCodeError(errs.ArgsError, "文件格式错误："+err.Error())
+	}
+	return &bundle, nil
+}
+
+// bundleLocales 字典项译文中出现的所有语言
+func bundleLocales(bundle *dto.SysDictBundle) []string {
+	set := map[string]bool{}
+	for _, dict := range bundle.Dicts {
+		for _, item := range dict.Items {
+			for locale := range item.Translations {
+				set[locale] = true
+			}
+		}
+	}
+	locales := make([]string, 0, len(set))
+	for locale := range set {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+func decodeDictXlsx(data []byte, bundle *dto.SysDictBundle) error {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dictRows, err := sheetRows(f, dictSheet, dictSheetHeader)
+	if err != nil {
+		return err
+	}
+	index := map[string]int{}
+	for _, row := range dictRows {
+		index[row["name"]] = len(bundle.Dicts)
+		bundle.Dicts = append(bundle.Dicts, dto.SysDictBundleDict{
+			Name:        row["name"],
+			Label:       row["label"],
+			Description: row["description"],
+			Public:      parseXlsxBool(row["public"]),
+		})
+	}
+	itemRows, err := sheetRows(f, dictItemSheet, dictItemSheetHeader)
+	if err != nil {
+		return err
+	}
+	for i, row := range itemRows {
+		dictIndex, ok := index[row["dictName"]]
+		if !ok {
+			return fmt.Errorf("items row %d: dict %q is not in sheet %s", i+2, row["dictName"], dictSheet)
+		}
+		item := dto.SysDictBundleItem{
+			Label:       row["label"],
+			Value:       row["value"],
+			Parent:      row["parentValue"],
+			Description: row["description"],
+			IsDefault:   parseXlsxBool(row["isDefault"]),
+		}
+		for column, value := range row {
+			if strings.HasPrefix(column, translationColumnPrefix) && value != "" {
+				if item.Translations == nil {
+					item.Translations = map[string]string{}
+				}
+				item.Translations[strings.TrimPrefix(column, translationColumnPrefix)] = value
+			}
+		}
+		for column, target := range map[string]*int{"status": &item.Status, "sort": &item.Sort} {
+			if row[column] == "" {
+				continue
+			}
+			if *target, err = strconv.Atoi(row[column]); err != nil {
+				return fmt.Errorf("items row %d: invalid %s %q", i+2, column, row[column])
+			}
+		}
+		bundle.Dicts[dictIndex].Items = append(bundle.Dicts[dictIndex].Items, item)
+	}
+	return nil
+}
+
+// sheetRows 按表头读取工作表，返回每行列名到值的映射，跳过空行。以 translationColumnPrefix 开头的列原样保留
+func sheetRows(f *excelize.File, sheet string, header []string) ([]map[string]string, error) {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	columns := map[int]string{}
+	for i, name := range rows[0] {
+		name = strings.TrimSpace(name)
+		if strings.HasPrefix(name, translationColumnPrefix) {
+			columns[i] = name
+			continue
+		}
+		for _, h := range header {
+			if strings.EqualFold(name, h) {
+				columns[i] = h
+			}
+		}
+	}
+	var result []map[string]string
+	for _, row := range rows[1:] {
+		values := map[string]string{}
+		var empty = true
+		for i, value := range row {
+			if column, ok := columns[i]; ok {
+				values[column] = strings.TrimSpace(value)
+				empty = empty && values[column] == ""
+			}
+		}
+		if !empty {
+			result = append(result, values)
+		}
+	}
+	return result, nil
+}
+
+func parseXlsxBool(value string) bool {
+	b, _ := strconv.ParseBool(strings.ToLower(value))
+	return b || value == "1"
+}
+
+func toRow(values []string) []interface{} {
+	row := make([]interface{}, len(values))
+	for i, v := range values {
+		row[i] = v
+	}
+	return row
+}
+
+// ExportDicts 导出未删除的字典及其所有字典项，names 为空时导出全部
+func (e SysDict) ExportDicts(names []string) (*dto.SysDictBundle, error) {
+	var dicts []models.SysDict
+	db := e.Orm.Where("deleted = ?", false).Order("id")
+	if len(names) > 0 {
+		db = db.Where("name IN ?", names)
+	}
+	if err := db.Find(&dicts).Error; err != nil {
+		return nil, err
+	}
+	dictNames := make([]string, 0, len(dicts))
+	for _, dict := range dicts {
+		dictNames = append(dictNames, dict.Name)
+	}
+	var items []models.SysDictItem
+	if len(dictNames) > 0 {
+		if err := e.Orm.Where("dict_name IN ?", dictNames).Order("sort, id").Find(&items).Error; err != nil {
+			return nil, err
+		}
+	}
+	itemsByDict := map[string][]dto.SysDictBundleItem{}
+	for _, item := range items {
+		itemsByDict[item.DictName] = append(itemsByDict[item.DictName], dto.SysDictBundleItem{
+			Label:        item.ItemLabel,
+			Value:        item.ItemValue,
+			Parent:       item.ParentValue,
+			Translations: item.Translations,
+			Description:  item.Description,
+			Status:       item.Status,
+			IsDefault:    item.IsDefault,
+			Sort:         item.Sort,
+		})
+	}
+	bundle := &dto.SysDictBundle{Dicts: make([]dto.SysDictBundleDict, 0, len(dicts))}
+	for _, dict := range dicts {
+		bundle.Dicts = append(bundle.Dicts, dto.SysDictBundleDict{
+			Name:        dict.Name,
+			Label:       dict.Label,
+			Description: dict.Description,
+			Public:      dict.Public,
+			Items:       itemsByDict[dict.Name],
+		})
+	}
+	return bundle, nil
+}
+
+// validateDictBundle 校验必填项、长度及重复
+func validateDictBundle(bundle *dto.SysDictBundle) error {
+	if len(bundle.Dicts) == 0 {
+		return errs.NewCodeError(errs.ArgsError, "没有要导入的字典")
+	}
+	dictNames := map[string]bool{}
+	for i := range bundle.Dicts {
+		dict := &bundle.Dicts[i]
+		if dict.Name == "" || len(dict.Name) >= 50 || dict.Label == "" || len(dict.Description) >= 100 {
+			return errs.NewCodeError(errs.ArgsError, fmt.Sprintf("第%d个字典%s不合法：名称、标签必填，名称少于50个字符，描述少于100个字符", i+1, dict.Name))
+		}
+		if dictNames[dict.Name] {
+			return errs.NewCodeError(errs.ArgsError, "字典重复："+dict.Name)
+		}
+		dictNames[dict.Name] = true
+		values := map[string]bool{}
+		for j := range dict.Items {
+			item := &dict.Items[j]
+			if item.Status == 0 {
+				item.Status = 1
+			}
+			if item.Label == "" || item.Value == "" || len(item.Value) > 200 || len(item.Description) >= 50 || (item.Status != 1 && item.Status != 2) {
+				return errs.NewCodeError(errs.ArgsError, fmt.Sprintf("字典%s的第%d个字典项不合法：标签、值必填，状态为1或2", dict.Name, j+1))
+			}
+			if values[item.Value] {
+				return errs.NewCodeError(errs.ArgsError, fmt.Sprintf("字典%s的字典项重复：%s", dict.Name, item.Value))
+			}
+			values[item.Value] = true
+			if err := validateTranslations(item.Translations); err != nil {
+				return errs.NewCodeError(errs.ArgsError, fmt.Sprintf("字典%s的字典项%s：%s", dict.Name, item.Value, err.(errs.CodeError).Msg()))
+			}
+			if item.Parent == item.Value {
+				return errs.NewCodeError(errs.ArgsError, fmt.Sprintf("字典%s的字典项%s的上级不能是自身", dict.Name, item.Value))
+			}
+		}
+	}
+	return nil
+}
+
+// mergeTranslations 合并译文：covered 表示 incoming 已全部包含在 current 中，compatible 表示两者没有不同的译文
+func mergeTranslations(current, incoming map[string]string) (merged map[string]string, covered bool, compatible bool) {
+	merged = make(map[string]string, len(current)+len(incoming))
+	for locale, label := range current {
+		merged[locale] = label
+	}
+	covered, compatible = true, true
+	for locale, label := range incoming {
+		existing, ok := current[locale]
+		if !ok {
+			covered = false
+			merged[locale] = label
+		} else if existing != label {
+			covered, compatible = false, false
+		}
+	}
+	return merged, covered, compatible
+}
+
+// ImportDicts 导入字典：不存在的新增；已存在且内容不同的，upsert 时覆盖（包括恢复已删除的字典），否则跳过并报告冲突。
+// 任一字典不合法则整体不导入；dryRun 时只返回将要发生的变更
+func (e SysDict) ImportDicts(bundle *dto.SysDictBundle, upsert bool, dryRun bool) (resp *dto.SysDictImportResp, err error) {
+	if err = validateDictBundle(bundle); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(bundle.Dicts))
+	for _, dict := range bundle.Dicts {
+		names = append(names, dict.Name)
+	}
+	var dbDicts []models.SysDict
+	if err = e.Orm.Where("name IN ?", names).Find(&dbDicts).Error; err != nil {
+		return nil, err
+	}
+	existingDicts := map[string]models.SysDict{}
+	for _, dict := range dbDicts {
+		existingDicts[dict.Name] = dict
+	}
+	var dbItems []models.SysDictItem
+	if err = e.Orm.Where("dict_name IN ?", names).Find(&dbItems).Error; err != nil {
+		return nil, err
+	}
+	existingItems := map[string]models.SysDictItem{}
+	for _, item := range dbItems {
+		existingItems[item.DictName+"\x00"+item.ItemValue] = item
+	}
+
+	for _, dict := range bundle.Dicts {
+		values := make(map[string]bool, len(dict.Items))
+		for _, item := range dict.Items {
+			values[item.Value] = true
+		}
+		for _, item := range dict.Items {
+			if _, exists := existingItems[dict.Name+"\x00"+item.Parent]; item.Parent != "" && !values[item.Parent] && !exists {
+				return nil, errs.NewCodeError(errs.ArgsError, fmt.Sprintf("字典%s的字典项%s的上级不存在：%s", dict.Name, item.Value, item.Parent))
+			}
+		}
+	}
+
+	resp = &dto.SysDictImportResp{DryRun: dryRun, Conflicts: make([]dto.SysDictImportConflict, 0), Dicts: make([]string, 0)}
+	var createDicts []models.SysDict
+	var updateDicts []models.SysDict
+	var createItems []models.SysDictItem
+	var updateItems []models.SysDictItem
+	for _, dict := range bundle.Dicts {
+		changed := false
+		dbDict, exists := existingDicts[dict.Name]
+		switch {
+		case !exists:
+			createDicts = append(createDicts, models.SysDict{Name: dict.Name, Label: dict.Label, Description: dict.Description, Public: dict.Public})
+			resp.DictCreated++
+			changed = true
+		case dbDict.Deleted && !upsert:
+			resp.Conflicts = append(resp.Conflicts, dto.SysDictImportConflict{DictName: dict.Name, Reason: "字典已删除"})
+			continue
+		case dbDict.Deleted || dbDict.Label != dict.Label || dbDict.Description != dict.Description || dbDict.Public != dict.Public:
+			if !upsert {
+				resp.Conflicts = append(resp.Conflicts, dto.SysDictImportConflict{DictName: dict.Name, Reason: "字典已存在且标签、描述或是否公开不同"})
+				break
+			}
+			dbDict.Label, dbDict.Description, dbDict.Public = dict.Label, dict.Description, dict.Public
+			updateDicts = append(updateDicts, dbDict)
+			resp.DictUpdated++
+			changed = true
+		default:
+			resp.Unchanged++
+		}
+
+		for _, item := range dict.Items {
+			dbItem, exists := existingItems[dict.Name+"\x00"+item.Value]
+			if !exists {
+				createItems = append(createItems, models.SysDictItem{
+					DictName:     dict.Name,
+					ItemLabel:    item.Label,
+					ItemValue:    item.Value,
+					ParentValue:  item.Parent,
+					Translations: item.Translations,
+					Description:  item.Description,
+					Status:       item.Status,
+					IsDefault:    item.IsDefault,
+					Sort:         item.Sort,
+				})
+				resp.ItemCreated++
+				changed = true
+				continue
+			}
+			fieldsEqual := dbItem.ItemLabel == item.Label && dbItem.ParentValue == item.Parent && dbItem.Description == item.Description &&
+				dbItem.Status == item.Status && dbItem.IsDefault == item.IsDefault && dbItem.Sort == item.Sort
+			merged, covered, compatible := mergeTranslations(dbItem.Translations, item.Translations)
+			switch {
+			case fieldsEqual && covered && (!upsert || len(dbItem.Translations) == len(item.Translations)):
+				resp.Unchanged++
+			case upsert:
+				dbItem.ItemLabel, dbItem.ParentValue, dbItem.Description = item.Label, item.Parent, item.Description
+				dbItem.Status, dbItem.IsDefault, dbItem.Sort = item.Status, item.IsDefault, item.Sort
+				dbItem.Translations = item.Translations
+				updateItems = append(updateItems, dbItem)
+				resp.ItemUpdated++
+				changed = true
+			case fieldsEqual && compatible:
+				// 不覆盖时只补充缺少的译文
+				dbItem.Translations = merged
+				updateItems = append(updateItems, dbItem)
+				resp.ItemUpdated++
+				changed = true
+			default:
+				resp.Conflicts = append(resp.Conflicts, dto.SysDictImportConflict{DictName: dict.Name, ItemValue: item.Value, Reason: "字典项已存在且内容不同"})
+			}
+		}
+		if changed {
+			resp.Dicts = append(resp.Dicts, dict.Name)
+		}
+	}
+	if dryRun || len(resp.Dicts) == 0 {
+		return resp, nil
+	}
+
+	currentUserId := mcontext.GetOpUserID(e.Context)
+	tx := e.Orm.Begin()
+	defer e.EndTx(tx, &err)
+	for _, dict := range createDicts {
+		dict.CreatedBy = &currentUserId
+		if err = tx.Create(&dict).Error; err != nil {
+			return nil, err
+		}
+	}
+	for _, dict := range updateDicts {
+		err = tx.Model(&models.SysDict{}).Where("id = ?", dict.ID).Updates(map[string]interface{}{
+			"label":       dict.Label,
+			"description": dict.Description,
+			"public":      dict.Public,
+			"deleted":     false,
+			"deleted_at":  nil,
+			"deleted_by":  nil,
+			"updated_by":  currentUserId,
+		}).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range createItems {
+		item.CreatedBy = &currentUserId
+		if err = tx.Create(&item).Error; err != nil {
+			return nil, err
+		}
+	}
+	for _, item := range updateItems {
+		err = tx.Model(&models.SysDictItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+			"item_label":   item.ItemLabel,
+			"parent_value": item.ParentValue,
+			"translations": item.Translations,
+			"description":  item.Description,
+			"status":       item.Status,
+			"is_default":   item.IsDefault,
+			"sort":         item.Sort,
+			"updated_by":   currentUserId,
+		}).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
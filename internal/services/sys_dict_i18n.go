@@ -0,0 +1,46 @@
+package services
+
+import (
+	"fmt"
+	"orderin-server/internal/models"
+	"orderin-server/pkg/common/customtypes"
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/i18n"
+	"strings"
+)
+
+// LocalizeDictItems 返回标签替换为 locale 译文的字典项副本，默认语言或没有译文时保持原标签
+func LocalizeDictItems(items []models.SysDictItem, locale string) []models.SysDictItem {
+	if locale == i18n.DefaultLocale {
+		return items
+	}
+	localized := make([]models.SysDictItem, len(items))
+	for i, item := range items {
+		if label, ok := item.Translations[locale]; ok && label != "" {
+			item.ItemLabel = label
+		}
+		localized[i] = item
+	}
+	return localized
+}
+
+// LocalizedETag 不同语言的响应内容不同，非默认语言在 ETag 后加上语言
+func LocalizedETag(etag string, locale string) string {
+	if locale == i18n.DefaultLocale {
+		return etag
+	}
+	return `"` + strings.Trim(etag, `"`) + "-" + locale + `"`
+}
+
+// validateTranslations 校验译文的语言是否支持及标签长度
+func validateTranslations(translations customtypes.StringMap) error {
+	for locale, label := range translations {
+		if !i18n.Supported(locale) {
+			return errs.NewCodeError(errs.ArgsError, "不支持的语言："+locale)
+		}
+		if label == "" || len(label) > 50 {
+			return errs.NewCodeError(errs.ArgsError, fmt.Sprintf("%s的标签不能为空且不能超过50个字符", locale))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"orderin-server/internal/dto"
+	"orderin-server/internal/models"
+	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/log"
+	"sort"
+)
+
+// BuildDictItemTree 以 parentValue 的下级为第一层构建树，depth 为层数，0表示不限。
+// 上级不在 items 中的字典项（如上级已禁用）及环上的字典项不会出现在树中
+func BuildDictItemTree(items []models.SysDictItem, parentValue string, depth int) []*dto.SysDictItemTree {
+	children := make(map[string][]models.SysDictItem)
+	for _, item := range items {
+		if item.ItemValue == item.ParentValue {
+			continue
+		}
+		children[item.ParentValue] = append(children[item.ParentValue], item)
+	}
+	for _, list := range children {
+		sortDictItems(list)
+	}
+	visited := make(map[string]bool)
+	var build func(parentValue string, level int) []*dto.SysDictItemTree
+	build = func(parentValue string, level int) []*dto.SysDictItemTree {
+		nodes := make([]*dto.SysDictItemTree, 0, len(children[parentValue]))
+		for _, item := range children[parentValue] {
+			if visited[item.ItemValue] {
+				continue
+			}
+			visited[item.ItemValue] = true
+			node := &dto.SysDictItemTree{SysDictItem: item}
+			if depth == 0 || level < depth {
+				if _, ok := children[item.ItemValue]; ok {
+					node.Children = build(item.ItemValue, level+1)
+				}
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	visited[parentValue] = true
+	return build(parentValue, 1)
+}
+
+// DictItemPath 返回从顶级到 value 的字典项，value 不存在或上级缺失时返回 false
+func DictItemPath(items []models.SysDictItem, value string) ([]models.SysDictItem, bool) {
+	byValue := make(map[string]models.SysDictItem, len(items))
+	for _, item := range items {
+		byValue[item.ItemValue] = item
+	}
+	var path []models.SysDictItem
+	visited := make(map[string]bool)
+	for value != "" {
+		item, ok := byValue[value]
+		if !ok || visited[value] {
+			return nil, false
+		}
+		visited[value] = true
+		path = append(path, item)
+		value = item.ParentValue
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
+}
+
+func sortDictItems(items []models.SysDictItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Sort != items[j].Sort {
+			return items[i].Sort < items[j].Sort
+		}
+		return items[i].ID < items[j].ID
+	})
+}
+
+// getAllItems 获取字典的所有字典项，包括已禁用的
+func (e SysDict) getAllItems(dictName string) ([]models.SysDictItem, error) {
+	var items []models.SysDictItem
+	err := e.Orm.Where("dict_name = ?", dictName).Find(&items).Error
+	return items, err
+}
+
+// GetItemTree 获取字典项树，包括已禁用的字典项
+func (e SysDict) GetItemTree(req *dto.SysDictItemTreeReq) ([]*dto.SysDictItemTree, error) {
+	items, err := e.getAllItems(req.DictName)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentValue != "" {
+		if _, ok := DictItemPath(items, req.ParentValue); !ok {
+			return nil, errs.NewCodeError(errs.RecordNotFoundError, "字典项不存在")
+		}
+	}
+	return BuildDictItemTree(items, req.ParentValue, req.Depth), nil
+}
+
+// GetItemPath 获取从顶级到该字典项的完整路径
+func (e SysDict) GetItemPath(dictName string, value string) ([]models.SysDictItem, error) {
+	items, err := e.getAllItems(dictName)
+	if err != nil {
+		return nil, err
+	}
+	path, ok := DictItemPath(items, value)
+	if !ok {
+		return nil, errs.NewCodeError(errs.RecordNotFoundError, "字典项不存在")
+	}
+	return path, nil
+}
+
+// PageQueryItemTree 树模式的分页查询：匹配条件的字典项连同其所有上级组成树，按顶级字典项分页
+func (e SysDict) PageQueryItemTree(req *dto.SysDictItemPageQueryReq, list *[]*dto.SysDictItemTree, count *int64) error {
+	var matched []models.SysDictItem
+	err := e.Orm.Scopes(relation.MakeCondition(*req)).Find(&matched).Error
+	if err != nil {
+		log.ZError(e.Context, "page query sys_dict_items fail", err)
+		return err
+	}
+	items, err := e.getAllItems(req.DictName)
+	if err != nil {
+		return err
+	}
+	byValue := make(map[string]models.SysDictItem, len(items))
+	for _, item := range items {
+		byValue[item.ItemValue] = item
+	}
+	selected := make(map[string]bool)
+	for _, item := range matched {
+		for value := item.ItemValue; value != "" && !selected[value]; value = byValue[value].ParentValue {
+			selected[value] = true
+		}
+	}
+	subset := make([]models.SysDictItem, 0, len(selected))
+	for _, item := range items {
+		if selected[item.ItemValue] {
+			subset = append(subset, item)
+		}
+	}
+	roots := BuildDictItemTree(subset, "", req.Depth)
+	*count = int64(len(roots))
+	start := (req.GetPageIndex() - 1) * req.GetPageSize()
+	if start > len(roots) {
+		start = len(roots)
+	}
+	end := start + req.GetPageSize()
+	if end > len(roots) {
+		end = len(roots)
+	}
+	*list = roots[start:end]
+	return nil
+}
+
+// checkItemParent 校验上级字典项存在，且不是自身或自身的下级
+func (e SysDict) checkItemParent(dictName string, value string, parentValue string) error {
+	if parentValue == "" {
+		return nil
+	}
+	items, err := e.getAllItems(dictName)
+	if err != nil {
+		return err
+	}
+	path, ok := DictItemPath(items, parentValue)
+	if !ok {
+		return errs.NewCodeError(errs.ArgsError, "上级字典项不存在")
+	}
+	for _, item := range path {
+		if item.ItemValue == value {
+			return errs.NewCodeError(errs.ArgsError, "上级字典项不能是自身或自身的下级")
+		}
+	}
+	return nil
+}
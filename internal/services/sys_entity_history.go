@@ -0,0 +1,30 @@
+package services
+
+import (
+	"orderin-server/internal/dto"
+	"orderin-server/internal/models"
+	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/service"
+)
+
+type SysEntityHistory struct {
+	service.Service
+}
+
+// Timeline 按时间倒序分页查询实体的变更历史
+func (e SysEntityHistory) Timeline(req *dto.SysEntityHistoryTimelineReq, list *[]models.SysEntityHistory, count *int64) error {
+	err := e.Orm.
+		Scopes(
+			relation.MakeCondition(*req),
+			relation.Paginate(req.GetPageSize(), req.GetPageIndex()),
+		).
+		Order("id desc").
+		Find(list).Limit(-1).Offset(-1).
+		Count(count).Error
+	if err != nil {
+		log.ZError(e.Context, "query sys_entity_histories timeline fail", err)
+		return err
+	}
+	return nil
+}
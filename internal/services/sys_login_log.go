@@ -0,0 +1,85 @@
+package services
+
+import (
+	"orderin-server/internal/dto"
+	"orderin-server/internal/models"
+	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/service"
+)
+
+type SysLoginLog struct {
+	service.Service
+}
+
+func (e SysLoginLog) Insert(loginLog *models.SysLoginLog) error {
+	return e.Orm.Create(loginLog).Error
+}
+
+func (e SysLoginLog) PageQuery(req *dto.SysLoginLogPageQueryReq, list *[]models.SysLoginLog, count *int64) error {
+	err := e.Orm.
+		Scopes(
+			relation.MakeCondition(*req),
+			relation.Paginate(req.GetPageSize(), req.GetPageIndex()),
+		).Find(list).Limit(-1).Offset(-1).
+		Count(count).Error
+	if err != nil {
+		log.ZError(e.Context, "page query sys_login_logs fail", err)
+		return err
+	}
+	return nil
+}
+
+// CursorQuery 游标分页查询，返回下一页的游标及总数（请求统计时）
+func (e SysLoginLog) CursorQuery(req *dto.SysLoginLogCursorQueryReq, list *[]models.SysLoginLog) (string, *int64, error) {
+	cursor, err := relation.NewCursor(*req, req.Cursor, req.GetPageSize())
+	if err != nil {
+		return "", nil, err
+	}
+	total, err := relation.CountRows(e.Orm.Model(&models.SysLoginLog{}).Scopes(relation.MakeCondition(*req)), req.Count)
+	if err != nil {
+		log.ZError(e.Context, "count sys_login_logs fail", err)
+		return "", nil, err
+	}
+	if err = cursor.Find(e.Orm.Scopes(relation.MakeCondition(*req)), list); err != nil {
+		log.ZError(e.Context, "cursor query sys_login_logs fail", err)
+		return "", nil, err
+	}
+	return cursor.Next(), total, nil
+}
+
+func (e SysLoginLog) PageQueryPersonal(userId int64, req *dto.SysLoginLogPersonalPageQueryReq, list *[]models.SysLoginLog, count *int64) error {
+	err := e.Orm.
+		Scopes(
+			relation.MakeCondition(*req),
+			relation.Paginate(req.GetPageSize(), req.GetPageIndex()),
+		).
+		Where("user_id = ?", userId).
+		Find(list).Limit(-1).Offset(-1).
+		Count(count).Error
+	if err != nil {
+		log.ZError(e.Context, "page query personal sys_login_logs fail", err)
+		return err
+	}
+	return nil
+}
+
+// IsNewDevice 判断本次登录的设备或地点是否从未成功登录过，首次登录不算新设备
+func (e SysLoginLog) IsNewDevice(loginLog *models.SysLoginLog) (bool, error) {
+	var total int64
+	err := e.Orm.Model(&models.SysLoginLog{}).
+		Where("user_id = ? and success = ?", loginLog.UserID, true).
+		Count(&total).Error
+	if err != nil || total == 0 {
+		return false, err
+	}
+	var matched int64
+	err = e.Orm.Model(&models.SysLoginLog{}).
+		Where("user_id = ? and success = ? and device = ? and os = ? and browser = ? and location = ?",
+			loginLog.UserID, true, loginLog.Device, loginLog.Os, loginLog.Browser, loginLog.Location).
+		Count(&matched).Error
+	if err != nil {
+		return false, err
+	}
+	return matched == 0, nil
+}
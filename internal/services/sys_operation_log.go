@@ -0,0 +1,90 @@
+package services
+
+import (
+	"orderin-server/internal/dto"
+	"orderin-server/internal/models"
+	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/service"
+	"time"
+)
+
+type SysOperationLog struct {
+	service.Service
+}
+
+func (e SysOperationLog) PageQuery(req *dto.SysOperationLogPageQueryReq, list *[]models.SysOperationLog, count *int64) error {
+	err := e.Orm.
+		Scopes(
+			relation.MakeCondition(*req),
+			relation.Paginate(req.GetPageSize(), req.GetPageIndex()),
+		).Find(list).Limit(-1).Offset(-1).
+		Count(count).Error
+	if err != nil {
+		log.ZError(e.Context, "page query sys_operation_logs fail", err)
+		return err
+	}
+	return nil
+}
+
+// CursorQuery 游标分页查询，返回下一页的游标及总数（请求统计时）
+func (e SysOperationLog) CursorQuery(req *dto.SysOperationLogCursorQueryReq, list *[]models.SysOperationLog) (string, *int64, error) {
+	cursor, err := relation.NewCursor(*req, req.Cursor, req.GetPageSize())
+	if err != nil {
+		return "", nil, err
+	}
+	total, err := relation.CountRows(e.Orm.Model(&models.SysOperationLog{}).Scopes(relation.MakeCondition(*req)), req.Count)
+	if err != nil {
+		log.ZError(e.Context, "count sys_operation_logs fail", err)
+		return "", nil, err
+	}
+	if err = cursor.Find(e.Orm.Scopes(relation.MakeCondition(*req)), list); err != nil {
+		log.ZError(e.Context, "cursor query sys_operation_logs fail", err)
+		return "", nil, err
+	}
+	return cursor.Next(), total, nil
+}
+
+func (e SysOperationLog) BatchInsert(logs []models.SysOperationLog, batchSize int) error {
+	return e.Orm.CreateInBatches(logs, batchSize).Error
+}
+
+// DeleteBefore 分批删除指定时间之前的日志，返回删除的总行数。
+// 先查出一批id再按主键删除，postgres 和 sqlite 不支持 DELETE ... LIMIT
+func (e SysOperationLog) DeleteBefore(before time.Time, batchSize int) (int64, error) {
+	// 在主库上查询，避免从库延迟查到已删除的id
+	db := relation.Primary(e.Orm)
+	var total int64
+	for {
+		var ids []int64
+		err := db.Model(&models.SysOperationLog{}).Where("created_at < ?", before).Limit(batchSize).Pluck("id", &ids).Error
+		if err != nil {
+			return total, err
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+		result := db.Delete(&models.SysOperationLog{}, ids)
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+		if len(ids) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// GetPermissionSummaries 获取权限路径与描述的映射，用于填充操作日志的接口描述
+func (e SysOperationLog) GetPermissionSummaries() (map[string]string, error) {
+	var permissions []models.SysPermission
+	err := e.Orm.Select("name", "description").Where("type = ?", "API").Find(&permissions).Error
+	if err != nil {
+		return nil, err
+	}
+	summaries := make(map[string]string, len(permissions))
+	for _, permission := range permissions {
+		summaries[permission.Name] = permission.Description
+	}
+	return summaries, nil
+}
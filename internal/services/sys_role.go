@@ -6,7 +6,6 @@ import (
 	"orderin-server/internal/dto"
 	"orderin-server/internal/models"
 	mcontext "orderin-server/pkg/common/context"
-	"orderin-server/pkg/common/db/relation"
 	"orderin-server/pkg/common/errs"
 	"orderin-server/pkg/common/log"
 	"orderin-server/pkg/common/service"
@@ -17,45 +16,24 @@ type SysRole struct {
 	service.Service
 }
 
-func (e SysRole) PageQuery(req *dto.SysRolePageQueryReq, list *[]models.SysRole, count *int64) error {
-	err := e.Orm.
-		Scopes(
-			relation.MakeCondition(*req),
-			relation.Paginate(req.GetPageSize(), req.GetPageIndex()),
-		).
-		Find(list).Limit(-1).Offset(-1).
-		Count(count).Error
-	if err != nil {
-		log.ZError(e.Context, "page query sys_roles fail", err)
-		return err
-	}
-	return nil
+// SysRoleHooks 角色增删改查的钩子
+var SysRoleHooks = service.CrudHooks[models.SysRole]{
+	Validate: validateSysRole,
 }
 
-func (e SysRole) Insert(role *models.SysRole) error {
-	var err error
+// validateSysRole 新增时检查角色名是否重复
+func validateSysRole(tx *gorm.DB, role *models.SysRole, create bool) error {
+	if !create {
+		return nil
+	}
 	var i int64
-	err = e.Orm.Model(role).Where("name = ?", role.Name).Count(&i).Error
-	if err != nil {
-		log.ZError(e.Context, "db error", err)
+	if err := tx.Model(&models.SysRole{}).Where("name = ?", role.Name).Count(&i).Error; err != nil {
 		return err
 	}
 	if i > 0 {
-		log.ZError(e.Context, "role has been exist", err)
-		err := errs.NewCodeError(errs.DuplicateKeyError, "角色已存在")
-		return err
+		return errs.NewCodeError(errs.DuplicateKeyError, "角色已存在")
 	}
-	err = e.Orm.Create(&role).Error
-	return err
-}
-
-func (e SysRole) UpdateSelectiveById(id int64, role models.SysRole) error {
-	role.ID = id
-	return e.Orm.Model(&role).Updates(&role).Error
-}
-
-func (e SysRole) Delete(id int64) {
-	e.Orm.Delete(models.SysRole{}, id)
+	return nil
 }
 
 func (e SysRole) GetByName(name string) (*models.SysRole, error) {
@@ -13,17 +13,25 @@ type SysSms struct {
 }
 
 func (e SysSms) PageQuery(req *dto.SysSmsLogPageQueryReq, list *[]models.SysSmsLog, count *int64) error {
-	err := e.Orm.
-		Scopes(
-			relation.MakeCondition(*req),
-			relation.Paginate(req.GetPageSize(), req.GetPageIndex()),
-		).Find(list).Limit(-1).Offset(-1).
-		Count(count).Error
+	return service.CrudService[models.SysSmsLog, dto.SysSmsLogPageQueryReq]{Service: e.Service}.PageQuery(req, list, count)
+}
+
+// CursorQuery 游标分页查询，返回下一页的游标及总数（请求统计时）
+func (e SysSms) CursorQuery(req *dto.SysSmsLogCursorQueryReq, list *[]models.SysSmsLog) (string, *int64, error) {
+	cursor, err := relation.NewCursor(*req, req.Cursor, req.GetPageSize())
 	if err != nil {
-		log.ZError(e.Context, "page query sys_sms_logs fail", err)
-		return err
+		return "", nil, err
+	}
+	total, err := relation.CountRows(e.Orm.Model(&models.SysSmsLog{}).Scopes(relation.MakeCondition(*req)), req.Count)
+	if err != nil {
+		log.ZError(e.Context, "count sys_sms_logs fail", err)
+		return "", nil, err
+	}
+	if err = cursor.Find(e.Orm.Scopes(relation.MakeCondition(*req)), list); err != nil {
+		log.ZError(e.Context, "cursor query sys_sms_logs fail", err)
+		return "", nil, err
 	}
-	return nil
+	return cursor.Next(), total, nil
 }
 
 func (e SysSms) Insert(smsLog models.SysSmsLog) error {
@@ -6,9 +6,7 @@ import (
 	"orderin-server/internal/dto"
 	"orderin-server/internal/models"
 	"orderin-server/pkg/common/config"
-	"orderin-server/pkg/common/db/relation"
 	"orderin-server/pkg/common/errs"
-	"orderin-server/pkg/common/log"
 	"orderin-server/pkg/common/service"
 )
 
@@ -54,7 +52,7 @@ func (e WxAuthorizer) GetById(id int64) (*models.WxAuthorizer, error) {
 
 func (e WxAuthorizer) GetByAppID(appID string) (*models.WxAuthorizer, error) {
 	var authorizer models.WxAuthorizer
-	result := e.Orm.Where("component_appid = ? and authorizer_appid = ?", config.Config.WxOpenPlatform.AppID, appID).First(&authorizer)
+	result := e.Orm.Where("component_appid = ? and authorizer_appid = ?", config.Get().WxOpenPlatform.AppID, appID).First(&authorizer)
 	err := result.Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -76,14 +74,7 @@ func (e WxAuthorizer) GetAll() (*[]models.WxAuthorizer, error) {
 }
 
 func (e WxAuthorizer) PageQuery(req *dto.WxAuthorizerPageQueryReq, list *[]models.WxAuthorizer, count *int64) error {
-	err := e.Orm.Scopes(relation.MakeCondition(*req),
-		relation.Paginate(req.GetPageSize(), req.GetPageIndex()),
-	).Find(list).Limit(-1).Offset(-1).Count(count).Error
-	if err != nil {
-		log.ZError(e.Context, "page query wx_authorizers fail", err)
-		return err
-	}
-	return nil
+	return service.CrudService[models.WxAuthorizer, dto.WxAuthorizerPageQueryReq]{Service: e.Service}.PageQuery(req, list, count)
 }
 
 func (e WxAuthorizer) UpdateById(id int64, authorizer *models.WxAuthorizer) error {
@@ -7,9 +7,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"gorm.io/gorm"
+	"net/http"
 	"orderin-server/pkg/common/errs"
 	"orderin-server/pkg/common/log"
 	"orderin-server/pkg/common/service"
+	"strings"
 )
 
 type Api struct {
@@ -105,6 +107,18 @@ func (e Api) OK(data interface{}) {
 	GinSuccess(e.Context, data)
 }
 
+// NotModified 设置 ETag 响应头，与请求头 If-None-Match 一致时返回 304 及空响应体并返回 true
+func (e Api) NotModified(etag string) bool {
+	e.Context.Header("ETag", etag)
+	for _, match := range strings.Split(e.Context.GetHeader("If-None-Match"), ",") {
+		if match = strings.TrimSpace(match); match == etag || match == "W/"+etag || match == "*" {
+			e.Context.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
 // PageOK 分页数据处理
 func (e Api) PageOK(result interface{}, count int, pageIndex int, pageSize int) {
 	var data PageData
@@ -114,3 +128,13 @@ func (e Api) PageOK(result interface{}, count int, pageIndex int, pageSize int)
 	data.PageSize = pageSize
 	e.OK(data)
 }
+
+// CursorPageOK 游标分页数据处理，total 为空时不返回总数
+func (e Api) CursorPageOK(result interface{}, nextCursor string, total *int64, pageSize int) {
+	e.OK(CursorPageData{
+		PageSize:   pageSize,
+		NextCursor: nextCursor,
+		Total:      total,
+		List:       result,
+	})
+}
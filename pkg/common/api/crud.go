@@ -0,0 +1,143 @@
+package api
+
+import (
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"orderin-server/pkg/common/dto"
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/service"
+	"orderin-server/pkg/common/utils"
+)
+
+// CrudHandlers 增删改查接口，由 RegisterCrud 注册路由
+type CrudHandlers interface {
+	Add(context *gin.Context)
+	Update(context *gin.Context)
+	Delete(context *gin.Context)
+	Get(context *gin.Context)
+	PageQuery(context *gin.Context)
+}
+
+// RegisterCrud 在 group 下注册 add、update、delete、get、page_query 路由
+func RegisterCrud(group *gin.RouterGroup, h CrudHandlers) {
+	group.POST("/add", h.Add)
+	group.POST("/update", h.Update)
+	group.POST("/delete", h.Delete)
+	group.POST("/get", h.Get)
+	group.POST("/page_query", h.PageQuery)
+}
+
+// CrudApi 通用的增删改查接口，M 为模型，Q 为分页查询条件，A、U 为新增和修改的请求，按同名字段复制到模型，U 需要有 int64 的 ID 字段。
+// swag 不解析泛型方法，模块嵌入 CrudApi 后定义带 swagger 注释的同名方法，在其中调用这里的方法
+type CrudApi[M any, Q any, A any, U any] struct {
+	Api
+	Hooks service.CrudHooks[M]
+}
+
+func (e *CrudApi[M, Q, A, U]) makeService(context *gin.Context, req any) (service.CrudService[M, Q], error) {
+	s := service.CrudService[M, Q]{Hooks: e.Hooks}
+	err := e.MakeContext(context).MakeOrm().Bind(req, binding.JSON).MakeService(&s.Service).Errors
+	return s, err
+}
+
+func (e CrudApi[M, Q, A, U]) Add(context *gin.Context) {
+	req := new(A)
+	s, err := e.makeService(context, req)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	m := new(M)
+	utils.CopyStructFields(m, req)
+	if err = s.Insert(m); err != nil {
+		log.ZError(e.Context, "添加失败", err)
+		e.Error(err)
+		return
+	}
+	e.OK(nil)
+}
+
+func (e CrudApi[M, Q, A, U]) Update(context *gin.Context) {
+	req := new(U)
+	s, err := e.makeService(context, req)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	id := reflect.ValueOf(req).Elem().FieldByName("ID")
+	if !id.IsValid() || id.Kind() != reflect.Int64 {
+		err = errs.NewCodeError(errs.ServerInternalError, "修改请求缺少ID字段")
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	m := new(M)
+	utils.CopyStructFields(m, req)
+	if err = s.UpdateSelectiveById(id.Int(), m); err != nil {
+		log.ZError(e.Context, "更新失败", err)
+		e.Error(err)
+		return
+	}
+	e.OK(nil)
+}
+
+func (e CrudApi[M, Q, A, U]) Delete(context *gin.Context) {
+	req := dto.IdReq{}
+	s, err := e.makeService(context, &req)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	if err = s.Delete(req.ID); err != nil {
+		log.ZError(e.Context, "删除失败", err)
+		e.Error(err)
+		return
+	}
+	e.OK(nil)
+}
+
+func (e CrudApi[M, Q, A, U]) Get(context *gin.Context) {
+	req := dto.IdReq{}
+	s, err := e.makeService(context, &req)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	m, err := s.Get(req.ID)
+	if err != nil {
+		e.Error(err)
+		return
+	}
+	e.OK(m)
+}
+
+func (e CrudApi[M, Q, A, U]) PageQuery(context *gin.Context) {
+	req := new(Q)
+	s, err := e.makeService(context, req)
+	if err != nil {
+		log.ZError(e.Context, "", err)
+		e.Error(err)
+		return
+	}
+	list := make([]M, 0)
+	var count int64
+	if err = s.PageQuery(req, &list, &count); err != nil {
+		e.Error(err)
+		return
+	}
+	pageIndex, pageSize := 1, len(list)
+	if p, ok := any(req).(interface {
+		GetPageIndex() int
+		GetPageSize() int
+	}); ok {
+		pageIndex, pageSize = p.GetPageIndex(), p.GetPageSize()
+	}
+	e.PageOK(list, int(count), pageIndex, pageSize)
+}
@@ -3,12 +3,17 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"orderin-server/pkg/common/constant"
 )
 
 func GinError(c *gin.Context, err error) {
-	c.JSON(http.StatusOK, ParseError(c, err))
+	resp := ParseError(c, err)
+	c.Set(constant.RespCode, resp.Code)
+	c.JSON(http.StatusOK, resp)
 }
 
 func GinSuccess(c *gin.Context, data any) {
-	c.JSON(http.StatusOK, Success(c, data))
+	resp := Success(c, data)
+	c.Set(constant.RespCode, resp.Code)
+	c.JSON(http.StatusOK, resp)
 }
@@ -4,11 +4,14 @@ import (
 	"github.com/gin-gonic/gin"
 	mcontext "orderin-server/pkg/common/context"
 	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/i18n"
+	"orderin-server/pkg/common/tracing"
 	"reflect"
 )
 
 type Response struct {
 	RequestId string `json:"requestId"`
+	TraceId   string `json:"traceId,omitempty"`
 	Code      int    `json:"code"`
 	Msg       string `json:"msg"`
 	Detail    string `json:"detail"`
@@ -22,6 +25,13 @@ type PageData struct {
 	List      interface{} `json:"list"`
 }
 
+type CursorPageData struct {
+	PageSize   int         `json:"pageSize"`
+	NextCursor string      `json:"nextCursor"`      // 为空表示没有下一页
+	Total      *int64      `json:"total,omitempty"` // 请求统计总数时返回
+	List       interface{} `json:"list"`
+}
+
 type ResponseFormat interface {
 	Format()
 }
@@ -56,6 +66,7 @@ func Success(c *gin.Context, data any) *Response {
 	}
 	return &Response{
 		RequestId: mcontext.GetRequestId(c),
+		TraceId:   tracing.TraceID(c),
 		Code:      0,
 		Msg:       "success",
 		Data:      data,
@@ -66,10 +77,12 @@ func ParseError(c *gin.Context, err error) *Response {
 	if err == nil {
 		return Success(c, nil)
 	}
+	// 文案按请求的语言翻译，见 i18n.Locale
+	locale := i18n.Locale(c)
 	unwrap := errs.Unwrap(err)
 	if codeErr, ok := unwrap.(errs.CodeError); ok {
-		resp := Response{Code: codeErr.Code(), Msg: codeErr.Msg(), Detail: codeErr.Detail()}
+		resp := Response{TraceId: tracing.TraceID(c), Code: codeErr.Code(), Msg: i18n.Message(locale, codeErr.Code(), codeErr.Msg()), Detail: codeErr.Detail()}
 		return &resp
 	}
-	return &Response{RequestId: mcontext.GetRequestId(c), Code: errs.ServerInternalError, Msg: "服务器错误", Detail: err.Error()}
+	return &Response{RequestId: mcontext.GetRequestId(c), TraceId: tracing.TraceID(c), Code: errs.ServerInternalError, Msg: i18n.Message(locale, errs.ServerInternalError, "服务器错误"), Detail: err.Error()}
 }
@@ -89,6 +89,6 @@ func (a *authService) DeleteToken(ctx context.Context, userID int64, platformID
 
 func Secret() jwt.Keyfunc {
 	return func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.Config.Secret), nil
+		return []byte(config.Get().Secret), nil
 	}
 }
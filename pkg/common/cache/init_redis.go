@@ -7,6 +7,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"orderin-server/pkg/common/config"
 	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/tracing"
 	"time"
 )
 
@@ -16,30 +17,32 @@ const (
 
 // NewRedis Initialize redis connection.
 func NewRedis() (redis.UniversalClient, error) {
-	if len(config.Config.Redis.Address) == 0 {
+	if len(config.Get().Redis.Address) == 0 {
 		return nil, errors.New("redis address is empty")
 	}
 	errs.AddReplace(redis.Nil, errs.ErrRecordNotFound)
 	var rdb redis.UniversalClient
-	if len(config.Config.Redis.Address) > 1 || config.Config.Redis.ClusterMode {
+	if len(config.Get().Redis.Address) > 1 || config.Get().Redis.ClusterMode {
 		rdb = redis.NewClusterClient(&redis.ClusterOptions{
-			Addrs:      config.Config.Redis.Address,
-			Username:   config.Config.Redis.Username,
-			Password:   config.Config.Redis.Password,
-			PoolSize:   config.Config.Redis.PoolSize,
+			Addrs:      config.Get().Redis.Address,
+			Username:   config.Get().Redis.Username,
+			Password:   config.Get().Redis.Password,
+			PoolSize:   config.Get().Redis.PoolSize,
 			MaxRetries: maxRetry,
 		})
 	} else {
 		rdb = redis.NewClient(&redis.Options{
-			Addr:       config.Config.Redis.Address[0],
-			Username:   config.Config.Redis.Username,
-			Password:   config.Config.Redis.Password,
-			DB:         config.Config.Redis.DB,
-			PoolSize:   config.Config.Redis.PoolSize,
+			Addr:       config.Get().Redis.Address[0],
+			Username:   config.Get().Redis.Username,
+			Password:   config.Get().Redis.Password,
+			DB:         config.Get().Redis.DB,
+			PoolSize:   config.Get().Redis.PoolSize,
 			MaxRetries: maxRetry,
 		})
 	}
 
+	rdb.AddHook(tracing.RedisHook{})
+
 	var err error
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
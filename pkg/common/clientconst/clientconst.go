@@ -0,0 +1,172 @@
+package clientconst
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/i18n"
+)
+
+// Header 生成文件的首行，标记为生成的代码
+const Header = "Code generated by orderin gen client-constants. DO NOT EDIT."
+
+// keyLocale 优先用该语言的标签生成枚举成员名
+const keyLocale = "en-US"
+
+// Constants 前端常量：字典枚举及错误码
+type Constants struct {
+	Enums      []Enum      `json:"enums"`
+	ErrorCodes []ErrorCode `json:"errorCodes"`
+}
+
+// Enum 由字典生成的枚举
+type Enum struct {
+	Name  string     `json:"name"` // 类型名，如 SysUserStatus
+	Dict  string     `json:"dict"` // 字典名称，如 sys_user_status
+	Label string     `json:"label"`
+	Items []EnumItem `json:"items"`
+}
+
+type EnumItem struct {
+	Key          string            `json:"key"` // 成员名
+	Value        string            `json:"value"`
+	Label        string            `json:"label"`
+	Translations map[string]string `json:"translations,omitempty"`
+}
+
+type ErrorCode struct {
+	Key          string            `json:"key"`
+	Code         int               `json:"code"`
+	Message      string            `json:"message"`
+	Translations map[string]string `json:"translations,omitempty"`
+}
+
+// NewEnum 按字典名生成类型名，按 en-US 标签或值生成成员名，都没有可用字符时按位置命名为 Item<n>，重名时加上值
+func NewEnum(dict string, label string, items []EnumItem) Enum {
+	enum := Enum{Name: Identifier(dict, "Dict"), Dict: dict, Label: label, Items: make([]EnumItem, 0, len(items))}
+	used := map[string]bool{}
+	for i, item := range items {
+		key := ""
+		if translated := item.Translations[keyLocale]; translated != "" {
+			key = Identifier(translated, "")
+		}
+		if key == "" {
+			key = Identifier(item.Value, "Value")
+		}
+		if key == "" {
+			key = "Item" + strconv.Itoa(i+1)
+		}
+		if suffix := Identifier(item.Value, ""); used[key] && suffix != "" {
+			key = key + "_" + suffix
+		}
+		for base, i := key, 2; used[key]; i++ {
+			key = base + strconv.Itoa(i)
+		}
+		used[key] = true
+		item.Key = key
+		enum.Items = append(enum.Items, item)
+	}
+	return enum
+}
+
+// ErrorCodes 已登记的错误码，文案取自 i18n 目录
+func ErrorCodes() []ErrorCode {
+	locales := i18n.Locales()
+	var codes []ErrorCode
+	for _, code := range errs.Codes() {
+		errorCode := ErrorCode{Key: code.Name, Code: code.Code, Message: i18n.Message(i18n.DefaultLocale, code.Code, "")}
+		for _, locale := range locales[1:] {
+			if msg := i18n.Message(locale, code.Code, ""); msg != "" {
+				if errorCode.Translations == nil {
+					errorCode.Translations = map[string]string{}
+				}
+				errorCode.Translations[locale] = msg
+			}
+		}
+		codes = append(codes, errorCode)
+	}
+	return codes
+}
+
+// Identifier 转为大驼峰的标识符，只保留字母和数字，以数字开头时加上 prefix，没有可用字符时返回空
+func Identifier(s string, prefix string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	id := b.String()
+	if id != "" && unicode.IsDigit(rune(id[0])) {
+		id = prefix + id
+	}
+	return id
+}
+
+// TypeScript 生成 ts 枚举及默认语言的标签
+func TypeScript(c *Constants) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n", Header)
+	for _, enum := range c.Enums {
+		fmt.Fprintf(&b, "\n/** %s（%s） */\nexport enum %s {\n", comment(enum.Label), enum.Dict, enum.Name)
+		for _, item := range enum.Items {
+			fmt.Fprintf(&b, "  /** %s */\n  %s = %s,\n", comment(item.Label), item.Key, tsString(item.Value))
+		}
+		fmt.Fprintf(&b, "}\n\nexport const %sLabels: Record<%s, string> = {\n", enum.Name, enum.Name)
+		for _, item := range enum.Items {
+			fmt.Fprintf(&b, "  [%s.%s]: %s,\n", enum.Name, item.Key, tsString(item.Label))
+		}
+		b.WriteString("};\n")
+	}
+	b.WriteString("\n/** 错误码 */\nexport enum ErrorCode {\n")
+	for _, code := range c.ErrorCodes {
+		fmt.Fprintf(&b, "  /** %s */\n  %s = %d,\n", comment(code.Message), code.Key, code.Code)
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// Go 生成 go 常量及默认语言的标签
+func Go(c *Constants, pkg string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s\n\npackage %s\n", Header, pkg)
+	for _, enum := range c.Enums {
+		fmt.Fprintf(&b, "\n// %s %s（%s）\ntype %s string\n\nconst (\n", enum.Name, comment(enum.Label), enum.Dict, enum.Name)
+		for _, item := range enum.Items {
+			fmt.Fprintf(&b, "%s%s %s = %s // %s\n", enum.Name, item.Key, enum.Name, strconv.Quote(item.Value), comment(item.Label))
+		}
+		fmt.Fprintf(&b, ")\n\n// %sLabels 各值的标签\nvar %sLabels = map[%s]string{\n", enum.Name, enum.Name, enum.Name)
+		for _, item := range enum.Items {
+			fmt.Fprintf(&b, "%s%s: %s,\n", enum.Name, item.Key, strconv.Quote(item.Label))
+		}
+		b.WriteString("}\n")
+	}
+	b.WriteString("\n// ErrorCode 错误码\ntype ErrorCode int\n\nconst (\n")
+	for _, code := range c.ErrorCodes {
+		fmt.Fprintf(&b, "ErrorCode%s ErrorCode = %d // %s\n", code.Key, code.Code, comment(code.Message))
+	}
+	b.WriteString(")\n")
+	return format.Source(b.Bytes())
+}
+
+// Sort 按字典名排序，保证生成的文件稳定
+func (c *Constants) Sort() {
+	sort.SliceStable(c.Enums, func(i, j int) bool {
+		return c.Enums[i].Dict < c.Enums[j].Dict
+	})
+}
+
+func comment(s string) string {
+	s = strings.ReplaceAll(s, "*/", "* /")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func tsString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`).Replace(s) + "'"
+}
@@ -0,0 +1,65 @@
+package clientconst
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewEnum(t *testing.T) {
+	enum := NewEnum("sys_user_status", "系统用户状态", []EnumItem{
+		{Value: "1", Label: "正常", Translations: map[string]string{"en-US": "Normal"}},
+		{Value: "2", Label: "禁用"},
+		{Value: "SMS_296350564", Label: "验证码"},
+		{Value: "3", Label: "正常", Translations: map[string]string{"en-US": "normal"}},
+	})
+	if enum.Name != "SysUserStatus" {
+		t.Errorf("Name = %s, want SysUserStatus", enum.Name)
+	}
+	want := []string{"Normal", "Value2", "SMS296350564", "Normal_3"}
+	for i, item := range enum.Items {
+		if item.Key != want[i] {
+			t.Errorf("Items[%d].Key = %s, want %s", i, item.Key, want[i])
+		}
+	}
+}
+
+func TestNewEnumWithoutIdentifier(t *testing.T) {
+	enum := NewEnum("sys_user_sex", "性别", []EnumItem{
+		{Value: "男", Label: "男"},
+		{Value: "女", Label: "女", Translations: map[string]string{"en-US": "女"}},
+		{Value: "M", Label: "男", Translations: map[string]string{"en-US": "Item1"}},
+		{Value: "未知", Label: "未知", Translations: map[string]string{"en-US": "Item1"}},
+	})
+	want := []string{"Item1", "Item2", "Item1_M", "Item12"}
+	for i, item := range enum.Items {
+		if item.Key != want[i] {
+			t.Errorf("Items[%d].Key = %s, want %s", i, item.Key, want[i])
+		}
+	}
+	ts := string(TypeScript(&Constants{Enums: []Enum{enum}}))
+	if !strings.Contains(ts, "  Item1 = '男',\n") || !strings.Contains(ts, "[SysUserSex.Item2]: '女',") {
+		t.Errorf("TypeScript() = %s", ts)
+	}
+}
+
+func TestRender(t *testing.T) {
+	c := &Constants{
+		Enums:      []Enum{NewEnum("sys_user_status", "系统用户状态", []EnumItem{{Value: "1", Label: "it's */"}})},
+		ErrorCodes: ErrorCodes(),
+	}
+	ts := string(TypeScript(c))
+	for _, s := range []string{"export enum SysUserStatus {", "Value1 = '1',", `[SysUserStatus.Value1]: 'it\'s */',`, "AccountOrPasswordError = 1001,"} {
+		if !strings.Contains(ts, s) {
+			t.Errorf("TypeScript() does not contain %q:\n%s", s, ts)
+		}
+	}
+	code, err := Go(c, "constants")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"package constants", `SysUserStatusValue1 SysUserStatus = "1"`, "ErrorCodeAccountOrPasswordError ErrorCode = 1001"} {
+		if !strings.Contains(string(code), s) {
+			t.Errorf("Go() does not contain %q:\n%s", s, code)
+		}
+	}
+}
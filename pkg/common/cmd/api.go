@@ -25,15 +25,15 @@ func (a *ApiCmd) GetPortFromConfig(portType string) int {
 	switch a.Name {
 	case AdminApi:
 		if portType == constant.FlagPort {
-			return config.Config.AdminApi.Port[0]
+			return config.Get().AdminApi.Port[0]
 		} else if portType == constant.FlagPrometheusPort {
-			return config.Config.Prometheus.AdminPrometheusPort[0]
+			return config.Get().Prometheus.AdminPrometheusPort[0]
 		}
 	case AppApi:
 		if portType == constant.FlagPort {
-			return config.Config.AppApi.Port[0]
+			return config.Get().AppApi.Port[0]
 		} else if portType == constant.FlagPrometheusPort {
-			return config.Config.Prometheus.AppPrometheusPort[0]
+			return config.Get().Prometheus.AppPrometheusPort[0]
 		}
 	}
 	return 0
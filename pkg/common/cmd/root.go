@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"github.com/spf13/cobra"
 	"orderin-server/pkg/common/config"
@@ -8,7 +9,10 @@ import (
 	"orderin-server/pkg/common/email"
 	"orderin-server/pkg/common/file_store"
 	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/network"
 	"orderin-server/pkg/common/sms"
+	"orderin-server/pkg/common/tracing"
+	"reflect"
 )
 
 type RootCmdPt interface {
@@ -35,6 +39,10 @@ func NewRootCmd(name string, opts ...func(*CmdOpts)) *RootCmd {
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			return rootCmd.persistentPreRun(cmd, opts...)
 		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			config.StopWatch()
+			return tracing.Shutdown(context.Background())
+		},
 	}
 	rootCmd.Command = cmd
 	rootCmd.addConfFlag()
@@ -51,6 +59,10 @@ func (rc *RootCmd) persistentPreRun(cmd *cobra.Command, opts ...func(*CmdOpts))
 	if err := rc.initializeLogger(cmdOpts); err != nil {
 		return fmt.Errorf("failed to initialize from config: %w", err)
 	}
+	//初始化链路追踪
+	if err := rc.initializeTracing(); err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
 
 	//初始化file store
 	file_store.InitFileStore()
@@ -60,9 +72,40 @@ func (rc *RootCmd) persistentPreRun(cmd *cobra.Command, opts ...func(*CmdOpts))
 
 	//初始化email vendor
 	email.InitEmailVendor()
+
+	//初始化离线ip库
+	network.InitGeoDB(config.Get().LoginLog.IpDatabase)
+
+	//监听配置文件变化
+	rc.subscribeConfig()
+	if err := config.Watch(); err != nil {
+		log.ZWarn(context.Background(), "failed to watch config, hot reload is disabled", err)
+	}
 	return nil
 }
 
+// subscribeConfig 订阅配置热加载，连接类配置需要重启才能生效
+func (rc *RootCmd) subscribeConfig() {
+	config.Subscribe("log", func(old, new *config.Snapshot) {
+		if old.Log.RemainLogLevel != new.Log.RemainLogLevel {
+			log.SetDefaultLevel(new.Log.RemainLogLevel)
+			log.ZInfo(context.Background(), "log level reloaded", "remainLogLevel", new.Log.RemainLogLevel)
+		}
+		oldLog, newLog := old.Log, new.Log
+		oldLog.RemainLogLevel = newLog.RemainLogLevel
+		if oldLog != newLog {
+			log.ZWarn(context.Background(), "log output config changed, restart to take effect", nil)
+		}
+	})
+	config.Subscribe("restart_required", func(old, new *config.Snapshot) {
+		if !reflect.DeepEqual(old.Mysql, new.Mysql) || !reflect.DeepEqual(old.Redis, new.Redis) ||
+			!reflect.DeepEqual(old.AdminApi, new.AdminApi) || !reflect.DeepEqual(old.AppApi, new.AppApi) ||
+			!reflect.DeepEqual(old.Prometheus, new.Prometheus) || old.Tracing != new.Tracing {
+			log.ZWarn(context.Background(), "mysql/redis/listen/prometheus/tracing config changed, restart to take effect", nil)
+		}
+	})
+}
+
 func (rc *RootCmd) initializeConfiguration(cmd *cobra.Command) error {
 	return rc.getConfFromCmdAndInit(cmd)
 }
@@ -77,7 +120,7 @@ func (rc *RootCmd) applyOptions(opts ...func(*CmdOpts)) *CmdOpts {
 }
 
 func (rc *RootCmd) initializeLogger(cmdOpts *CmdOpts) error {
-	logConfig := config.Config.Log
+	logConfig := config.Get().Log
 
 	return log.InitFromConfig(
 		cmdOpts.logFilePrefixName,
@@ -91,6 +134,16 @@ func (rc *RootCmd) initializeLogger(cmdOpts *CmdOpts) error {
 	)
 }
 
+func (rc *RootCmd) initializeTracing() error {
+	if err := tracing.Init(rc.Name); err != nil {
+		return err
+	}
+	if config.Get().Tracing.Exporter != "" && config.Get().Tracing.Exporter != tracing.ExporterOff {
+		tracing.InstrumentDefaultTransport()
+	}
+	return nil
+}
+
 func defaultCmdOpts() *CmdOpts {
 	return &CmdOpts{
 		logFilePrefixName: "orderin.log.all",
@@ -102,7 +155,22 @@ func (r *RootCmd) SetRootCmdPt(cmdItf RootCmdPt) {
 }
 
 func (r *RootCmd) addConfFlag() {
-	r.Command.Flags().StringP(constant.FlagConf, "c", "", "path to config file folder")
+	AddConfFlags(&r.Command)
+}
+
+// AddConfFlags 添加加载配置相关的flag
+func AddConfFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(constant.FlagConf, "c", "", "path to config file folder")
+	cmd.Flags().String(constant.FlagProfile, "", "config profile, config-{profile}.yaml overrides config.yaml")
+	cmd.Flags().StringArray(constant.FlagSet, nil, "override config item, e.g. --set mysql.maxOpenConn=20")
+}
+
+// ConfOptionsFromFlags 从flag中读取配置目录及加载选项
+func ConfOptionsFromFlags(cmd *cobra.Command) (string, []func(*config.Options)) {
+	configFolderPath, _ := cmd.Flags().GetString(constant.FlagConf)
+	profile, _ := cmd.Flags().GetString(constant.FlagProfile)
+	overrides, _ := cmd.Flags().GetStringArray(constant.FlagSet)
+	return configFolderPath, []func(*config.Options){config.WithProfile(profile), config.WithOverrides(overrides...)}
 }
 
 func (r *RootCmd) AddPortFlag() {
@@ -141,9 +209,9 @@ func (r *RootCmd) GetPrometheusPortFlag() int {
 }
 
 func (r *RootCmd) getConfFromCmdAndInit(cmdLines *cobra.Command) error {
-	configFolderPath, _ := cmdLines.Flags().GetString(constant.FlagConf)
+	configFolderPath, opts := ConfOptionsFromFlags(cmdLines)
 	fmt.Println("configFolderPath:", configFolderPath)
-	return config.InitConfig(configFolderPath)
+	return config.InitConfig(configFolderPath, opts...)
 }
 
 func (r *RootCmd) Execute() error {
@@ -1,71 +1,86 @@
 package config
 
-var Config configStruct
+// Snapshot 某一时刻生效的配置，通过 Get 获取，只读不可修改
+type Snapshot = configStruct
 
+// 带 secret 标签的字段在日志中会被脱敏，见 log.Redact；
+// default 标签为缺省值，vd 标签为加载完成后的校验规则
 type configStruct struct {
 	Env struct {
 		Profiles string `yaml:"profiles"`
 	} `yaml:"env"`
+	// 关系数据库，driver 为 sqlite 时 database 为数据库文件路径，:memory: 为内存数据库
 	Mysql struct {
-		Address       []string `yaml:"address"`
+		Driver        string   `yaml:"driver" default:"mysql" vd:"@:in($,'mysql','postgres','sqlite'); msg:sprintf('mysql.driver must be one of mysql|postgres|sqlite, got %v',$)"`
+		Address       []string `yaml:"address" vd:"@:len($)>0 || (Driver)$=='sqlite'; msg:'mysql.address is required'"`
 		Username      string   `yaml:"username"`
-		Password      string   `yaml:"password"`
-		Database      string   `yaml:"database"`
-		MaxOpenConn   int      `yaml:"maxOpenConn"`
-		MaxIdleConn   int      `yaml:"maxIdleConn"`
-		MaxLifeTime   int      `yaml:"maxLifeTime"`
-		LogLevel      int      `yaml:"logLevel"`
-		SlowThreshold int      `yaml:"slowThreshold"`
+		Password      string   `yaml:"password" secret:"true"`
+		Database      string   `yaml:"database" vd:"@:len($)>0; msg:'mysql.database is required'"`
+		MaxOpenConn   int      `yaml:"maxOpenConn" default:"10"`
+		MaxIdleConn   int      `yaml:"maxIdleConn" default:"5"`
+		MaxLifeTime   int      `yaml:"maxLifeTime" default:"60"`
+		LogLevel      int      `yaml:"logLevel" default:"3"`
+		SlowThreshold int      `yaml:"slowThreshold" default:"500"`
+		// 只读从库，address 中第一个以外的地址也是从库。连接池参数为0时与主库相同
+		Replicas []struct {
+			Address     string `yaml:"address"`
+			MaxOpenConn int    `yaml:"maxOpenConn"`
+			MaxIdleConn int    `yaml:"maxIdleConn"`
+			MaxLifeTime int    `yaml:"maxLifeTime"`
+		} `yaml:"replicas"`
+		ReplicaCheckInterval int `yaml:"replicaCheckInterval" default:"10"` // 从库健康检查间隔（秒），不可用的从库不再读取
+		// 启动时执行未执行的迁移，表结构只由迁移维护。生产环境关闭，部署时执行 orderin migrate up，有未执行的迁移时不能启动
+		AutoMigrate bool `yaml:"autoMigrate" default:"true"`
 	} `yaml:"mysql"`
 
 	Mongo struct {
-		Uri         string   `yaml:"uri"`
+		Uri         string   `yaml:"uri" secret:"true"`
 		Address     []string `yaml:"address"`
 		Database    string   `yaml:"database"`
 		Username    string   `yaml:"username"`
-		Password    string   `yaml:"password"`
+		Password    string   `yaml:"password" secret:"true"`
 		MaxPoolSize int      `yaml:"maxPoolSize"`
 	} `yaml:"mongo"`
 
 	Redis struct {
 		ClusterMode bool     `yaml:"clusterMode"`
-		Address     []string `yaml:"address"`
+		Address     []string `yaml:"address" vd:"@:len($)>0; msg:'redis.address is required'"`
 		Username    string   `yaml:"username"`
-		Password    string   `yaml:"password"`
+		Password    string   `yaml:"password" secret:"true"`
 		DB          int      `yaml:"db"`
-		PoolSize    int      `yaml:"poolSize"`
+		PoolSize    int      `yaml:"poolSize" default:"10"`
 	} `yaml:"redis"`
 
 	FileStore struct {
-		DriverType      string `yaml:"driverType"`
+		DriverType      string `yaml:"driverType" vd:"@:$=='' || in($,'AliYunOSS','HuaweiOBS','QiNiuKodo'); msg:sprintf('fileStore.driverType must be one of AliYunOSS|HuaweiOBS|QiNiuKodo, got %v',$)"`
 		Endpoint        string `yaml:"endpoint"`
 		AccessKeyID     string `yaml:"accessKeyId"`
-		AccessKeySecret string `yaml:"accessKeySecret"`
+		AccessKeySecret string `yaml:"accessKeySecret" secret:"true"`
 		BucketName      string `yaml:"bucketName"`
 		Domain          string `yaml:"domain"`
 	} `yaml:"fileStore"`
 
 	Sms struct {
-		DriverType      string `yaml:"driverType"`
+		DriverType      string `yaml:"driverType" vd:"@:$=='' || in($,'AliyunDysms'); msg:sprintf('sms.driverType must be one of AliyunDysms, got %v',$)"`
 		Endpoint        string `yaml:"endpoint"`
 		AccessKeyID     string `yaml:"accessKeyId"`
-		AccessKeySecret string `yaml:"accessKeySecret"`
+		AccessKeySecret string `yaml:"accessKeySecret" secret:"true"`
 	} `yaml:"sms"`
 
 	Mail struct {
-		DriverType string `yaml:"driverType"`
+		DriverType string `yaml:"driverType" vd:"@:$=='' || in($,'QQEmail','GMail'); msg:sprintf('mail.driverType must be one of QQEmail|GMail, got %v',$)"`
 		From       string `yaml:"from"`
 		Account    string `yaml:"account"`
-		Password   string `yaml:"password"`
+		Password   string `yaml:"password" secret:"true"`
 		SmtpHost   string `yaml:"smtpHost"`
 		SmtpPort   int    `yaml:"smtpPort"`
 	} `yaml:"mail"`
 
 	WxOpenPlatform struct {
 		AppID         string `yaml:"appId"`
-		AppSecret     string `yaml:"appSecret"`
-		MessageToken  string `yaml:"messageToken"`
-		MessageAesKey string `yaml:"messageAesKey"`
+		AppSecret     string `yaml:"appSecret" secret:"true"`
+		MessageToken  string `yaml:"messageToken" secret:"true"`
+		MessageAesKey string `yaml:"messageAesKey" secret:"true"`
 	} `yaml:"wxOpenPlatform"`
 
 	WxOfficialAccount struct {
@@ -73,10 +88,10 @@ type configStruct struct {
 	} `yaml:"wxOfficialAccount"`
 
 	Log struct {
-		StorageLocation     string `yaml:"storageLocation"`
-		RotationTime        uint   `yaml:"rotationTime"`
-		RemainRotationCount uint   `yaml:"remainRotationCount"`
-		RemainLogLevel      int    `yaml:"remainLogLevel"`
+		StorageLocation     string `yaml:"storageLocation" default:"./logs/"`
+		RotationTime        uint   `yaml:"rotationTime" default:"24"`
+		RemainRotationCount uint   `yaml:"remainRotationCount" default:"2"`
+		RemainLogLevel      int    `yaml:"remainLogLevel" default:"4" vd:"@:$>=0 && $<=6; msg:sprintf('log.remainLogLevel must be between 0 and 6, got %v',$)"`
 		IsStdout            bool   `yaml:"isStdout"`
 		IsJson              bool   `yaml:"isJson"`
 		WithStack           bool   `yaml:"withStack"`
@@ -89,25 +104,58 @@ type configStruct struct {
 		AppPrometheusPort   []int  `yaml:"appPrometheusPort"`
 	} `yaml:"prometheus"`
 
-	Secret      string `yaml:"secret"`
+	Secret      string `yaml:"secret" secret:"true" vd:"@:len($)>0; msg:'secret is required'"`
 	TokenPolicy struct {
-		Expire int64 `yaml:"expire"`
+		Expire int64 `yaml:"expire" default:"90" vd:"@:$>0; msg:'tokenPolicy.expire must be greater than 0'"`
 	} `yaml:"tokenPolicy"`
 
 	SuperAdmin struct {
 		Username string `yaml:"username"`
-		Password string `yaml:"password"`
+		Password string `yaml:"password" secret:"true"`
 		RealName string `yaml:"realName"`
 		Phone    string `yaml:"phone"`
 	} `yaml:"superAdmin"`
 
 	AdminApi struct {
-		Port     []int  `yaml:"port"`
-		ListenIP string `yaml:"listenIP"`
+		Port     []int  `yaml:"port" vd:"@:len($)>0; msg:'adminApi.port is required'"`
+		ListenIP string `yaml:"listenIP" default:"0.0.0.0"`
 	} `yaml:"adminApi"`
 
 	AppApi struct {
-		Port     []int  `yaml:"port"`
-		ListenIP string `yaml:"listenIP"`
+		Port     []int  `yaml:"port" vd:"@:len($)>0; msg:'appApi.port is required'"`
+		ListenIP string `yaml:"listenIP" default:"0.0.0.0"`
 	} `yaml:"appApi"`
+
+	OperationLog struct {
+		Enable        bool `yaml:"enable"`
+		BatchSize     int  `yaml:"batchSize" default:"100"`
+		FlushInterval int  `yaml:"flushInterval" default:"3"`
+		RetentionDays int  `yaml:"retentionDays" default:"180"`
+	} `yaml:"operationLog"`
+
+	LoginLog struct {
+		IpDatabase     string `yaml:"ipDatabase"`
+		NewDeviceAlert bool   `yaml:"newDeviceAlert"`
+	} `yaml:"loginLog"`
+
+	Sentinel struct {
+		InboundQps float64 `yaml:"inboundQps" default:"200" vd:"@:$>0; msg:'sentinel.inboundQps must be greater than 0'"`
+	} `yaml:"sentinel"`
+
+	DynamicConfig struct {
+		PollInterval int `yaml:"pollInterval" default:"30" vd:"@:$>0; msg:'dynamicConfig.pollInterval must be greater than 0'"` // 轮询配置版本号的间隔（秒）
+	} `yaml:"dynamicConfig"`
+
+	DictCache struct {
+		LocalSize int `yaml:"localSize" default:"500" vd:"@:$>0; msg:'dictCache.localSize must be greater than 0'"` // 本地缓存的字典个数
+		LocalTtl  int `yaml:"localTtl" default:"60"`                                                                // 本地缓存有效期（秒），0表示不过期
+		RedisTtl  int `yaml:"redisTtl" default:"600"`                                                               // redis 缓存有效期（秒），0表示不过期
+	} `yaml:"dictCache"`
+
+	Tracing struct {
+		Exporter    string  `yaml:"exporter" default:"off" vd:"@:$=='' || in($,'off','stdout','otlp'); msg:sprintf('tracing.exporter must be one of off|stdout|otlp, got %v',$)"`
+		Endpoint    string  `yaml:"endpoint"`
+		Insecure    bool    `yaml:"insecure"`
+		SampleRatio float64 `yaml:"sampleRatio" default:"1" vd:"@:$>=0 && $<=1; msg:sprintf('tracing.sampleRatio must be between 0 and 1, got %v',$)"`
+	} `yaml:"tracing"`
 }
@@ -2,15 +2,23 @@ package config
 
 import (
 	"fmt"
+	vd "github.com/bytedance/go-tagexpr/v2/validator"
 	"gopkg.in/yaml.v3"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 const (
-	FileName          = "config.yaml"
-	DefaultFolderPath = "../config/"
-	Version           = "1.0.0"
+	FileName        = "config.yaml"
+	ProfileFileName = "config-%s.yaml"
+	// LegacyProfileFileName 旧版本的 profile 文件名，文件中是完整的配置，作为覆盖层加载结果相同
+	LegacyProfileFileName = "config_%s.yaml"
+	EnvPrefix             = "ORDERIN_"
+	EnvConfigPath         = "ORDERIN_CONFIG"
+	EnvProfile            = "ORDERIN_PROFILE"
+	DefaultFolderPath     = "../config/"
+	Version               = "1.0.0"
 )
 
 // return absolude path join ../config/, this is k8s container config path
@@ -30,37 +38,152 @@ func GetProjectRoot() string {
 	return rootDir
 }
 
-func initConfig(config interface{}, configName, configFolderPath string) error {
-	configFolderPath = filepath.Join(configFolderPath, configName)
-	_, err := os.Stat(configFolderPath)
+// Options 配置加载选项
+type Options struct {
+	// Profile 为空时依次取环境变量 ORDERIN_PROFILE、基础配置文件中的 env.profiles
+	Profile string
+	// Overrides 命令行覆盖项，格式为 yaml路径=值，如 mysql.maxOpenConn=20
+	Overrides []string
+}
+
+func WithProfile(profile string) func(*Options) {
+	return func(o *Options) {
+		o.Profile = profile
+	}
+}
+
+func WithOverrides(overrides ...string) func(*Options) {
+	return func(o *Options) {
+		o.Overrides = append(o.Overrides, overrides...)
+	}
+}
+
+// resolveConfigFile 返回配置文件路径，目录下不存在时回退到项目根目录下的 config 目录
+func resolveConfigFile(configName, configFolderPath string) (string, error) {
+	configPath := filepath.Join(configFolderPath, configName)
+	_, err := os.Stat(configPath)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			fmt.Println("stat config path error:", err.Error())
-			return fmt.Errorf("stat config path error: %w", err)
+			return "", fmt.Errorf("stat config path error: %w", err)
 		}
-		configFolderPath = filepath.Join(GetProjectRoot(), "config", configName)
-		fmt.Println("flag's path,environment's path,default path all is not exist,using project path:", configFolderPath)
+		configPath = filepath.Join(GetProjectRoot(), "config", configName)
+		fmt.Println("flag's path,environment's path,default path all is not exist,using project path:", configPath)
 	}
-	data, err := os.ReadFile(configFolderPath)
+	return configPath, nil
+}
+
+func unmarshalFile(config interface{}, configPath string) error {
+	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return fmt.Errorf("read file error: %w", err)
 	}
 	if err = yaml.Unmarshal(data, config); err != nil {
-		return fmt.Errorf("unmarshal yaml error: %w", err)
+		return fmt.Errorf("unmarshal yaml %s error: %w", configPath, err)
 	}
-	fmt.Println("use config", configFolderPath)
-
+	fmt.Println("use config", configPath)
 	return nil
 }
 
-func InitConfig(configFolderPath string) error {
-	if configFolderPath == "" {
-		envConfigPath := os.Getenv("XXXJZ_CONFIG")
-		if envConfigPath != "" {
-			configFolderPath = envConfigPath
-		} else {
-			configFolderPath = GetDefaultConfigPath()
+// findProfileFile 查找 profile 的配置文件，优先 config-{profile}.yaml，其次旧版本的 config_{profile}.yaml
+func findProfileFile(configDir string, profile string) (string, bool) {
+	for _, name := range []string{ProfileFileName, LegacyProfileFileName} {
+		path := filepath.Join(configDir, fmt.Sprintf(name, profile))
+		if _, err := os.Stat(path); err == nil {
+			return path, true
 		}
 	}
-	return initConfig(&Config, FileName, configFolderPath)
+	return "", false
+}
+
+// load 按 缺省值 -> config.yaml -> config-{profile}.yaml -> ORDERIN_* 环境变量 -> 命令行覆盖项 的顺序加载，后者覆盖前者，
+// 最后解密 ENC(...) 形式的值，返回实际使用的配置目录
+func load(config *configStruct, configFolderPath string, options *Options) (string, error) {
+	if err := applyDefaults(config); err != nil {
+		return "", err
+	}
+	basePath, err := resolveConfigFile(FileName, configFolderPath)
+	if err != nil {
+		return "", err
+	}
+	if err = unmarshalFile(config, basePath); err != nil {
+		return "", err
+	}
+	configDir := filepath.Dir(basePath)
+
+	profile := options.Profile
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	if profile == "" {
+		profile = config.Env.Profiles
+	}
+	if profile != "" {
+		if profilePath, ok := findProfileFile(configDir, profile); ok {
+			if err = unmarshalFile(config, profilePath); err != nil {
+				return "", err
+			}
+		} else if options.Profile != "" {
+			// --profile 指定的 profile 必须存在对应文件，环境变量指定的可以没有，如镜像中只有 config.yaml
+			return "", fmt.Errorf("config file of profile %s not found: %s", profile, filepath.Join(configDir, fmt.Sprintf(ProfileFileName, profile)))
+		}
+		config.Env.Profiles = profile
+	}
+
+	if err = applyEnv(config); err != nil {
+		return "", err
+	}
+	if err = applyOverrides(config, options.Overrides); err != nil {
+		return "", err
+	}
+	if err = decryptSecrets(config); err != nil {
+		return "", err
+	}
+	return configDir, nil
+}
+
+// Load 加载配置并设为当前配置，不做校验
+func Load(configFolderPath string, opts ...func(*Options)) error {
+	if configFolderPath == "" {
+		configFolderPath = os.Getenv(EnvConfigPath)
+	}
+	if configFolderPath == "" {
+		// 兼容旧的环境变量
+		configFolderPath = os.Getenv("XXXJZ_CONFIG")
+	}
+	if configFolderPath == "" {
+		configFolderPath = GetDefaultConfigPath()
+	}
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	c := &configStruct{}
+	configDir, err := load(c, configFolderPath, options)
+	if err != nil {
+		return err
+	}
+	setSource(configFolderPath, configDir, options)
+	current.Store(c)
+	return nil
+}
+
+// InitConfig 加载并校验配置
+func InitConfig(configFolderPath string, opts ...func(*Options)) error {
+	if err := Load(configFolderPath, opts...); err != nil {
+		return err
+	}
+	return Validate()
+}
+
+// Validate 按 vd 标签校验当前配置，返回全部不合法的配置项
+func Validate() error {
+	return validate(Get())
+}
+
+func validate(config *configStruct) error {
+	if err := vd.Validate(config, true); err != nil {
+		return fmt.Errorf("invalid config: %s", strings.ReplaceAll(err.Error(), "\t", "; "))
+	}
+	return nil
 }
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"orderin-server/pkg/common/utils"
+)
+
+const (
+	// EnvMasterKey 解密 ENC(...) 配置值的主密钥
+	EnvMasterKey = "ORDERIN_MASTER_KEY"
+	// EnvMasterKeyFile 主密钥文件路径，文件内容即主密钥
+	EnvMasterKeyFile = "ORDERIN_MASTER_KEY_FILE"
+	// FileEnvSuffix 环境变量加此后缀表示从文件读取值，如 ORDERIN_MYSQL_PASSWORD_FILE=/run/secrets/mysql
+	FileEnvSuffix = "_FILE"
+)
+
+var (
+	// EncryptedRegexp 匹配文本中的加密值 ENC(base64)
+	EncryptedRegexp      = regexp.MustCompile(`ENC\(([A-Za-z0-9+/=]*)\)`)
+	encryptedValueRegexp = regexp.MustCompile(`^\s*ENC\(([A-Za-z0-9+/=]*)\)\s*$`)
+)
+
+// IsEncrypted 判断配置值是否为 ENC(...) 形式
+func IsEncrypted(value string) bool {
+	return encryptedValueRegexp.MatchString(value)
+}
+
+// MasterKey 依次从 ORDERIN_MASTER_KEY、ORDERIN_MASTER_KEY_FILE 读取主密钥，都未设置时返回空
+func MasterKey() (string, error) {
+	if key := os.Getenv(EnvMasterKey); key != "" {
+		return key, nil
+	}
+	if path := os.Getenv(EnvMasterKeyFile); path != "" {
+		return ReadKeyFile(path)
+	}
+	return "", nil
+}
+
+// ReadKeyFile 读取密钥文件，去掉首尾空白
+func ReadKeyFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read key file error: %w", err)
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("key file %s is empty", path)
+	}
+	return key, nil
+}
+
+// deriveKey 主密钥可以是任意字符串，经 sha256 得到 AES-256 密钥
+func deriveKey(masterKey string) []byte {
+	sum := sha256.Sum256([]byte(masterKey))
+	return sum[:]
+}
+
+// Encrypt 用主密钥加密配置值，返回 ENC(...) 形式
+func Encrypt(plain, masterKey string) (string, error) {
+	if masterKey == "" {
+		return "", fmt.Errorf("master key is empty")
+	}
+	data, err := utils.AesGcmEncrypt([]byte(plain), deriveKey(masterKey))
+	if err != nil {
+		return "", err
+	}
+	return "ENC(" + base64.StdEncoding.EncodeToString(data) + ")", nil
+}
+
+// Decrypt 解密 ENC(...) 形式的配置值，非加密值原样返回
+func Decrypt(value, masterKey string) (string, error) {
+	m := encryptedValueRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return value, nil
+	}
+	if masterKey == "" {
+		return "", fmt.Errorf("value is encrypted but neither %s nor %s is set", EnvMasterKey, EnvMasterKeyFile)
+	}
+	data, err := base64.StdEncoding.DecodeString(m[1])
+	if err != nil {
+		return "", err
+	}
+	plain, err := utils.AesGcmDecrypt(data, deriveKey(masterKey))
+	if err != nil {
+		return "", fmt.Errorf("decrypt failed, wrong master key or corrupted value: %w", err)
+	}
+	return string(plain), nil
+}
+
+// decryptSecrets 解密所有 ENC(...) 形式的字符串配置
+func decryptSecrets(config *configStruct) error {
+	var masterKey string
+	var keyLoaded bool
+	for _, it := range items(config) {
+		for _, v := range stringValues(it.value) {
+			if !IsEncrypted(v.String()) {
+				continue
+			}
+			if !keyLoaded {
+				key, err := MasterKey()
+				if err != nil {
+					return err
+				}
+				masterKey, keyLoaded = key, true
+			}
+			plain, err := Decrypt(v.String(), masterKey)
+			if err != nil {
+				return fmt.Errorf("config item %s: %w", it.path, err)
+			}
+			v.SetString(plain)
+		}
+	}
+	return nil
+}
+
+func stringValues(v reflect.Value) []reflect.Value {
+	switch {
+	case v.Kind() == reflect.String:
+		return []reflect.Value{v}
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		values := make([]reflect.Value, v.Len())
+		for i := range values {
+			values[i] = v.Index(i)
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// ReEncrypt 用新主密钥重新加密文本中所有 ENC(...) 值，只替换加密值，保留其余内容，返回新文本和替换的个数
+func ReEncrypt(content, oldKey, newKey string) (string, int, error) {
+	var count int
+	var replaceErr error
+	result := EncryptedRegexp.ReplaceAllStringFunc(content, func(value string) string {
+		if replaceErr != nil {
+			return value
+		}
+		plain, err := Decrypt(value, oldKey)
+		if err != nil {
+			replaceErr = err
+			return value
+		}
+		encrypted, err := Encrypt(plain, newKey)
+		if err != nil {
+			replaceErr = err
+			return value
+		}
+		count++
+		return encrypted
+	})
+	if replaceErr != nil {
+		return "", 0, replaceErr
+	}
+	return result, count, nil
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	encrypted, err := Encrypt("123456", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(encrypted) {
+		t.Fatalf("Encrypt() = %s, want ENC(...)", encrypted)
+	}
+	if plain, err := Decrypt(encrypted, "master"); err != nil || plain != "123456" {
+		t.Errorf("Decrypt() = %q, %v, want 123456", plain, err)
+	}
+	if _, err = Decrypt(encrypted, "other"); err == nil {
+		t.Errorf("Decrypt() with wrong key should fail")
+	}
+	if plain, _ := Decrypt("plain", ""); plain != "plain" {
+		t.Errorf("Decrypt() should keep plain value, got %q", plain)
+	}
+
+	content := "mysql:\n  password: " + encrypted + " # comment\nsecret: plain\n"
+	reEncrypted, count, err := ReEncrypt(content, "master", "new")
+	if err != nil || count != 1 {
+		t.Fatalf("ReEncrypt() count=%d err=%v", count, err)
+	}
+	if !strings.HasSuffix(reEncrypted, " # comment\nsecret: plain\n") {
+		t.Errorf("ReEncrypt() should keep the rest of the content, got %q", reEncrypted)
+	}
+	value := strings.TrimSuffix(strings.Split(reEncrypted, "password: ")[1], " # comment\nsecret: plain\n")
+	if plain, err := Decrypt(value, "new"); err != nil || plain != "123456" {
+		t.Errorf("Decrypt() after ReEncrypt() = %q, %v", plain, err)
+	}
+}
+
+func TestLoadSecrets(t *testing.T) {
+	dir := t.TempDir()
+	encrypted, _ := Encrypt("jwt-secret", "master")
+	base := "secret: " + encrypted + "\nredis:\n  address: [\"" + encrypted + "\"]\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(base), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	passwordFile := filepath.Join(dir, "mysql_password")
+	if err := os.WriteFile(passwordFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ORDERIN_MYSQL_PASSWORD_FILE", passwordFile)
+
+	if _, err := load(&configStruct{}, dir, &Options{}); err == nil {
+		t.Errorf("load() without master key should fail")
+	}
+
+	t.Setenv(EnvMasterKey, "master")
+	var c configStruct
+	if _, err := load(&c, dir, &Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if c.Secret != "jwt-secret" || c.Redis.Address[0] != "jwt-secret" {
+		t.Errorf("encrypted value not decrypted: secret=%s redis=%v", c.Secret, c.Redis.Address)
+	}
+	if c.Mysql.Password != "from-file" {
+		t.Errorf("mysql.password = %q, want from-file", c.Mysql.Password)
+	}
+
+	t.Setenv("ORDERIN_MYSQL_PASSWORD", "from-env")
+	if _, err := load(&configStruct{}, dir, &Options{}); err == nil {
+		t.Errorf("load() with both env and _FILE env should fail")
+	}
+}
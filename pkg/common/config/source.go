@@ -0,0 +1,293 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// item 一个可配置的叶子字段
+type item struct {
+	path  string // yaml 路径，如 mysql.maxOpenConn
+	env   string // 对应的环境变量，如 ORDERIN_MYSQL_MAX_OPEN_CONN
+	field reflect.StructField
+	value reflect.Value
+}
+
+// items 展开配置结构体的所有叶子字段
+func items(config *configStruct) []item {
+	var result []item
+	walk(reflect.ValueOf(config).Elem(), "", &result)
+	return result
+}
+
+func walk(v reflect.Value, prefix string, result *[]item) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		if field.Type.Kind() == reflect.Struct {
+			walk(v.Field(i), path, result)
+			continue
+		}
+		*result = append(*result, item{path: path, env: EnvName(path), field: field, value: v.Field(i)})
+	}
+}
+
+// EnvName 返回配置项对应的环境变量名，如 fileStore.accessKeySecret -> ORDERIN_FILE_STORE_ACCESS_KEY_SECRET
+func EnvName(path string) string {
+	var b strings.Builder
+	b.WriteString(EnvPrefix)
+	for i, segment := range strings.Split(path, ".") {
+		if i > 0 {
+			b.WriteByte('_')
+		}
+		runes := []rune(segment)
+		for j, r := range runes {
+			if j > 0 && unicode.IsUpper(r) &&
+				(!unicode.IsUpper(runes[j-1]) || (j+1 < len(runes) && unicode.IsLower(runes[j+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
+
+// applyDefaults 按 default 标签设置缺省值，在读取配置文件之前调用
+func applyDefaults(config *configStruct) error {
+	for _, it := range items(config) {
+		def, ok := it.field.Tag.Lookup("default")
+		if !ok {
+			continue
+		}
+		if err := setValue(it.value, def); err != nil {
+			return fmt.Errorf("invalid default value of %s: %w", it.path, err)
+		}
+	}
+	return nil
+}
+
+// applyEnv 用 ORDERIN_* 环境变量覆盖配置，ORDERIN_*_FILE 表示从挂载的文件中读取
+func applyEnv(config *configStruct) error {
+	for _, it := range items(config) {
+		if isStructSlice(it.value) {
+			if err := applyEnvElements(it); err != nil {
+				return err
+			}
+		}
+		if err := applyEnvItem(it); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyEnvItem(it item) error {
+	raw, ok := os.LookupEnv(it.env)
+	if path, fileOk := os.LookupEnv(it.env + FileEnvSuffix); fileOk {
+		if ok {
+			return fmt.Errorf("both %s and %s%s are set", it.env, it.env, FileEnvSuffix)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s from %s%s error: %w", it.path, it.env, FileEnvSuffix, err)
+		}
+		raw, ok = strings.TrimRight(string(data), "\r\n"), true
+	}
+	if !ok {
+		return nil
+	}
+	if isStructSlice(it.value) {
+		return fmt.Errorf("%s is a list of objects, set its fields with %s_<INDEX>_<FIELD> instead", it.env, it.env)
+	}
+	if err := setValue(it.value, raw); err != nil {
+		return fmt.Errorf("invalid value of %s from env %s: %w", it.path, it.env, err)
+	}
+	return nil
+}
+
+// applyEnvElements 按下标设置结构体切片元素的字段，如 ORDERIN_MYSQL_REPLICAS_0_ADDRESS
+func applyEnvElements(it item) error {
+	prefix := it.env + "_"
+	keys := map[int][]string{}
+	var indexes []int
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok || key == it.env+FileEnvSuffix {
+			continue
+		}
+		index, field, _ := strings.Cut(rest, "_")
+		n, err := strconv.Atoi(index)
+		if err != nil || n < 0 || field == "" {
+			return fmt.Errorf("invalid env %s, expected %s<INDEX>_<FIELD>", key, prefix)
+		}
+		if _, ok = keys[n]; !ok {
+			indexes = append(indexes, n)
+		}
+		keys[n] = append(keys[n], key)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		fields, err := element(it, index)
+		if err != nil {
+			return fmt.Errorf("invalid env %s: %w", keys[index][0], err)
+		}
+		known := map[string]bool{}
+		for _, field := range fields {
+			known[field.env], known[field.env+FileEnvSuffix] = true, true
+			if err = applyEnvItem(field); err != nil {
+				return err
+			}
+		}
+		for _, key := range keys[index] {
+			if !known[key] {
+				return fmt.Errorf("unknown config env %s", key)
+			}
+		}
+	}
+	return nil
+}
+
+// applyOverrides 用命令行 --set path=value 覆盖配置，path 不区分大小写
+func applyOverrides(config *configStruct, overrides []string) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	byPath := map[string]item{}
+	for _, it := range items(config) {
+		byPath[strings.ToLower(it.path)] = it
+	}
+	for _, override := range overrides {
+		path, raw, ok := strings.Cut(override, "=")
+		if !ok {
+			return fmt.Errorf("invalid config override %q, expected path=value", override)
+		}
+		path = strings.ToLower(strings.TrimSpace(path))
+		it, ok := byPath[path]
+		if !ok {
+			var err error
+			if it, err = lookupElement(byPath, path); err != nil {
+				return err
+			}
+		}
+		if isStructSlice(it.value) {
+			return fmt.Errorf("%s is a list of objects, set its fields with %s.<index>.<field> instead", it.path, it.path)
+		}
+		if err := setValue(it.value, raw); err != nil {
+			return fmt.Errorf("invalid value of %s from flag: %w", it.path, err)
+		}
+	}
+	return nil
+}
+
+// lookupElement 查找 path.<index>.<field> 形式的结构体切片元素字段，path 为小写
+func lookupElement(byPath map[string]item, path string) (item, error) {
+	for prefix, it := range byPath {
+		rest, ok := strings.CutPrefix(path, prefix+".")
+		if !ok || !isStructSlice(it.value) {
+			continue
+		}
+		index, name, _ := strings.Cut(rest, ".")
+		n, err := strconv.Atoi(index)
+		if err != nil || n < 0 || name == "" {
+			return item{}, fmt.Errorf("invalid config item %q, expected %s.<index>.<field>", path, it.path)
+		}
+		fields, err := element(it, n)
+		if err != nil {
+			return item{}, fmt.Errorf("invalid config item %q: %w", path, err)
+		}
+		for _, field := range fields {
+			if strings.ToLower(field.path) == path {
+				return field, nil
+			}
+		}
+		break
+	}
+	return item{}, fmt.Errorf("unknown config item %q", path)
+}
+
+// isStructSlice 结构体切片不能用一个字符串整体赋值，只能按下标设置元素的字段
+func isStructSlice(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Struct
+}
+
+// element 展开结构体切片第 index 个元素的叶子字段，index 等于长度时追加一个元素
+func element(it item, index int) ([]item, error) {
+	if index > it.value.Len() {
+		return nil, fmt.Errorf("index %d out of range, next index is %d", index, it.value.Len())
+	}
+	if index == it.value.Len() {
+		it.value.Set(reflect.Append(it.value, reflect.Zero(it.value.Type().Elem())))
+	}
+	var result []item
+	walk(it.value.Index(index), it.path+"."+strconv.Itoa(index), &result)
+	return result, nil
+}
+
+// setValue 把字符串解析为字段类型后赋值，切片以逗号分隔
+func setValue(v reflect.Value, raw string) error {
+	if v.Kind() == reflect.Slice {
+		parts := []string{}
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(slice.Index(i), part); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	raw = strings.TrimSpace(raw)
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
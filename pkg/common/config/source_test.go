@@ -0,0 +1,119 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnvName(t *testing.T) {
+	for path, want := range map[string]string{
+		"secret":                    "ORDERIN_SECRET",
+		"mysql.maxOpenConn":         "ORDERIN_MYSQL_MAX_OPEN_CONN",
+		"fileStore.accessKeySecret": "ORDERIN_FILE_STORE_ACCESS_KEY_SECRET",
+		"adminApi.listenIP":         "ORDERIN_ADMIN_API_LISTEN_IP",
+		"redis.db":                  "ORDERIN_REDIS_DB",
+	} {
+		if got := EnvName(path); got != want {
+			t.Errorf("EnvName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	dir := t.TempDir()
+	base := "env:\n  profiles: dev\nmysql:\n  address: [127.0.0.1]\n  database: orderin\n  maxOpenConn: 20\nsecret: base\nadminApi:\n  port: [10000]\n"
+	prod := "mysql:\n  maxOpenConn: 50\nsecret: prod\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(base), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config-prod.yaml"), []byte(prod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ORDERIN_REDIS_ADDRESS", "a:6379, b:6379")
+	t.Setenv("ORDERIN_SECRET", "env")
+
+	var c configStruct
+	_, err := load(&c, dir, &Options{Profile: "prod", Overrides: []string{"appApi.port=10001,10002"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Env.Profiles != "prod" || c.Mysql.MaxOpenConn != 50 || c.Mysql.MaxIdleConn != 5 {
+		t.Errorf("profile or default not applied: %+v", c.Mysql)
+	}
+	if c.Secret != "env" || !reflect.DeepEqual(c.Redis.Address, []string{"a:6379", "b:6379"}) {
+		t.Errorf("env not applied: secret=%s redis=%v", c.Secret, c.Redis.Address)
+	}
+	if !reflect.DeepEqual(c.AppApi.Port, []int{10001, 10002}) {
+		t.Errorf("override not applied: %v", c.AppApi.Port)
+	}
+
+	if _, err = load(&configStruct{}, dir, &Options{Profile: "staging"}); err == nil {
+		t.Errorf("load() with missing profile file should fail")
+	}
+	// 环境变量指定的 profile 可以没有文件，旧文件名 config_{profile}.yaml 仍然可用
+	t.Setenv(EnvProfile, "staging")
+	if _, err = load(&configStruct{}, dir, &Options{}); err != nil {
+		t.Errorf("load() with missing env profile file: %v", err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, "config_test.yaml"), []byte("mysql:\n  maxOpenConn: 30\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c = configStruct{}
+	if _, err = load(&c, dir, &Options{Profile: "test"}); err != nil || c.Mysql.MaxOpenConn != 30 {
+		t.Errorf("legacy profile file not applied: %v, %d", err, c.Mysql.MaxOpenConn)
+	}
+	if _, err = load(&configStruct{}, dir, &Options{Overrides: []string{"mysql.unknown=1"}}); err == nil {
+		t.Errorf("load() with unknown override should fail")
+	}
+}
+
+func TestReplicaOverrides(t *testing.T) {
+	dir := t.TempDir()
+	base := "mysql:\n  replicas:\n    - address: a:3306\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(base), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ORDERIN_MYSQL_REPLICAS_0_MAX_OPEN_CONN", "8")
+	t.Setenv("ORDERIN_MYSQL_REPLICAS_1_ADDRESS", "b:3306")
+
+	var c configStruct
+	_, err := load(&c, dir, &Options{Overrides: []string{"mysql.replicas.1.maxIdleConn=2", "mysql.replicas.2.address=c:3306"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	replicas := c.Mysql.Replicas
+	if len(replicas) != 3 || replicas[0].Address != "a:3306" || replicas[0].MaxOpenConn != 8 ||
+		replicas[1].Address != "b:3306" || replicas[1].MaxIdleConn != 2 || replicas[2].Address != "c:3306" {
+		t.Errorf("replicas not applied: %+v", replicas)
+	}
+
+	for _, override := range []string{"mysql.replicas=a:3306", "mysql.replicas.5.address=d:3306", "mysql.replicas.0.unknown=1"} {
+		if _, err = load(&configStruct{}, dir, &Options{Overrides: []string{override}}); err == nil {
+			t.Errorf("load() with override %s should fail", override)
+		}
+	}
+	t.Setenv("ORDERIN_MYSQL_REPLICAS", "a:3306")
+	if _, err = load(&configStruct{}, dir, &Options{}); err == nil || !strings.Contains(err.Error(), "list of objects") {
+		t.Errorf("load() with ORDERIN_MYSQL_REPLICAS = %v, want list of objects error", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c := &configStruct{}
+	if err := applyDefaults(c); err != nil {
+		t.Fatal(err)
+	}
+	c.FileStore.DriverType = "S3"
+	err := validate(c)
+	if err == nil {
+		t.Fatal("Validate() should fail")
+	}
+	for _, want := range []string{"secret is required", "adminApi.port is required", "fileStore.driverType must be one of"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, want it to contain %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"orderin-server/pkg/common/log"
+)
+
+// 配置文件变化后等待一段时间再加载，合并编辑器保存时的多次写入
+const reloadDebounce = 500 * time.Millisecond
+
+var (
+	current atomic.Pointer[configStruct]
+	empty   = &configStruct{}
+
+	sourceMu     sync.Mutex
+	sourceFolder string
+	sourceDir    string
+	sourceOpts   *Options
+
+	subscribersMu sync.Mutex
+	subscribers   []subscriber
+
+	watchMu sync.Mutex
+	watcher *fsnotify.Watcher
+)
+
+type subscriber struct {
+	name string
+	fn   func(old, new *Snapshot)
+}
+
+// Get 返回当前生效的配置，返回值与其他调用方共享，只读不可修改，需要修改时用 Clone 复制。
+// 热加载时生效的配置会整体替换，调用方不要长期持有返回值，每次使用时调用 Get，或通过 Subscribe 获取新配置
+func Get() *Snapshot {
+	if c := current.Load(); c != nil {
+		return c
+	}
+	return empty
+}
+
+// Clone 深拷贝配置，切片、map 和指针指向的值也会复制，修改副本不影响原配置
+func (c *configStruct) Clone() *Snapshot {
+	dup := &configStruct{}
+	copyValue(reflect.ValueOf(dup).Elem(), reflect.ValueOf(c).Elem())
+	return dup
+}
+
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		iter := src.MapRange()
+		for iter.Next() {
+			v := reflect.New(src.Type().Elem()).Elem()
+			copyValue(v, iter.Value())
+			dst.SetMapIndex(iter.Key(), v)
+		}
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.New(src.Type().Elem()))
+		copyValue(dst.Elem(), src.Elem())
+	default:
+		dst.Set(src)
+	}
+}
+
+func setSource(configFolderPath, configDir string, options *Options) {
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+	sourceFolder, sourceDir, sourceOpts = configFolderPath, configDir, options
+}
+
+// Subscribe 订阅配置变更，同名订阅会被替换。回调在配置替换后按订阅顺序同步执行，
+// 回调内应比较自己关心的部分再决定是否重建。old 和 new 只读，同 Get
+func Subscribe(name string, fn func(old, new *Snapshot)) {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+	for i, s := range subscribers {
+		if s.name == name {
+			subscribers[i].fn = fn
+			return
+		}
+	}
+	subscribers = append(subscribers, subscriber{name: name, fn: fn})
+}
+
+// Reload 重新加载配置文件，新配置校验不通过时保留旧配置并返回错误
+func Reload() error {
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+	if sourceOpts == nil {
+		return fmt.Errorf("config has not been loaded")
+	}
+	c := &configStruct{}
+	if _, err := load(c, sourceFolder, sourceOpts); err != nil {
+		return err
+	}
+	if err := validate(c); err != nil {
+		return err
+	}
+	old := current.Swap(c)
+	if old == nil || reflect.DeepEqual(old, c) {
+		return nil
+	}
+	notify(old, c)
+	return nil
+}
+
+func notify(old, new *configStruct) {
+	subscribersMu.Lock()
+	list := append([]subscriber(nil), subscribers...)
+	subscribersMu.Unlock()
+	for _, s := range list {
+		func() {
+			// 单个订阅者出错不影响其他订阅者，其持有的旧客户端继续可用
+			defer func() {
+				if r := recover(); r != nil {
+					log.ZError(context.Background(), "config subscriber panic", fmt.Errorf("%v", r), "subscriber", s.name, "stack", string(debug.Stack()))
+				}
+			}()
+			s.fn(old, new)
+		}()
+	}
+}
+
+// Watch 监听配置目录，配置文件变化时自动 Reload。监听的是目录而不是文件，
+// 以兼容编辑器先写临时文件再 rename 以及 k8s ConfigMap 通过软链接切换的更新方式
+func Watch() error {
+	watchMu.Lock()
+	defer watchMu.Unlock()
+	if watcher != nil {
+		return nil
+	}
+	sourceMu.Lock()
+	dir := sourceDir
+	sourceMu.Unlock()
+	if dir == "" {
+		return fmt.Errorf("config has not been loaded")
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = w.Add(dir); err != nil {
+		_ = w.Close()
+		return err
+	}
+	watcher = w
+	go watchLoop(w)
+	log.ZInfo(context.Background(), "watching config folder", "dir", dir)
+	return nil
+}
+
+// StopWatch 停止监听配置目录
+func StopWatch() {
+	watchMu.Lock()
+	defer watchMu.Unlock()
+	if watcher != nil {
+		_ = watcher.Close()
+		watcher = nil
+	}
+}
+
+func watchLoop(w *fsnotify.Watcher) {
+	var timer *time.Timer
+	for {
+		select {
+		case event, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			if !isConfigFileEvent(event) {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(reloadDebounce, func() {
+				if err := Reload(); err != nil {
+					log.ZError(context.Background(), "reload config failed, keep the old config", err)
+					return
+				}
+				log.ZInfo(context.Background(), "config reloaded")
+			})
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+			log.ZWarn(context.Background(), "config watcher error", err)
+		}
+	}
+}
+
+func isConfigFileEvent(event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Base(event.Name)
+	// k8s ConfigMap 更新时替换的是 ..data 软链接
+	if name == "..data" {
+		return true
+	}
+	return name == FileName || ((strings.HasPrefix(name, "config-") || strings.HasPrefix(name, "config_")) && strings.HasSuffix(name, ".yaml"))
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const watchTestConfig = "mysql:\n  address: [127.0.0.1]\n  database: orderin\nredis:\n  address: [127.0.0.1:6379]\nsecret: %s\nadminApi:\n  port: [10000]\nappApi:\n  port: [10001]\nsentinel:\n  inboundQps: %d\n"
+
+func writeWatchTestConfig(t *testing.T, dir, secret string, qps int) {
+	t.Helper()
+	content := []byte(fmt.Sprintf(watchTestConfig, secret, qps))
+	if err := os.WriteFile(filepath.Join(dir, FileName), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	writeWatchTestConfig(t, dir, "s1", 100)
+	if err := InitConfig(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	changed := make(chan float64, 1)
+	Subscribe("test", func(old, new *Snapshot) {
+		if old.Sentinel != new.Sentinel {
+			changed <- new.Sentinel.InboundQps
+		}
+	})
+
+	// 校验不通过时保留旧配置
+	writeWatchTestConfig(t, dir, "", 300)
+	if err := Reload(); err == nil {
+		t.Errorf("Reload() with invalid config should fail")
+	}
+	if Get().Secret != "s1" || Get().Sentinel.InboundQps != 100 {
+		t.Errorf("old config should stay active, got secret=%s qps=%v", Get().Secret, Get().Sentinel.InboundQps)
+	}
+
+	if err := Watch(); err != nil {
+		t.Fatal(err)
+	}
+	defer StopWatch()
+	writeWatchTestConfig(t, dir, "s2", 300)
+	select {
+	case qps := <-changed:
+		if qps != 300 || Get().Secret != "s2" {
+			t.Errorf("reloaded qps=%v secret=%s, want 300 s2", qps, Get().Secret)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("config was not reloaded after file change")
+	}
+}
+
+func TestClone(t *testing.T) {
+	dir := t.TempDir()
+	writeWatchTestConfig(t, dir, "s1", 100)
+	if err := InitConfig(dir); err != nil {
+		t.Fatal(err)
+	}
+	if Get() != Get() {
+		t.Errorf("Get() should return the active snapshot")
+	}
+	c := Get().Clone()
+	c.Secret = "changed"
+	c.Mysql.Address[0] = "changed"
+	if Get().Secret != "s1" || Get().Mysql.Address[0] != "127.0.0.1" {
+		t.Errorf("modifying the result of Clone() changed the active config")
+	}
+}
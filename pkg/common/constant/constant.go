@@ -7,6 +7,8 @@ const (
 	Token          = "token"
 	RemoteAddr     = "remoteAddr"
 	RequestId      = "X-Request-Id"
+	RespCode       = "respCode"
+	TraceId        = "traceId"
 
 	// token.
 	NormalToken  = 0
@@ -18,6 +20,8 @@ const (
 	FlagPort           = "port"
 	FlagPrometheusPort = "prometheus_port"
 	FlagConf           = "config_folder_path"
+	FlagProfile        = "profile"
+	FlagSet            = "set"
 
 	// scenes of sending verify code
 	LoginByPhone  = "LoginByPhone"
@@ -25,6 +29,12 @@ const (
 	BindEmail     = "BindEmail"
 	CheckIdentity = "CheckIdentity"
 
+	// login methods
+	LoginMethodPassword = "password"
+	LoginMethodPhone    = "phone"
+	LoginMethodWechat   = "wechat"
+	LoginMethodMFA      = "mfa"
+
 	//these operations need check identity
 	ChangeEmail     = "change_email"
 	ChangePhone     = "change_phone"
@@ -0,0 +1,43 @@
+package customtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StringMap 以 json 保存的字符串映射
+type StringMap map[string]string
+
+// 写入数据库，空时写入 NULL
+func (m StringMap) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(map[string]string(m))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// 从数据库读取
+func (m *StringMap) Scan(v interface{}) error {
+	var data []byte
+	switch value := v.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("can not convert %v to StringMap", v)
+	}
+	if len(data) == 0 {
+		*m = nil
+		return nil
+	}
+	return json.Unmarshal(data, (*map[string]string)(m))
+}
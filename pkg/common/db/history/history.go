@@ -0,0 +1,279 @@
+package history
+
+import (
+	"encoding/json"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	mcontext "orderin-server/pkg/common/context"
+	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/log"
+	"reflect"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+
+	// 单次更新/删除最多追踪的行数，超过则只记录前面的行
+	maxTrackedRows = 500
+
+	snapshotKey = "history:snapshots"
+	// 模型字段上声明 history:"-" 则不记录该字段的变更
+	tagName = "history"
+)
+
+// Tracked 需要记录变更历史的模型实现该接口
+type Tracked interface {
+	HistoryEntity() string
+}
+
+// Change 字段级别的变更
+type Change struct {
+	Field    string          `json:"field"`
+	OldValue json.RawMessage `json:"oldValue,omitempty"`
+	NewValue json.RawMessage `json:"newValue,omitempty"`
+}
+
+// Record 一条实体变更记录
+type Record struct {
+	EntityType string
+	EntityID   string
+	Action     string
+	Changes    []Change
+	OperatorID int64
+}
+
+// Writer 负责持久化变更记录，tx与触发变更的语句处于同一个连接（事务）中
+type Writer func(tx *gorm.DB, records []Record) error
+
+type plugin struct {
+	writer Writer
+}
+
+// Register 注册gorm的create/update/delete回调，对实现了 Tracked 的模型记录字段级变更
+func Register(db *gorm.DB, writer Writer) error {
+	p := &plugin{writer: writer}
+	callback := db.Callback()
+	if err := callback.Create().After("gorm:create").Register("history:after_create", p.afterCreate); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("history:before_update", p.beforeChange); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register("history:after_update", p.afterUpdate); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("history:before_delete", p.beforeChange); err != nil {
+		return err
+	}
+	return callback.Delete().After("gorm:delete").Register("history:after_delete", p.afterDelete)
+}
+
+func trackedEntity(tx *gorm.DB) (string, bool) {
+	if tx.Statement.Schema == nil || tx.Statement.Schema.PrioritizedPrimaryField == nil {
+		return "", false
+	}
+	model := reflect.New(tx.Statement.Schema.ModelType).Interface()
+	tracked, ok := model.(Tracked)
+	if !ok {
+		return "", false
+	}
+	return tracked.HistoryEntity(), true
+}
+
+func (p *plugin) afterCreate(tx *gorm.DB) {
+	entity, ok := trackedEntity(tx)
+	if !ok || tx.Error != nil {
+		return
+	}
+	var records []Record
+	eachStruct(tx.Statement.ReflectValue, func(rv reflect.Value) {
+		records = append(records, Record{
+			EntityType: entity,
+			EntityID:   primaryKey(tx, rv),
+			Action:     ActionCreate,
+			Changes:    diff(tx, reflect.Value{}, rv),
+		})
+	})
+	p.write(tx, records)
+}
+
+// beforeChange 更新或删除前查出受影响行的快照
+func (p *plugin) beforeChange(tx *gorm.DB) {
+	if _, ok := trackedEntity(tx); !ok || tx.Error != nil {
+		return
+	}
+	snapshots := p.query(tx, nil)
+	if snapshots.Len() > 0 {
+		tx.InstanceSet(snapshotKey, snapshots)
+	}
+}
+
+func (p *plugin) afterUpdate(tx *gorm.DB) {
+	entity, ok := trackedEntity(tx)
+	if !ok || tx.Error != nil {
+		return
+	}
+	old, ok := snapshotsOf(tx)
+	if !ok {
+		return
+	}
+	ids := make([]interface{}, 0, old.Len())
+	oldById := make(map[string]reflect.Value, old.Len())
+	for i := 0; i < old.Len(); i++ {
+		id, _ := tx.Statement.Schema.PrioritizedPrimaryField.ValueOf(tx.Statement.Context, old.Index(i))
+		ids = append(ids, id)
+		oldById[primaryKey(tx, old.Index(i))] = old.Index(i)
+	}
+	current := p.query(tx, ids)
+	var records []Record
+	for i := 0; i < current.Len(); i++ {
+		id := primaryKey(tx, current.Index(i))
+		changes := diff(tx, oldById[id], current.Index(i))
+		if len(changes) == 0 {
+			continue
+		}
+		records = append(records, Record{EntityType: entity, EntityID: id, Action: ActionUpdate, Changes: changes})
+	}
+	p.write(tx, records)
+}
+
+func (p *plugin) afterDelete(tx *gorm.DB) {
+	entity, ok := trackedEntity(tx)
+	if !ok || tx.Error != nil {
+		return
+	}
+	old, ok := snapshotsOf(tx)
+	if !ok {
+		return
+	}
+	records := make([]Record, 0, old.Len())
+	for i := 0; i < old.Len(); i++ {
+		records = append(records, Record{
+			EntityType: entity,
+			EntityID:   primaryKey(tx, old.Index(i)),
+			Action:     ActionDelete,
+			Changes:    diff(tx, old.Index(i), reflect.Value{}),
+		})
+	}
+	p.write(tx, records)
+}
+
+// query ids为空时按当前语句的条件（主键或where）查询，否则按主键查询
+func (p *plugin) query(tx *gorm.DB, ids []interface{}) reflect.Value {
+	stmt := tx.Statement
+	list := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	// 快照在主库上查询，从库延迟时会把旧值当作变更前的值。Primary 会复制当前语句，必须在 NewDB 之前调用
+	session := relation.Primary(tx).Session(&gorm.Session{NewDB: true, SkipHooks: true}).Table(stmt.Table)
+	pk := stmt.Schema.PrioritizedPrimaryField
+	if ids != nil {
+		session = session.Where(clause.IN{Column: clause.Column{Name: pk.DBName}, Values: ids})
+	} else {
+		conditions := 0
+		if c, ok := stmt.Clauses["WHERE"]; ok {
+			if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+				session = session.Clauses(where)
+				conditions++
+			}
+		}
+		eachStruct(stmt.ReflectValue, func(rv reflect.Value) {
+			if id, zero := pk.ValueOf(stmt.Context, rv); !zero {
+				session = session.Where(clause.Eq{Column: clause.Column{Name: pk.DBName}, Value: id})
+				conditions++
+			}
+		})
+		if conditions == 0 {
+			return list.Elem()
+		}
+	}
+	if err := session.Limit(maxTrackedRows).Find(list.Interface()).Error; err != nil {
+		log.ZWarn(stmt.Context, "query entity history snapshot fail", err, "table", stmt.Table)
+	}
+	return list.Elem()
+}
+
+func (p *plugin) write(tx *gorm.DB, records []Record) {
+	if len(records) == 0 {
+		return
+	}
+	operatorId := mcontext.GetOpUserID(tx.Statement.Context)
+	for i := range records {
+		records[i].OperatorID = operatorId
+	}
+	if err := p.writer(tx.Session(&gorm.Session{NewDB: true}), records); err != nil {
+		log.ZError(tx.Statement.Context, "write entity history fail", err, "table", tx.Statement.Table)
+	}
+}
+
+func snapshotsOf(tx *gorm.DB) (reflect.Value, bool) {
+	v, ok := tx.InstanceGet(snapshotKey)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return v.(reflect.Value), true
+}
+
+func eachStruct(rv reflect.Value, fn func(rv reflect.Value)) {
+	rv = reflect.Indirect(rv)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			item := reflect.Indirect(rv.Index(i))
+			if item.Kind() == reflect.Struct {
+				fn(item)
+			}
+		}
+	case reflect.Struct:
+		fn(rv)
+	}
+}
+
+func primaryKey(tx *gorm.DB, rv reflect.Value) string {
+	id, _ := tx.Statement.Schema.PrioritizedPrimaryField.ValueOf(tx.Statement.Context, rv)
+	data, _ := json.Marshal(id)
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return s
+	}
+	return string(data)
+}
+
+// diff 对比两个快照的字段，old或current为零值时分别对应新增和删除
+func diff(tx *gorm.DB, old reflect.Value, current reflect.Value) []Change {
+	var changes []Change
+	for _, field := range tx.Statement.Schema.Fields {
+		if field.DBName == "" || field.PrimaryKey || field.Tag.Get(tagName) == "-" || isAutoTime(field) {
+			continue
+		}
+		change := Change{Field: field.DBName}
+		if old.IsValid() {
+			change.OldValue = fieldValue(tx, field, old)
+		}
+		if current.IsValid() {
+			change.NewValue = fieldValue(tx, field, current)
+		}
+		if string(change.OldValue) == string(change.NewValue) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func fieldValue(tx *gorm.DB, field *schema.Field, rv reflect.Value) json.RawMessage {
+	value, zero := field.ValueOf(tx.Statement.Context, rv)
+	if zero {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func isAutoTime(field *schema.Field) bool {
+	return field.AutoCreateTime > 0 || field.AutoUpdateTime > 0
+}
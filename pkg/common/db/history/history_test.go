@@ -0,0 +1,153 @@
+package history
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"orderin-server/pkg/common/constant"
+)
+
+type historyTestItem struct {
+	ID        int64 `gorm:"primaryKey"`
+	Name      string
+	Price     int
+	Secret    string `history:"-"`
+	UpdatedAt time.Time
+}
+
+func (historyTestItem) HistoryEntity() string {
+	return "item"
+}
+
+type historyTestUntracked struct {
+	ID   int64 `gorm:"primaryKey"`
+	Name string
+}
+
+func openHistoryDB(t *testing.T) (*gorm.DB, *[]Record) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/test.db"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&historyTestItem{}, &historyTestUntracked{}); err != nil {
+		t.Fatal(err)
+	}
+	var records []Record
+	err = Register(db, func(tx *gorm.DB, batch []Record) error {
+		records = append(records, batch...)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(context.Background(), constant.OpUserID, int64(9))
+	return db.WithContext(ctx), &records
+}
+
+func changeFields(changes []Change) map[string][2]string {
+	result := map[string][2]string{}
+	for _, c := range changes {
+		result[c.Field] = [2]string{string(c.OldValue), string(c.NewValue)}
+	}
+	return result
+}
+
+func TestCreate(t *testing.T) {
+	db, records := openHistoryDB(t)
+	if err := db.Create(&[]historyTestItem{{ID: 1, Name: "apple", Price: 3, Secret: "s"}, {ID: 2, Name: "pear"}}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(*records) != 2 {
+		t.Fatalf("records = %+v, want 2", *records)
+	}
+	r := (*records)[0]
+	if r.EntityType != "item" || r.EntityID != "1" || r.Action != ActionCreate || r.OperatorID != 9 {
+		t.Errorf("record = %+v", r)
+	}
+	want := map[string][2]string{"name": {"", `"apple"`}, "price": {"", "3"}}
+	if got := changeFields(r.Changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+	if got := changeFields((*records)[1].Changes); !reflect.DeepEqual(got, map[string][2]string{"name": {"", `"pear"`}}) {
+		t.Errorf("zero fields should be skipped on create: %v", got)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	db, records := openHistoryDB(t)
+	if err := db.Create(&[]historyTestItem{{ID: 1, Name: "apple", Price: 3}, {ID: 2, Name: "pear", Price: 5}}).Error; err != nil {
+		t.Fatal(err)
+	}
+	*records = nil
+
+	if err := db.Model(&historyTestItem{ID: 1}).Updates(map[string]interface{}{"price": 4, "secret": "s"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(*records) != 1 || (*records)[0].EntityID != "1" || (*records)[0].Action != ActionUpdate {
+		t.Fatalf("records = %+v, want one update of id 1", *records)
+	}
+	if got, want := changeFields((*records)[0].Changes), map[string][2]string{"price": {"3", "4"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+
+	// 按条件批量更新只记录实际变化的行，值没有变化的行不记录
+	*records = nil
+	if err := db.Model(&historyTestItem{}).Where("id IN ?", []int64{1, 2}).Update("name", "pear").Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(*records) != 1 || (*records)[0].EntityID != "1" {
+		t.Fatalf("records = %+v, want only id 1", *records)
+	}
+	if got, want := changeFields((*records)[0].Changes), map[string][2]string{"name": {`"apple"`, `"pear"`}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+
+	// 只修改了不记录的字段时没有变更记录
+	*records = nil
+	if err := db.Model(&historyTestItem{ID: 2}).Update("secret", "t").Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(*records) != 0 {
+		t.Errorf("records = %+v, want none", *records)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	db, records := openHistoryDB(t)
+	if err := db.Create(&historyTestItem{ID: 1, Name: "apple", Price: 3, Secret: "s"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	*records = nil
+
+	if err := db.Delete(&historyTestItem{}, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(*records) != 1 || (*records)[0].EntityID != "1" || (*records)[0].Action != ActionDelete {
+		t.Fatalf("records = %+v, want one delete of id 1", *records)
+	}
+	want := map[string][2]string{"name": {`"apple"`, ""}, "price": {"3", ""}}
+	if got := changeFields((*records)[0].Changes); !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+}
+
+func TestUntracked(t *testing.T) {
+	db, records := openHistoryDB(t)
+	if err := db.Create(&historyTestUntracked{ID: 1, Name: "apple"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&historyTestUntracked{ID: 1}).Update("name", "pear").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(&historyTestUntracked{}, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(*records) != 0 {
+		t.Errorf("records = %+v, want none for untracked model", *records)
+	}
+}
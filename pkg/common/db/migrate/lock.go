@@ -0,0 +1,68 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/log"
+)
+
+const (
+	lockName = "orderin_schema_migrations"
+	// lockTimeout 等待其他实例迁移完成的最长时间
+	lockTimeout = 10 * time.Minute
+)
+
+// withLock 持有迁移锁执行 fn。mysql 使用 GET_LOCK，postgres 使用 advisory lock，都是会话级的锁，
+// 在单独的连接上加锁；sqlite 一般只有一个进程，不加锁
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	driver := relation.DriverOf(m.db)
+	if driver == relation.Sqlite {
+		return fn()
+	}
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	var unlock string
+	var key interface{}
+	switch driver {
+	case relation.Postgres:
+		h := fnv.New64a()
+		h.Write([]byte(lockName))
+		key = int64(h.Sum64())
+		if _, err = conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", key); err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		unlock = "SELECT pg_advisory_unlock($1)"
+	default:
+		key = lockName
+		var locked sql.NullInt64
+		err = conn.QueryRowContext(lockCtx, "SELECT GET_LOCK(?, ?)", key, int(lockTimeout.Seconds())).Scan(&locked)
+		if err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		if locked.Int64 != 1 {
+			return fmt.Errorf("acquire migration lock: timeout after %s", lockTimeout)
+		}
+		unlock = "SELECT RELEASE_LOCK(?)"
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), unlock, key); err != nil {
+			log.ZWarn(ctx, "release migration lock fail", err)
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,190 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/log"
+)
+
+// Migration 一个版本的迁移，可以是 go 函数或由 SQL 文件加载
+type Migration struct {
+	Version string // 版本号，按字典序执行，一般为创建时间 20060102150405
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error // 为空时不能回滚
+}
+
+// Status 迁移的执行状态，AppliedAt 为空表示未执行；Missing 表示已执行但代码中没有该版本
+type Status struct {
+	Version   string
+	Name      string
+	AppliedAt *time.Time
+	Missing   bool
+}
+
+// schemaMigration 已执行的迁移记录
+type schemaMigration struct {
+	Version   string `gorm:"primaryKey;size:32"`
+	Name      string `gorm:"size:255"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator 按版本执行迁移，执行和回滚时加数据库锁，多个实例同时启动时只有一个在迁移
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New 创建 Migrator，所有语句都在主库上执行
+func New(db *gorm.DB, migrations []Migration) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for i, m := range sorted {
+		if m.Version == "" || m.Up == nil {
+			return nil, fmt.Errorf("migration %s_%s: version and up are required", m.Version, m.Name)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version %s", m.Version)
+		}
+	}
+	return &Migrator{db: relation.Primary(db), migrations: sorted}, nil
+}
+
+func (m *Migrator) applied() (map[string]schemaMigration, error) {
+	if err := m.db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+	var records []schemaMigration
+	if err := m.db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[string]schemaMigration, len(records))
+	for _, r := range records {
+		result[r.Version] = r
+	}
+	return result, nil
+}
+
+// Status 返回所有迁移的状态，按版本排序
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		s := Status{Version: migration.Version, Name: migration.Name}
+		if r, ok := applied[migration.Version]; ok {
+			appliedAt := r.AppliedAt
+			s.AppliedAt = &appliedAt
+			delete(applied, migration.Version)
+		}
+		result = append(result, s)
+	}
+	for _, r := range applied {
+		appliedAt := r.AppliedAt
+		result = append(result, Status{Version: r.Version, Name: r.Name, AppliedAt: &appliedAt, Missing: true})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+// Pending 返回未执行的迁移
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up 按版本顺序执行未执行的迁移，steps 为 0 时全部执行，返回执行了的迁移
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		pending, err := m.Pending()
+		if err != nil {
+			return err
+		}
+		if steps > 0 && steps < len(pending) {
+			pending = pending[:steps]
+		}
+		for _, migration := range pending {
+			migration := migration
+			err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := migration.Up(tx); err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migrate up %s_%s: %w", migration.Version, migration.Name, err)
+			}
+			log.ZInfo(ctx, "migration applied", "version", migration.Version, "name", migration.Name)
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down 按版本倒序回滚最近执行的 steps 个迁移，返回回滚了的迁移
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		applied, err := m.applied()
+		if err != nil {
+			return err
+		}
+		byVersion := make(map[string]Migration, len(m.migrations))
+		for _, migration := range m.migrations {
+			byVersion[migration.Version] = migration
+		}
+		versions := make([]string, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+		if steps < len(versions) {
+			versions = versions[:steps]
+		}
+		for _, version := range versions {
+			migration, ok := byVersion[version]
+			if !ok {
+				return fmt.Errorf("migration %s is applied but not found", version)
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %s_%s cannot be rolled back", migration.Version, migration.Name)
+			}
+			err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := migration.Down(tx); err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{Version: migration.Version}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migrate down %s_%s: %w", migration.Version, migration.Name, err)
+			}
+			log.ZInfo(ctx, "migration rolled back", "version", migration.Version, "name", migration.Name)
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- 注释; 不拆分
+CREATE TABLE t (name varchar(10) DEFAULT 'a;b');
+/* 块注释; */ INSERT INTO t VALUES ("x;y");
+UPDATE t SET name = 'c'`
+	want := []string{
+		"CREATE TABLE t (name varchar(10) DEFAULT 'a;b')",
+		`INSERT INTO t VALUES ("x;y")`,
+		"UPDATE t SET name = 'c'",
+	}
+	if got := SplitStatements(script); !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitStatements = %q, want %q", got, want)
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/test.db"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsys := fstest.MapFS{
+		"sql/001_create_items.up.sql":         {Data: []byte("CREATE TABLE items (id integer PRIMARY KEY, name text);\nINSERT INTO items VALUES (1, 'a');")},
+		"sql/001_create_items.down.sql":       {Data: []byte("DROP TABLE items;")},
+		"sql/002_add_price.up.sql":            {Data: []byte("ALTER TABLE items ADD COLUMN price int NOT NULL DEFAULT 0;")},
+		"sql/002_add_price.up.postgres.sql":   {Data: []byte("ALTER TABLE items ADD COLUMN price integer;")},
+		"sql/002_add_price.down.sqlite.sql":   {Data: []byte("ALTER TABLE items DROP COLUMN price;")},
+		"sql/README.md":                       {Data: []byte("ignored")},
+		"sql/003_rename_name.up.mysql.sql":    {Data: []byte("ALTER TABLE items RENAME COLUMN name TO title;")},
+		"sql/003_rename_name.up.sqlite.sql":   {Data: []byte("ALTER TABLE items RENAME COLUMN name TO title;")},
+		"sql/003_rename_name.down.sqlite.sql": {Data: []byte("ALTER TABLE items RENAME COLUMN title TO name;")},
+	}
+	migrations, err := LoadSQL(fsys, "sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrations = append(migrations, Migration{
+		Version: "004",
+		Name:    "backfill_price",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("UPDATE items SET price = 10").Error
+		},
+	})
+	m, err := New(db, migrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	done, err := m.Up(ctx, 2)
+	if err != nil || len(done) != 2 {
+		t.Fatalf("up 2 steps = %v, %v", done, err)
+	}
+	if done, err = m.Up(ctx, 0); err != nil || len(done) != 2 {
+		t.Fatalf("up all = %v, %v", done, err)
+	}
+	var item struct {
+		Title string
+		Price int
+	}
+	db.Raw("SELECT title, price FROM items WHERE id = 1").Scan(&item)
+	if item.Title != "a" || item.Price != 10 {
+		t.Errorf("item = %+v", item)
+	}
+	if done, err = m.Up(ctx, 0); err != nil || len(done) != 0 {
+		t.Errorf("up again = %v, %v", done, err)
+	}
+
+	// 004 没有 down，不能回滚
+	if _, err = m.Down(ctx, 1); err == nil {
+		t.Errorf("down of migration without down should fail")
+	}
+	m.migrations[3].Down = func(tx *gorm.DB) error { return nil }
+	if done, err = m.Down(ctx, 2); err != nil || len(done) != 2 || done[0].Version != "004" || done[1].Version != "003" {
+		t.Fatalf("down 2 steps = %v, %v", done, err)
+	}
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied := make([]bool, 0, len(statuses))
+	for _, s := range statuses {
+		applied = append(applied, s.AppliedAt != nil)
+	}
+	if want := []bool{true, true, false, false}; !reflect.DeepEqual(applied, want) {
+		t.Errorf("applied = %v, want %v", applied, want)
+	}
+
+	// 代码中删除了已执行的迁移
+	m2, _ := New(db, migrations[:1])
+	statuses, _ = m2.Status()
+	if len(statuses) != 2 || !statuses[1].Missing {
+		t.Errorf("statuses = %+v, want 002 missing", statuses)
+	}
+
+	if _, err = New(db, append(migrations, Migration{Version: "001", Up: migrations[0].Up})); err == nil {
+		t.Errorf("duplicate version should be rejected")
+	}
+}
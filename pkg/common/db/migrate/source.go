@@ -0,0 +1,132 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"orderin-server/pkg/common/db/relation"
+)
+
+// sqlFileRegexp 匹配 <版本>_<名称>.<up|down>[.<driver>].sql，带 driver 的文件只在该数据库上使用，优先于通用文件
+var sqlFileRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)(?:\.(mysql|postgres|sqlite))?\.sql$`)
+
+type sqlFiles struct {
+	up   map[string]string // driver -> 文件内容，通用文件的 driver 为空
+	down map[string]string
+}
+
+// LoadSQL 加载目录 dir 中的 SQL 迁移文件
+func LoadSQL(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]*sqlFiles{}
+	names := map[string]string{}
+	var versions []string
+	for _, entry := range entries {
+		m := sqlFileRegexp.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || m == nil {
+			continue
+		}
+		version, name, direction, driver := m[1], m[2], m[3], m[4]
+		if n, ok := names[version]; ok && n != name {
+			return nil, fmt.Errorf("duplicate migration version %s: %s and %s", version, n, name)
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		f, ok := files[version]
+		if !ok {
+			f = &sqlFiles{up: map[string]string{}, down: map[string]string{}}
+			files[version] = f
+			names[version] = name
+			versions = append(versions, version)
+		}
+		if direction == "up" {
+			f.up[driver] = string(data)
+		} else {
+			f.down[driver] = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(versions))
+	for _, version := range versions {
+		f := files[version]
+		if len(f.up) == 0 {
+			return nil, fmt.Errorf("migration %s_%s has no up file", version, names[version])
+		}
+		migration := Migration{Version: version, Name: names[version], Up: sqlRunner(f.up)}
+		if len(f.down) > 0 {
+			migration.Down = sqlRunner(f.down)
+		}
+		migrations = append(migrations, migration)
+	}
+	return migrations, nil
+}
+
+// sqlRunner 按当前数据库选择文件，逐条执行其中的语句
+func sqlRunner(scripts map[string]string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		driver := relation.DriverOf(tx)
+		script, ok := scripts[driver]
+		if !ok {
+			if script, ok = scripts[""]; !ok {
+				return fmt.Errorf("no sql file for %s", driver)
+			}
+		}
+		for _, stmt := range SplitStatements(script) {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// SplitStatements 按分号拆分 SQL 脚本，忽略引号内的分号和注释，不支持 postgres 的 $$ 函数体
+func SplitStatements(script string) []string {
+	var stmts []string
+	var b strings.Builder
+	var quote rune
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+			continue
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i < len(runes) && !(runes[i-1] == '*' && runes[i] == '/') {
+				i++
+			}
+			b.WriteRune(' ')
+			continue
+		case r == ';':
+			if stmt := strings.TrimSpace(b.String()); stmt != "" {
+				stmts = append(stmts, stmt)
+			}
+			b.Reset()
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if stmt := strings.TrimSpace(b.String()); stmt != "" {
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
@@ -0,0 +1,60 @@
+package relation
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	mcontext "orderin-server/pkg/common/context"
+)
+
+const (
+	createdByField = "CreatedBy"
+	updatedByField = "UpdatedBy"
+)
+
+// useAudit 注册审计字段回调：新增时填充 CreatedBy，修改时填充 UpdatedBy，操作人取自上下文中的 OpUserID。
+// 上下文中没有操作人时不处理，新增时已赋值的 CreatedBy 不覆盖，和 UpdatedAt 一样 UpdateColumn 不更新 UpdatedBy
+func useAudit(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("gorm:create").Register("audit:created_by", fillCreatedBy); err != nil {
+		return err
+	}
+	return callback.Update().Before("gorm:update").Register("audit:updated_by", fillUpdatedBy)
+}
+
+func fillCreatedBy(tx *gorm.DB) {
+	if tx.Error != nil || tx.Statement.Schema == nil {
+		return
+	}
+	field := tx.Statement.Schema.LookUpField(createdByField)
+	userID := mcontext.GetOpUserID(tx.Statement.Context)
+	if field == nil || userID == 0 {
+		return
+	}
+	ctx := tx.Statement.Context
+	set := func(rv reflect.Value) {
+		if _, zero := field.ValueOf(ctx, rv); zero {
+			_ = field.Set(ctx, rv, &userID)
+		}
+	}
+	rv := reflect.Indirect(tx.Statement.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if item := reflect.Indirect(rv.Index(i)); item.Kind() == reflect.Struct {
+				set(item)
+			}
+		}
+	case reflect.Struct:
+		set(rv)
+	}
+}
+
+func fillUpdatedBy(tx *gorm.DB) {
+	if tx.Error != nil || tx.Statement.SkipHooks || tx.Statement.Schema == nil || tx.Statement.Schema.LookUpField(updatedByField) == nil {
+		return
+	}
+	if userID := mcontext.GetOpUserID(tx.Statement.Context); userID != 0 {
+		tx.Statement.SetColumn(updatedByField, &userID, true)
+	}
+}
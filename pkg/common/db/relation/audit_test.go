@@ -0,0 +1,50 @@
+package relation
+
+import (
+	"context"
+	"testing"
+
+	"orderin-server/pkg/common/constant"
+)
+
+type auditTestItem struct {
+	ID        int64 `gorm:"primaryKey"`
+	Name      string
+	CreatedBy *int64
+	UpdatedBy *int64
+}
+
+func TestAudit(t *testing.T) {
+	db, err := newSqliteGormDB(&option{Database: t.TempDir() + "/test.db", MaxOpenConn: 1, LogLevel: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&auditTestItem{}); err != nil {
+		t.Fatal(err)
+	}
+	other := int64(9)
+	ctx := context.WithValue(context.Background(), constant.OpUserID, int64(7))
+	items := []auditTestItem{{ID: 1, Name: "a"}, {ID: 2, Name: "b", CreatedBy: &other}}
+	if err = db.WithContext(ctx).Create(&items).Error; err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&auditTestItem{ID: 3, Name: "c"})
+	db.WithContext(ctx).Model(&auditTestItem{ID: 1}).Updates(&auditTestItem{Name: "a1"})
+	db.WithContext(ctx).Model(&auditTestItem{}).Where("id = ?", 2).Update("name", "b1")
+	db.WithContext(ctx).Model(&auditTestItem{ID: 3}).UpdateColumn("name", "c1")
+
+	var got []auditTestItem
+	db.Order("id").Find(&got)
+	by := func(p *int64) int64 {
+		if p == nil {
+			return 0
+		}
+		return *p
+	}
+	want := [][2]int64{{7, 7}, {9, 7}, {0, 0}}
+	for i, item := range got {
+		if by(item.CreatedBy) != want[i][0] || by(item.UpdatedBy) != want[i][1] {
+			t.Errorf("item %d: createdBy = %d, updatedBy = %d, want %v", item.ID, by(item.CreatedBy), by(item.UpdatedBy), want[i])
+		}
+	}
+}
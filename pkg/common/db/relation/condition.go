@@ -1,6 +1,11 @@
 package relation
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
+
+var collationRegexp = regexp.MustCompile(`^\w+$`)
 
 type Condition interface {
 	SetWhere(k string, v []interface{})
@@ -15,9 +20,15 @@ type GormCondition struct {
 }
 
 type GormPublic struct {
-	Where map[string][]interface{}
+	Where []GormWhere
 	Order []string
-	Or    map[string][]interface{}
+	Or    []GormWhere
+}
+
+// GormWhere 一个查询条件，按添加的顺序生成 sql
+type GormWhere struct {
+	Query string
+	Args  []interface{}
 }
 
 type GormJoin struct {
@@ -31,17 +42,11 @@ func (e *GormJoin) SetJoinOn(t, on string) Condition {
 }
 
 func (e *GormPublic) SetWhere(k string, v []interface{}) {
-	if e.Where == nil {
-		e.Where = make(map[string][]interface{})
-	}
-	e.Where[k] = v
+	e.Where = append(e.Where, GormWhere{Query: k, Args: v})
 }
 
 func (e *GormPublic) SetOr(k string, v []interface{}) {
-	if e.Or == nil {
-		e.Or = make(map[string][]interface{})
-	}
-	e.Or[k] = v
+	e.Or = append(e.Or, GormWhere{Query: k, Args: v})
 }
 
 func (e *GormPublic) SetOrder(k string) {
@@ -51,6 +56,20 @@ func (e *GormPublic) SetOrder(k string) {
 	e.Order = append(e.Order, k)
 }
 
+// joinWhere 用 sep 连接所有条件，每个条件加括号，没有条件时返回空
+func (e *GormPublic) joinWhere(sep string) (string, []interface{}) {
+	if len(e.Where) == 1 {
+		return e.Where[0].Query, e.Where[0].Args
+	}
+	parts := make([]string, 0, len(e.Where))
+	args := make([]interface{}, 0)
+	for _, w := range e.Where {
+		parts = append(parts, "("+w.Query+")")
+		args = append(args, w.Args...)
+	}
+	return strings.Join(parts, sep), args
+}
+
 func (e *GormCondition) SetJoinOn(t, on string) Condition {
 	if e.Join == nil {
 		e.Join = make([]*GormJoin, 0)
@@ -65,11 +84,15 @@ func (e *GormCondition) SetJoinOn(t, on string) Condition {
 }
 
 type resolveSearchTag struct {
-	Type   string
-	Column string
-	Table  string
-	On     []string
-	Join   string
+	Type    string
+	Column  string
+	Table   string
+	On      []string
+	Join    string
+	Path    string // json 路径，如 $.tags
+	Op      string // json_extract 的比较方式：eq、ne、gt、gte、lt、lte，默认 eq
+	Mode    string // match 的检索模式，boolean 为布尔模式，默认自然语言模式
+	Collate string // mysql 比较字符串时使用的排序规则，如 utf8mb4_bin 区分大小写
 }
 
 // makeTag 解析search的tag标签
@@ -103,6 +126,22 @@ func makeTag(tag string) *resolveSearchTag {
 			if len(ts) > 1 {
 				r.Join = ts[1]
 			}
+		case "path":
+			if len(ts) > 1 {
+				r.Path = ts[1]
+			}
+		case "op":
+			if len(ts) > 1 {
+				r.Op = ts[1]
+			}
+		case "mode":
+			if len(ts) > 1 {
+				r.Mode = ts[1]
+			}
+		case "collate":
+			if len(ts) > 1 && collationRegexp.MatchString(ts[1]) {
+				r.Collate = ts[1]
+			}
 		}
 	}
 	return r
@@ -0,0 +1,342 @@
+package relation
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"orderin-server/pkg/common/errs"
+)
+
+const (
+	// CountExact 游标分页时精确统计总数
+	CountExact = "exact"
+	// CountEstimate 游标分页时通过 EXPLAIN 估算总数
+	CountEstimate = "estimate"
+
+	// cursorKeyColumn 唯一的排序列，排序条件中没有时追加在最后，保证顺序稳定
+	cursorKeyColumn = "id"
+)
+
+type orderKey struct {
+	Table  string
+	Column string
+	Desc   bool
+}
+
+func (k orderKey) expr(driver string) string {
+	return Quote(driver, k.Table, k.Column)
+}
+
+// Cursor 游标（keyset）分页。排序取自查询条件中 type:order 的字段，不再使用 OFFSET，
+// 游标中保存上一页最后一行的排序键，排序列不能为 NULL
+type Cursor struct {
+	keys   []orderKey
+	values []interface{} // 为空表示第一页
+	size   int
+	next   string
+}
+
+type cursorPayload struct {
+	Order  string        `json:"o"` // 排序条件，与当前请求不一致时游标无效
+	Values []cursorValue `json:"v"`
+}
+
+type cursorValue struct {
+	Time  *time.Time  `json:"t,omitempty"`
+	Value interface{} `json:"v"`
+}
+
+// NewCursor 由查询条件 q 的 order 标签及上一页返回的游标创建，cursor 为空时查询第一页
+func NewCursor(q interface{}, cursor string, pageSize int) (*Cursor, error) {
+	c := &Cursor{keys: resolveOrderKeys(q), size: pageSize}
+	if cursor == "" {
+		return c, nil
+	}
+	values, err := c.decode(cursor)
+	if err != nil {
+		return nil, errs.NewCodeError(errs.ArgsError, "游标无效")
+	}
+	c.values = values
+	return c, nil
+}
+
+// resolveOrderKeys 解析 order 标签，没有 id 时按最后一个排序列的方向追加 id
+func resolveOrderKeys(q interface{}) []orderKey {
+	var keys []orderKey
+	table := ""
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		vType := v.Type()
+		for i := 0; i < vType.NumField(); i++ {
+			tag, ok := vType.Field(i).Tag.Lookup(FromQueryTag)
+			if !ok {
+				if v.Field(i).Kind() == reflect.Struct {
+					walk(v.Field(i))
+				}
+				continue
+			}
+			t := makeTag(tag)
+			if t.Type != "order" {
+				continue
+			}
+			if table == "" {
+				table = t.Table
+			}
+			switch strings.ToLower(v.Field(i).String()) {
+			case "desc":
+				keys = append(keys, orderKey{Table: t.Table, Column: t.Column, Desc: true})
+			case "asc":
+				keys = append(keys, orderKey{Table: t.Table, Column: t.Column})
+			}
+		}
+	}
+	walk(reflect.Indirect(reflect.ValueOf(q)))
+
+	desc := true
+	for _, key := range keys {
+		if key.Column == cursorKeyColumn {
+			return keys
+		}
+		desc = key.Desc
+	}
+	return append(keys, orderKey{Table: table, Column: cursorKeyColumn, Desc: desc})
+}
+
+func (c *Cursor) orderSignature() string {
+	parts := make([]string, 0, len(c.keys))
+	for _, key := range c.keys {
+		direction := "asc"
+		if key.Desc {
+			direction = "desc"
+		}
+		parts = append(parts, key.Table+"."+key.Column+" "+direction)
+	}
+	return strings.Join(parts, ",")
+}
+
+// where 返回排在游标之后的行的条件，如 (a < ?) OR (a = ? AND id < ?)
+func (c *Cursor) where(driver string) (string, []interface{}) {
+	clauses := make([]string, 0, len(c.keys))
+	args := make([]interface{}, 0)
+	for i, key := range c.keys {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, c.keys[j].expr(driver)+" = ?")
+			args = append(args, c.values[j])
+		}
+		op := " > ?"
+		if key.Desc {
+			op = " < ?"
+		}
+		parts = append(parts, key.expr(driver)+op)
+		args = append(args, c.values[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return strings.Join(clauses, " OR "), args
+}
+
+// Scope 按游标过滤并排序，多查一行用于判断是否还有下一页。会替换 MakeCondition 添加的排序
+func (c *Cursor) Scope() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		driver := DriverOf(db)
+		if len(c.values) > 0 {
+			query, args := c.where(driver)
+			db = db.Where(query, args...)
+		}
+		columns := make([]clause.OrderByColumn, 0, len(c.keys))
+		for i, key := range c.keys {
+			columns = append(columns, clause.OrderByColumn{
+				Column:  clause.Column{Name: key.expr(driver), Raw: true},
+				Desc:    key.Desc,
+				Reorder: i == 0,
+			})
+		}
+		return db.Clauses(clause.OrderBy{Columns: columns}).Limit(c.size + 1)
+	}
+}
+
+// Find 查询一页数据到 list（切片指针），并由最后一行生成下一页的游标
+func (c *Cursor) Find(db *gorm.DB, list interface{}) error {
+	tx := db.Scopes(c.Scope()).Find(list)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	rv := reflect.ValueOf(list).Elem()
+	c.next = ""
+	if rv.Len() <= c.size {
+		return nil
+	}
+	rv.Set(rv.Slice(0, c.size))
+	last := rv.Index(c.size - 1)
+	if last.Kind() == reflect.Ptr {
+		last = last.Elem()
+	}
+	values := make([]interface{}, 0, len(c.keys))
+	for _, key := range c.keys {
+		field := tx.Statement.Schema.LookUpField(key.Column)
+		if field == nil {
+			return fmt.Errorf("cursor column %s not found in %s", key.Column, tx.Statement.Schema.Name)
+		}
+		value, _ := field.ValueOf(tx.Statement.Context, last)
+		values = append(values, value)
+	}
+	next, err := c.encode(values)
+	if err != nil {
+		return err
+	}
+	c.next = next
+	return nil
+}
+
+// Next 下一页的游标，为空表示没有下一页
+func (c *Cursor) Next() string {
+	return c.next
+}
+
+func (c *Cursor) encode(values []interface{}) (string, error) {
+	payload := cursorPayload{Order: c.orderSignature(), Values: make([]cursorValue, 0, len(values))}
+	for _, value := range values {
+		if valuer, ok := value.(driver.Valuer); ok {
+			v, err := valuer.Value()
+			if err != nil {
+				return "", err
+			}
+			value = v
+		}
+		if t, ok := value.(time.Time); ok {
+			payload.Values = append(payload.Values, cursorValue{Time: &t})
+		} else {
+			payload.Values = append(payload.Values, cursorValue{Value: value})
+		}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func (c *Cursor) decode(cursor string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var payload cursorPayload
+	if err = decoder.Decode(&payload); err != nil {
+		return nil, err
+	}
+	if payload.Order != c.orderSignature() || len(payload.Values) != len(c.keys) {
+		return nil, fmt.Errorf("cursor order mismatch")
+	}
+	values := make([]interface{}, 0, len(payload.Values))
+	for _, v := range payload.Values {
+		if v.Time != nil {
+			values = append(values, *v.Time)
+			continue
+		}
+		switch value := v.Value.(type) {
+		case nil:
+			return nil, fmt.Errorf("cursor value is null")
+		case json.Number:
+			if n, err := value.Int64(); err == nil {
+				values = append(values, n)
+			} else if f, err := value.Float64(); err == nil {
+				values = append(values, f)
+			} else {
+				return nil, err
+			}
+		default:
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// CountRows 按 mode 统计总数：为空不统计返回 nil，CountExact 使用 COUNT(*)，
+// CountEstimate 取 EXPLAIN 估算的行数，大表上快得多但有过滤条件时误差较大
+func CountRows(db *gorm.DB, mode string) (*int64, error) {
+	var count int64
+	switch mode {
+	case CountExact:
+		if err := db.Count(&count).Error; err != nil {
+			return nil, err
+		}
+	case CountEstimate:
+		estimated, err := explainRows(db)
+		if err != nil {
+			return nil, err
+		}
+		if !estimated.Valid {
+			// 优化器没有给出行数时退回精确统计
+			return CountRows(db, CountExact)
+		}
+		count = estimated.Int64
+	default:
+		return nil, nil
+	}
+	return &count, nil
+}
+
+// explainRows 取优化器估算的行数：mysql 为 EXPLAIN 第一行的 rows 列，postgres 为计划的 Plan Rows，
+// sqlite 没有估算值
+func explainRows(db *gorm.DB) (sql.NullInt64, error) {
+	var result sql.NullInt64
+	driver := DriverOf(db)
+	if driver == Sqlite {
+		return result, nil
+	}
+	// 估算 SELECT 1 而不是 COUNT(*)，postgres 中 COUNT(*) 的计划顶层是聚合，行数为1
+	var ones []int
+	stmt := db.Session(&gorm.Session{DryRun: true}).Select("1").Find(&ones).Statement
+	explain := "EXPLAIN "
+	if driver == Postgres {
+		explain = "EXPLAIN (FORMAT JSON) "
+	}
+	rows, err := db.Session(&gorm.Session{NewDB: true}).Raw(explain+stmt.SQL.String(), stmt.Vars...).Rows()
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return result, err
+	}
+	if !rows.Next() {
+		return result, rows.Err()
+	}
+	if driver == Postgres {
+		var plan []byte
+		if err = rows.Scan(&plan); err != nil {
+			return result, err
+		}
+		var plans []struct {
+			Plan struct {
+				Rows float64 `json:"Plan Rows"`
+			} `json:"Plan"`
+		}
+		if err = json.Unmarshal(plan, &plans); err != nil || len(plans) == 0 {
+			return result, err
+		}
+		return sql.NullInt64{Int64: int64(plans[0].Plan.Rows), Valid: true}, nil
+	}
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		if strings.EqualFold(column, "rows") {
+			values[i] = &result
+		} else {
+			values[i] = new(sql.RawBytes)
+		}
+	}
+	return result, rows.Scan(values...)
+}
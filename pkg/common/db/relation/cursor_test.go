@@ -0,0 +1,75 @@
+package relation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type cursorTestOrder struct {
+	CreatedAtOrder string `search:"type:order;column:created_at;table:logs"`
+	IdOrder        string `search:"type:order;column:id;table:logs"`
+}
+
+type cursorTestQuery struct {
+	Pagination struct{ Cursor string } `search:"-"`
+	Phone      string                  `search:"type:exact;column:phone;table:logs"`
+	cursorTestOrder
+}
+
+func TestResolveOrderKeys(t *testing.T) {
+	keys := resolveOrderKeys(cursorTestQuery{})
+	if want := []orderKey{{Table: "logs", Column: "id", Desc: true}}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("default keys = %v, want %v", keys, want)
+	}
+	keys = resolveOrderKeys(cursorTestQuery{cursorTestOrder: cursorTestOrder{CreatedAtOrder: "ASC"}})
+	want := []orderKey{{Table: "logs", Column: "created_at"}, {Table: "logs", Column: "id"}}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+	keys = resolveOrderKeys(cursorTestQuery{cursorTestOrder: cursorTestOrder{CreatedAtOrder: "desc", IdOrder: "asc"}})
+	want = []orderKey{{Table: "logs", Column: "created_at", Desc: true}, {Table: "logs", Column: "id"}}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	q := cursorTestQuery{cursorTestOrder: cursorTestOrder{CreatedAtOrder: "desc"}}
+	c, err := NewCursor(q, "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createdAt := time.Date(2024, 5, 1, 10, 30, 0, 123000000, time.Local)
+	encoded, err := c.encode([]interface{}{createdAt, int64(42)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := NewCursor(q, encoded, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next.values) != 2 || !next.values[0].(time.Time).Equal(createdAt) || next.values[1] != int64(42) {
+		t.Fatalf("decoded values = %v", next.values)
+	}
+	query, args := next.where(Mysql)
+	wantQuery := "(`logs`.`created_at` < ?) OR (`logs`.`created_at` = ? AND `logs`.`id` < ?)"
+	if query != wantQuery || len(args) != 3 {
+		t.Errorf("where = %s %v, want %s", query, args, wantQuery)
+	}
+
+	// 零值也要保留
+	encoded2, _ := c.encode([]interface{}{createdAt, int64(0)})
+	if zero, err := NewCursor(q, encoded2, 10); err != nil || zero.values[1] != int64(0) {
+		t.Errorf("zero value lost: %v %v", err, zero)
+	}
+
+	// 排序变化后旧游标失效
+	if _, err = NewCursor(cursorTestQuery{}, encoded, 10); err == nil {
+		t.Errorf("cursor with different order should be rejected")
+	}
+	if _, err = NewCursor(q, "not-a-cursor", 10); err == nil {
+		t.Errorf("malformed cursor should be rejected")
+	}
+}
@@ -0,0 +1,186 @@
+package relation
+
+import (
+	"errors"
+	"fmt"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/tracing"
+	"time"
+)
+
+const (
+	maxRetry = 100 // number of retries
+)
+
+type option struct {
+	Driver        string
+	Username      string
+	Password      string
+	Address       []string
+	Database      string
+	LogLevel      int
+	SlowThreshold int
+	MaxLifeTime   int
+	MaxOpenConn   int
+	MaxIdleConn   int
+	Connect       func(dsn string, maxRetry int) (*gorm.DB, error)
+
+	Replicas             []replicaOption
+	ReplicaCheckInterval int
+}
+
+// NewGormDB 按配置的 driver 连接 mysql、postgres 或 sqlite
+func NewGormDB() (*gorm.DB, error) {
+	errs.AddReplace(gorm.ErrRecordNotFound, errs.ErrRecordNotFound)
+	errs.AddErrHandler(replaceDuplicateKey)
+
+	o := &option{
+		Driver:        config.Get().Mysql.Driver,
+		Username:      config.Get().Mysql.Username,
+		Password:      config.Get().Mysql.Password,
+		Address:       config.Get().Mysql.Address,
+		Database:      config.Get().Mysql.Database,
+		LogLevel:      config.Get().Mysql.LogLevel,
+		SlowThreshold: config.Get().Mysql.SlowThreshold,
+		MaxLifeTime:   config.Get().Mysql.MaxLifeTime,
+		MaxOpenConn:   config.Get().Mysql.MaxOpenConn,
+		MaxIdleConn:   config.Get().Mysql.MaxIdleConn,
+
+		ReplicaCheckInterval: config.Get().Mysql.ReplicaCheckInterval,
+	}
+	o.Replicas = replicaOptions(o)
+	switch o.Driver {
+	case Postgres:
+		return newPostgresGormDB(o)
+	case Sqlite:
+		return newSqliteGormDB(o)
+	case Mysql, "":
+		return newMysqlGormDB(o)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %s", o.Driver)
+	}
+}
+
+// replicaOptions address 中第一个以外的地址和 replicas 都是从库，没有单独设置连接池参数时与主库相同
+func replicaOptions(o *option) []replicaOption {
+	var replicas []replicaOption
+	if len(o.Address) > 1 {
+		for _, address := range o.Address[1:] {
+			replicas = append(replicas, replicaOption{Address: address})
+		}
+	}
+	for _, r := range config.Get().Mysql.Replicas {
+		replicas = append(replicas, replicaOption{
+			Address:     r.Address,
+			MaxOpenConn: r.MaxOpenConn,
+			MaxIdleConn: r.MaxIdleConn,
+			MaxLifeTime: r.MaxLifeTime,
+		})
+	}
+	for i := range replicas {
+		if replicas[i].MaxOpenConn == 0 {
+			replicas[i].MaxOpenConn = o.MaxOpenConn
+		}
+		if replicas[i].MaxIdleConn == 0 {
+			replicas[i].MaxIdleConn = o.MaxIdleConn
+		}
+		if replicas[i].MaxLifeTime == 0 {
+			replicas[i].MaxLifeTime = o.MaxLifeTime
+		}
+	}
+	return replicas
+}
+
+// openGormDB 打开数据库并设置日志、链路追踪、审计字段和连接池，各数据库的错误统一转换为 gorm 的错误。配置了从库时读写分离
+func openGormDB(dialector gorm.Dialector, o *option, replicas replicaDriver) (*gorm.DB, error) {
+	sqlLogger := log.NewSqlLogger(
+		logger.LogLevel(o.LogLevel),
+		true,
+		time.Duration(o.SlowThreshold)*time.Millisecond,
+	)
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:         sqlLogger,
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Use(tracing.GormPlugin{}); err != nil {
+		return nil, err
+	}
+	if err = useAudit(db); err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetConnMaxLifetime(time.Second * time.Duration(o.MaxLifeTime))
+	sqlDB.SetMaxOpenConns(o.MaxOpenConn)
+	sqlDB.SetMaxIdleConns(o.MaxIdleConn)
+	if len(o.Replicas) > 0 {
+		if err = useReplicas(db, o, replicas); err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// connectToDatabase Connection retry, returns immediately when authentication fails.
+func connectToDatabase(dialector func(dsn string) gorm.Dialector, dsn string, maxRetry int) (*gorm.DB, error) {
+	var db *gorm.DB
+	var err error
+	for i := 0; i <= maxRetry; i++ {
+		db, err = gorm.Open(dialector(dsn), nil)
+		if err == nil {
+			return db, nil
+		}
+		if isAuthError(err) {
+			return nil, err
+		}
+		time.Sleep(time.Duration(1) * time.Second)
+	}
+	return nil, err
+}
+
+func isAuthError(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1045
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "28P01"
+	}
+	return false
+}
+
+func replaceDuplicateKey(err error) errs.CodeError {
+	if IsDuplicateKey(err) {
+		return errs.ErrDuplicateKey
+	}
+	return nil
+}
+
+// IsDuplicateKey 是否违反唯一约束。NewGormDB 打开的连接已将各数据库的错误转换为 gorm.ErrDuplicatedKey，
+// 这里同时识别未经转换的驱动错误
+func IsDuplicateKey(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1062
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505"
+	}
+	return isSqliteDuplicateKey(err)
+}
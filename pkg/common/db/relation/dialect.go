@@ -0,0 +1,31 @@
+package relation
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// DriverOf 连接使用的数据库，取自 gorm 的 Dialector：mysql、postgres 或 sqlite
+func DriverOf(db *gorm.DB) string {
+	if db == nil || db.Dialector == nil {
+		return Mysql
+	}
+	return db.Dialector.Name()
+}
+
+// Quote 按数据库给表名、列名加引号并以 . 连接，为空的部分会被忽略，如 Quote(Postgres, "t", "c") 为 "t"."c"
+func Quote(driver string, names ...string) string {
+	quote := "`"
+	if driver == Postgres {
+		quote = `"`
+	}
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		parts = append(parts, quote+strings.ReplaceAll(name, quote, quote+quote)+quote)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,114 @@
+package relation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type dialectTestUser struct {
+	ID   int64  `gorm:"primaryKey"`
+	Name string `gorm:"size:50;uniqueIndex"`
+}
+
+type dialectTestQuery struct {
+	Name    string `search:"type:iexact;column:name;table:dialect_test_users"`
+	Keyword string `search:"type:icontains;column:name;table:dialect_test_users"`
+	IdOrder string `search:"type:order;column:id;table:dialect_test_users"`
+}
+
+func TestQuote(t *testing.T) {
+	cases := map[string]string{
+		Quote(Mysql, "t", "c"):    "`t`.`c`",
+		Quote(Postgres, "t", "c"): `"t"."c"`,
+		Quote(Sqlite, "", "c"):    "`c`",
+		Quote(Postgres, `a"b`):    `"a""b"`,
+	}
+	for got, want := range cases {
+		if got != want {
+			t.Errorf("Quote() = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestMakeConditionDialects(t *testing.T) {
+	q := dialectTestQuery{Name: "Tom", Keyword: "o", IdOrder: "desc"}
+	cases := []struct {
+		dialector gorm.Dialector
+		want      []string
+	}{
+		{mysql.New(mysql.Config{DSN: "u:p@tcp(localhost)/db", SkipInitializeWithVersion: true}),
+			[]string{"`dialect_test_users`.`name` = 'Tom'", "`dialect_test_users`.`name` like '%o%'", "ORDER BY `dialect_test_users`.`id` desc"}},
+		{postgres.New(postgres.Config{DSN: "host=localhost"}),
+			[]string{`lower("dialect_test_users"."name") = lower('Tom')`, `"dialect_test_users"."name" ilike '%o%'`, `ORDER BY "dialect_test_users"."id" desc`}},
+	}
+	for _, c := range cases {
+		db, err := gorm.Open(c.dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Scopes(MakeCondition(q)).Find(&[]dialectTestUser{})
+		})
+		for _, want := range c.want {
+			if !strings.Contains(sql, want) {
+				t.Errorf("%s: %s does not contain %s", db.Dialector.Name(), sql, want)
+			}
+		}
+	}
+}
+
+func TestSqlite(t *testing.T) {
+	db, err := newSqliteGormDB(&option{Database: t.TempDir() + "/test.db", MaxOpenConn: 2, MaxIdleConn: 1, LogLevel: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&dialectTestUser{}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 5; i++ {
+		if err = db.Create(&dialectTestUser{ID: int64(i), Name: fmt.Sprintf("user%d", i)}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = db.Create(&dialectTestUser{Name: "user1"}).Error
+	if !IsDuplicateKey(err) || replaceDuplicateKey(err) == nil {
+		t.Errorf("IsDuplicateKey(%v) = false", err)
+	}
+
+	var users []dialectTestUser
+	if err = db.Scopes(MakeCondition(dialectTestQuery{Name: "USER2"})).Find(&users).Error; err != nil || len(users) != 1 {
+		t.Errorf("iexact: %v %v", err, users)
+	}
+
+	q := dialectTestQuery{Keyword: "user", IdOrder: "asc"}
+	var ids []int64
+	cursor := ""
+	for {
+		c, err := NewCursor(q, cursor, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var page []dialectTestUser
+		if err = c.Find(db.Scopes(MakeCondition(q)), &page); err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range page {
+			ids = append(ids, u.ID)
+		}
+		if cursor = c.Next(); cursor == "" {
+			break
+		}
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Errorf("cursor pages = %v", ids)
+	}
+	total, err := CountRows(db.Model(&dialectTestUser{}).Scopes(MakeCondition(q)), CountEstimate)
+	if err != nil || total == nil || *total != 5 {
+		t.Errorf("CountRows() = %v, %v", total, err)
+	}
+}
@@ -2,74 +2,42 @@ package relation
 
 import (
 	"fmt"
-	mysqldriver "github.com/go-sql-driver/mysql"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
-	"orderin-server/pkg/common/config"
-	"orderin-server/pkg/common/errs"
-	"orderin-server/pkg/common/log"
-	"time"
 )
 
-const (
-	maxRetry = 100 // number of retries
-	Driver   = "mysql"
-)
-
-type option struct {
-	Username      string
-	Password      string
-	Address       []string
-	Database      string
-	LogLevel      int
-	SlowThreshold int
-	MaxLifeTime   int
-	MaxOpenConn   int
-	MaxIdleConn   int
-	Connect       func(dsn string, maxRetry int) (*gorm.DB, error)
-}
-
 // newMysqlGormDB Initialize the database connection.
 func newMysqlGormDB(o *option) (*gorm.DB, error) {
 	err := maybeCreateDatabase(o)
 	if err != nil {
 		return nil, err
 	}
-	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=true&loc=Local",
-		o.Username, o.Password, o.Address[0], o.Database)
-	sqlLogger := log.NewSqlLogger(
-		logger.LogLevel(o.LogLevel),
-		true,
-		time.Duration(o.SlowThreshold)*time.Millisecond,
-	)
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: sqlLogger,
+	return openGormDB(mysql.Open(mysqlDsn(o, o.Address[0], o.Database)), o, replicaDriver{
+		name: "mysql",
+		dsn: func(address string) string {
+			return mysqlDsn(o, address, o.Database)
+		},
+		dialector: func(conn gorm.ConnPool) gorm.Dialector {
+			return mysql.New(mysql.Config{Conn: conn})
+		},
 	})
-	if err != nil {
-		return nil, err
-	}
-	sqlDB, err := db.DB()
-	if err != nil {
-		return nil, err
-	}
-	sqlDB.SetConnMaxLifetime(time.Second * time.Duration(o.MaxLifeTime))
-	sqlDB.SetMaxOpenConns(o.MaxOpenConn)
-	sqlDB.SetMaxIdleConns(o.MaxIdleConn)
-	return db, nil
+}
+
+func mysqlDsn(o *option, address, database string) string {
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=true&loc=Local",
+		o.Username, o.Password, address, database)
 }
 
 // maybeCreateDatabase creates a database if it does not exists.
 func maybeCreateDatabase(o *option) error {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=true&loc=Local",
-		o.Username, o.Password, o.Address[0], "mysql")
+	dsn := mysqlDsn(o, o.Address[0], "mysql")
 
 	var db *gorm.DB
 	var err error
 	if f := o.Connect; f != nil {
 		db, err = f(dsn, maxRetry)
 	} else {
-		db, err = connectToDatabase(dsn, maxRetry)
+		db, err = connectToDatabase(mysql.Open, dsn, maxRetry)
 	}
 	if err != nil {
 		panic(err.Error() + " Open failed " + dsn)
@@ -90,52 +58,3 @@ func maybeCreateDatabase(o *option) error {
 	}
 	return nil
 }
-
-// connectToDatabase Connection retry for mysql.
-func connectToDatabase(dsn string, maxRetry int) (*gorm.DB, error) {
-	var db *gorm.DB
-	var err error
-	for i := 0; i <= maxRetry; i++ {
-		db, err = gorm.Open(mysql.Open(dsn), nil)
-		if err == nil {
-			return db, nil
-		}
-		if mysqlErr, ok := err.(*mysqldriver.MySQLError); ok && mysqlErr.Number == 1045 {
-			return nil, err
-		}
-		time.Sleep(time.Duration(1) * time.Second)
-	}
-	return nil, err
-}
-
-// NewGormDB gorm mysql.
-func NewGormDB() (*gorm.DB, error) {
-	errs.AddReplace(gorm.ErrRecordNotFound, errs.ErrRecordNotFound)
-	errs.AddErrHandler(replaceDuplicateKey)
-
-	return newMysqlGormDB(&option{
-		Username:      config.Config.Mysql.Username,
-		Password:      config.Config.Mysql.Password,
-		Address:       config.Config.Mysql.Address,
-		Database:      config.Config.Mysql.Database,
-		LogLevel:      config.Config.Mysql.LogLevel,
-		SlowThreshold: config.Config.Mysql.SlowThreshold,
-		MaxLifeTime:   config.Config.Mysql.MaxLifeTime,
-		MaxOpenConn:   config.Config.Mysql.MaxOpenConn,
-		MaxIdleConn:   config.Config.Mysql.MaxIdleConn,
-	})
-}
-
-func replaceDuplicateKey(err error) errs.CodeError {
-	if IsMysqlDuplicateKey(err) {
-		return errs.ErrDuplicateKey
-	}
-	return nil
-}
-
-func IsMysqlDuplicateKey(err error) bool {
-	if mysqlErr, ok := err.(*mysqldriver.MySQLError); ok {
-		return mysqlErr.Number == 1062
-	}
-	return false
-}
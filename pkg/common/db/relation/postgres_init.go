@@ -0,0 +1,71 @@
+package relation
+
+import (
+	"fmt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"net"
+	"net/url"
+)
+
+// newPostgresGormDB 连接 postgres，数据库不存在时自动创建
+func newPostgresGormDB(o *option) (*gorm.DB, error) {
+	if err := maybeCreatePostgresDatabase(o); err != nil {
+		return nil, err
+	}
+	return openGormDB(postgres.Open(postgresDsn(o, o.Address[0], o.Database)), o, replicaDriver{
+		name: "pgx",
+		dsn: func(address string) string {
+			return postgresDsn(o, address, o.Database)
+		},
+		dialector: func(conn gorm.ConnPool) gorm.Dialector {
+			return postgres.New(postgres.Config{Conn: conn})
+		},
+	})
+}
+
+func postgresDsn(o *option, address, database string) string {
+	host := address
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "5432")
+	}
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(o.Username, o.Password),
+		Host:   host,
+		Path:   "/" + database,
+	}
+	return u.String()
+}
+
+// maybeCreatePostgresDatabase postgres 不支持 CREATE DATABASE IF NOT EXISTS，先查询是否存在
+func maybeCreatePostgresDatabase(o *option) error {
+	dsn := postgresDsn(o, o.Address[0], "postgres")
+	var db *gorm.DB
+	var err error
+	if f := o.Connect; f != nil {
+		db, err = f(dsn, maxRetry)
+	} else {
+		db, err = connectToDatabase(postgres.Open, dsn, maxRetry)
+	}
+	if err != nil {
+		return fmt.Errorf("open postgres %s: %w", o.Address[0], err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	var exists bool
+	if err = db.Raw("SELECT EXISTS(SELECT 1 FROM pg_database WHERE datname = ?)", o.Database).Scan(&exists).Error; err != nil {
+		return fmt.Errorf("init db %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if err = db.Exec(fmt.Sprintf("CREATE DATABASE %s ENCODING 'UTF8'", Quote(Postgres, o.Database))).Error; err != nil {
+		return fmt.Errorf("init db %w", err)
+	}
+	return nil
+}
@@ -13,8 +13,18 @@ const (
 	Mysql = "mysql"
 	// Postgres 数据库标识
 	Postgres = "postgres"
+	// Sqlite 数据库标识
+	Sqlite = "sqlite"
 )
 
+// likeOperator 不区分大小写时 postgres 使用 ilike，mysql 的默认排序规则和 sqlite 的 like 本身不区分大小写
+func likeOperator(driver string, caseInsensitive bool) string {
+	if driver == Postgres && caseInsensitive {
+		return "ilike"
+	}
+	return "like"
+}
+
 // ResolveSearchQuery 解析
 /**
  * 	exact / iexact 等于
@@ -23,9 +33,17 @@ const (
  *	lt / lte 小于 / 小于等于
  *	startswith / istartswith 以…起始
  *	endswith / iendswith 以…结束
- *	in
- *	isnull
+ *	in / notin
+ *	isnull / isnotnull 为 true 时判断为空 / 不为空
+ *	between 范围，字段为两个元素的切片或前两个字段为上下限的结构体，只有一端时为 gte / lte
+ *	json_contains json 字段（或 path 指定的部分）包含字段值，切片时包含全部元素
+ *	json_extract 取 json 字段中 path 的值与字段值比较，op 为 eq、ne、gt、gte、lt、lte
+ *	match 全文检索，column 可为多列，mysql 需要对应的 FULLTEXT 索引，mode:boolean 为布尔模式
+ *	or / and 条件组：切片时每个元素内的条件为 AND，元素之间按类型连接；结构体时各字段的条件按类型连接，可以嵌套
  *  order 排序		e.g. order[key]=desc     order[key]=asc
+ *
+ *	collate:排序规则 指定 mysql 比较字符串时的排序规则，如 utf8mb4_bin 使 exact 区分大小写，
+ *	utf8mb4_unicode_ci 使 iexact、icontains 等在 _bin 列上不区分大小写
  */
 func ResolveSearchQuery(driver string, q interface{}, condition Condition) {
 	qType := reflect.TypeOf(q)
@@ -51,137 +69,98 @@ func ResolveSearchQuery(driver string, q interface{}, condition Condition) {
 		}
 		//解析
 		switch t.Type {
-		case "or":
-			//获取属性类型
-			kind := qType.Field(i).Type.Kind()
-			if kind == reflect.Array || kind == reflect.Slice {
-				fieldValue := qValue.Field(i)
-				if fieldValue.Len() > 0 {
-					chilrenCondition := make([]*GormCondition, 0)
-					for j := 0; j < fieldValue.Len(); j++ {
-						childCondition := &GormCondition{
-							GormPublic: GormPublic{},
-							Join:       make([]*GormJoin, 0),
-						}
-						chilrenCondition = append(chilrenCondition, childCondition)
-
-						elem := fieldValue.Index(j)
-						ResolveSearchQuery(driver, elem.Interface(), childCondition)
-					}
-					query := ""
-					args := make([]interface{}, 0)
-					for k := 0; k < len(chilrenCondition); k++ {
-						itemQuery := ""
-						for key, value := range chilrenCondition[k].Where {
-							itemQuery = itemQuery + " AND " + key
-							args = append(args, value...)
-						}
-						itemQuery = "(" + itemQuery[4:] + ")"
-						if i > 0 {
-							query = query + " OR "
-						}
-						query = query + itemQuery
-					}
-					if len(query) > 0 {
-						condition.SetWhere(query[3:], args)
-					}
-				}
+		case "or", "and":
+			query, args := resolveGroup(driver, qValue.Field(i), " "+strings.ToUpper(t.Type)+" ")
+			if query != "" {
+				condition.SetWhere(query, args)
 			}
 		case "left":
 			join := condition.SetJoinOn(t.Type, fmt.Sprintf(
-				"left join `%s` on `%s`.`%s` = `%s`.`%s`",
-				t.Join,
-				t.Join,
-				t.On[0],
-				t.Table,
-				t.On[1],
+				"left join %s on %s = %s",
+				Quote(driver, t.Join),
+				Quote(driver, t.Join, t.On[0]),
+				Quote(driver, t.Table, t.On[1]),
 			))
 			ResolveSearchQuery(driver, qValue.Field(i).Interface(), join)
 		case "right":
 			//右连接
 			join := condition.SetJoinOn(t.Type, fmt.Sprintf(
-				"right left join `%s` on `%s`.`%s` = `%s`.`%s`",
-				t.Join,
-				t.Join,
-				t.On[0],
-				t.Table,
-				t.On[1],
+				"right join %s on %s = %s",
+				Quote(driver, t.Join),
+				Quote(driver, t.Join, t.On[0]),
+				Quote(driver, t.Table, t.On[1]),
 			))
 			ResolveSearchQuery(driver, qValue.Field(i).Interface(), join)
 		case "inner":
 			//内连接
 			join := condition.SetJoinOn(t.Type, fmt.Sprintf(
-				"join `%s` on `%s`.`%s` = `%s`.`%s`",
-				t.Join,
-				t.Join,
-				t.On[0],
-				t.Table,
-				t.On[1],
+				"join %s on %s = %s",
+				Quote(driver, t.Join),
+				Quote(driver, t.Join, t.On[0]),
+				Quote(driver, t.Table, t.On[1]),
 			))
 			ResolveSearchQuery(driver, qValue.Field(i).Interface(), join)
-		case "exact", "iexact":
-			condition.SetWhere(fmt.Sprintf("`%s`.`%s` = ?", t.Table, t.Column), []interface{}{qValue.Field(i).Interface()})
+		case "exact":
+			condition.SetWhere(fmt.Sprintf("%s = ?", t.columnExpr(driver, t.Column)), []interface{}{qValue.Field(i).Interface()})
+		case "iexact":
+			switch driver {
+			case Postgres:
+				condition.SetWhere(fmt.Sprintf("lower(%s) = lower(?)", Quote(driver, t.Table, t.Column)), []interface{}{qValue.Field(i).Interface()})
+			case Sqlite:
+				condition.SetWhere(fmt.Sprintf("%s = ? collate nocase", Quote(driver, t.Table, t.Column)), []interface{}{qValue.Field(i).Interface()})
+			default:
+				condition.SetWhere(fmt.Sprintf("%s = ?", t.columnExpr(driver, t.Column)), []interface{}{qValue.Field(i).Interface()})
+			}
 		case "contains", "icontains":
-			//fixme mysql不支持ilike
-			if driver == Postgres && t.Type == "icontains" {
-				condition.SetWhere(fmt.Sprintf("`%s`.`%s` ilike ?", t.Table, t.Column), []interface{}{"%" + qValue.Field(i).String() + "%"})
-			} else {
-				columns := strings.Split(t.Column, ",")
-				if len(columns) > 1 {
-					clauses := make([]string, len(columns))
-					values := make([]interface{}, len(columns))
+			like := likeOperator(driver, t.Type == "icontains")
+			columns := strings.Split(t.Column, ",")
+			if len(columns) > 1 {
+				clauses := make([]string, len(columns))
+				values := make([]interface{}, len(columns))
 
-					currentValue := "%" + qValue.Field(i).String() + "%"
-					for i, columnName := range columns {
-						clauses[i] = fmt.Sprintf("`%s`.`%s` like ?", t.Table, columnName)
-						values[i] = currentValue
-					}
-					condition.SetWhere(strings.Join(clauses, " or "), values)
-				} else {
-					//获取属性类型,如果是数组或者切片，需要拼接query语句
-					kind := qType.Field(i).Type.Kind()
-					if kind == reflect.Array || kind == reflect.Slice {
-						fieldValue := qValue.Field(i)
-						if fieldValue.Len() > 0 {
-							query := ""
-							args := make([]interface{}, 0)
-							for j := 0; j < fieldValue.Len(); j++ {
-								elem := fieldValue.Index(j)
-								query = query + " OR " + fmt.Sprintf("`%s`.`%s` like ?", t.Table, t.Column)
-								args = append(args, "%"+elem.String()+"%")
-							}
-							if len(query) > 0 {
-								condition.SetWhere(query[3:], args)
-							}
+				currentValue := "%" + qValue.Field(i).String() + "%"
+				for i, columnName := range columns {
+					clauses[i] = fmt.Sprintf("%s %s ?", t.columnExpr(driver, columnName), like)
+					values[i] = currentValue
+				}
+				condition.SetWhere(strings.Join(clauses, " or "), values)
+			} else {
+				//获取属性类型,如果是数组或者切片，需要拼接query语句
+				kind := qType.Field(i).Type.Kind()
+				if kind == reflect.Array || kind == reflect.Slice {
+					fieldValue := qValue.Field(i)
+					if fieldValue.Len() > 0 {
+						query := ""
+						args := make([]interface{}, 0)
+						for j := 0; j < fieldValue.Len(); j++ {
+							elem := fieldValue.Index(j)
+							query = query + " OR " + fmt.Sprintf("%s %s ?", t.columnExpr(driver, t.Column), like)
+							args = append(args, "%"+elem.String()+"%")
+						}
+						if len(query) > 0 {
+							condition.SetWhere(query[3:], args)
 						}
-					} else {
-						condition.SetWhere(fmt.Sprintf("`%s`.`%s` like ?", t.Table, t.Column), []interface{}{"%" + qValue.Field(i).String() + "%"})
 					}
-
+				} else {
+					condition.SetWhere(fmt.Sprintf("%s %s ?", t.columnExpr(driver, t.Column), like), []interface{}{"%" + qValue.Field(i).String() + "%"})
 				}
 			}
 		case "gt":
-			condition.SetWhere(fmt.Sprintf("`%s`.`%s` > ?", t.Table, t.Column), []interface{}{qValue.Field(i).Interface()})
+			condition.SetWhere(fmt.Sprintf("%s > ?", Quote(driver, t.Table, t.Column)), []interface{}{qValue.Field(i).Interface()})
 		case "gte":
-			condition.SetWhere(fmt.Sprintf("`%s`.`%s` >= ?", t.Table, t.Column), []interface{}{qValue.Field(i).Interface()})
+			condition.SetWhere(fmt.Sprintf("%s >= ?", Quote(driver, t.Table, t.Column)), []interface{}{qValue.Field(i).Interface()})
 		case "lt":
-			condition.SetWhere(fmt.Sprintf("`%s`.`%s` < ?", t.Table, t.Column), []interface{}{qValue.Field(i).Interface()})
+			condition.SetWhere(fmt.Sprintf("%s < ?", Quote(driver, t.Table, t.Column)), []interface{}{qValue.Field(i).Interface()})
 		case "lte":
-			condition.SetWhere(fmt.Sprintf("`%s`.`%s` <= ?", t.Table, t.Column), []interface{}{qValue.Field(i).Interface()})
+			condition.SetWhere(fmt.Sprintf("%s <= ?", Quote(driver, t.Table, t.Column)), []interface{}{qValue.Field(i).Interface()})
 		case "not":
-			condition.SetWhere(fmt.Sprintf("`%s`.`%s` != ?", t.Table, t.Column), []interface{}{qValue.Field(i).Interface()})
+			condition.SetWhere(fmt.Sprintf("%s != ?", Quote(driver, t.Table, t.Column)), []interface{}{qValue.Field(i).Interface()})
 		case "startswith", "istartswith":
-			if driver == Postgres && t.Type == "istartswith" {
-				condition.SetWhere(fmt.Sprintf("`%s`.`%s` ilike ?", t.Table, t.Column), []interface{}{qValue.Field(i).String() + "%"})
-			} else {
-				condition.SetWhere(fmt.Sprintf("`%s`.`%s` like ?", t.Table, t.Column), []interface{}{qValue.Field(i).String() + "%"})
-			}
+			like := likeOperator(driver, t.Type == "istartswith")
+			condition.SetWhere(fmt.Sprintf("%s %s ?", t.columnExpr(driver, t.Column), like), []interface{}{qValue.Field(i).String() + "%"})
 		case "endswith", "iendswith":
-			if driver == Postgres && t.Type == "iendswith" {
-				condition.SetWhere(fmt.Sprintf("`%s`.`%s` ilike ?", t.Table, t.Column), []interface{}{"%" + qValue.Field(i).String()})
-			} else {
-				condition.SetWhere(fmt.Sprintf("`%s`.`%s` like ?", t.Table, t.Column), []interface{}{"%" + qValue.Field(i).String()})
-			}
+			like := likeOperator(driver, t.Type == "iendswith")
+			condition.SetWhere(fmt.Sprintf("%s %s ?", t.columnExpr(driver, t.Column), like), []interface{}{"%" + qValue.Field(i).String()})
 		case "in":
 			field := qValue.Field(i)
 			kind := field.Kind()
@@ -191,7 +170,7 @@ func ResolveSearchQuery(driver string, q interface{}, condition Condition) {
 				length := field.Len()
 				// 判断切片或数组长度是大于零
 				if length > 0 {
-					condition.SetWhere(fmt.Sprintf("`%s`.`%s` in (?)", t.Table, t.Column), []interface{}{field.Interface()})
+					condition.SetWhere(fmt.Sprintf("%s in (?)", Quote(driver, t.Table, t.Column)), []interface{}{field.Interface()})
 				}
 			}
 		case "notin":
@@ -203,18 +182,148 @@ func ResolveSearchQuery(driver string, q interface{}, condition Condition) {
 				length := field.Len()
 				// 判断切片或数组长度是大于零
 				if length > 0 {
-					condition.SetWhere(fmt.Sprintf("`%s`.`%s` not in (?)", t.Table, t.Column), []interface{}{field.Interface()})
+					condition.SetWhere(fmt.Sprintf("%s not in (?)", Quote(driver, t.Table, t.Column)), []interface{}{field.Interface()})
 				}
 			}
 		case "isnull":
 			if !(qValue.Field(i).IsZero() && qValue.Field(i).IsNil()) {
-				condition.SetWhere(fmt.Sprintf("`%s`.`%s` is null", t.Table, t.Column), make([]interface{}, 0))
+				condition.SetWhere(fmt.Sprintf("%s is null", Quote(driver, t.Table, t.Column)), make([]interface{}, 0))
+			}
+		case "isnotnull":
+			condition.SetWhere(fmt.Sprintf("%s is not null", Quote(driver, t.Table, t.Column)), make([]interface{}, 0))
+		case "between":
+			low, high := rangeBounds(qValue.Field(i))
+			column := Quote(driver, t.Table, t.Column)
+			switch {
+			case low != nil && high != nil:
+				condition.SetWhere(fmt.Sprintf("%s between ? and ?", column), []interface{}{low, high})
+			case low != nil:
+				condition.SetWhere(fmt.Sprintf("%s >= ?", column), []interface{}{low})
+			case high != nil:
+				condition.SetWhere(fmt.Sprintf("%s <= ?", column), []interface{}{high})
+			}
+		case "json_contains":
+			if query, args := jsonContains(driver, t, qValue.Field(i)); query != "" {
+				condition.SetWhere(query, args)
+			}
+		case "json_extract":
+			if query, args := jsonExtract(driver, t, qValue.Field(i)); query != "" {
+				condition.SetWhere(query, args)
 			}
+		case "match":
+			query, args := fullTextMatch(driver, t, qValue.Field(i).String())
+			condition.SetWhere(query, args)
 		case "order":
 			switch strings.ToLower(qValue.Field(i).String()) {
 			case "desc", "asc":
-				condition.SetOrder(fmt.Sprintf("`%s`.`%s` %s", t.Table, t.Column, qValue.Field(i).String()))
+				condition.SetOrder(fmt.Sprintf("%s %s", Quote(driver, t.Table, t.Column), qValue.Field(i).String()))
 			}
 		}
 	}
 }
+
+// columnExpr 加引号的列名，mysql 中指定了 collate 时附加排序规则
+func (t *resolveSearchTag) columnExpr(driver, column string) string {
+	expr := Quote(driver, t.Table, column)
+	if driver == Mysql && t.Collate != "" {
+		expr += " collate " + t.Collate
+	}
+	return expr
+}
+
+// resolveGroup 解析条件组，切片的每个元素内为 AND，元素之间用 sep 连接；结构体各字段的条件用 sep 连接。
+// 组内的连接和排序被忽略
+func resolveGroup(driver string, field reflect.Value, sep string) (string, []interface{}) {
+	field = reflect.Indirect(field)
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, 0, field.Len())
+		args := make([]interface{}, 0)
+		for j := 0; j < field.Len(); j++ {
+			elem := reflect.Indirect(field.Index(j))
+			if elem.Kind() != reflect.Struct {
+				continue
+			}
+			child := &GormCondition{}
+			ResolveSearchQuery(driver, elem.Interface(), child)
+			query, childArgs := child.joinWhere(" AND ")
+			if query == "" {
+				continue
+			}
+			parts = append(parts, "("+query+")")
+			args = append(args, childArgs...)
+		}
+		if len(parts) == 1 {
+			return parts[0][1 : len(parts[0])-1], args
+		}
+		return strings.Join(parts, sep), args
+	case reflect.Struct:
+		child := &GormCondition{}
+		ResolveSearchQuery(driver, field.Interface(), child)
+		return child.joinWhere(sep)
+	}
+	return "", nil
+}
+
+// rangeBounds 取范围的上下限，字段为两个元素的切片或数组，或前两个导出字段为上下限的结构体，零值的一端返回 nil
+func rangeBounds(field reflect.Value) (interface{}, interface{}) {
+	var bounds []reflect.Value
+	field = reflect.Indirect(field)
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		if field.Len() != 2 {
+			return nil, nil
+		}
+		bounds = []reflect.Value{field.Index(0), field.Index(1)}
+	case reflect.Struct:
+		for j := 0; j < field.NumField() && len(bounds) < 2; j++ {
+			if field.Type().Field(j).IsExported() {
+				bounds = append(bounds, field.Field(j))
+			}
+		}
+		if len(bounds) != 2 {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	values := make([]interface{}, 2)
+	for j, bound := range bounds {
+		bound = reflect.Indirect(bound)
+		if bound.IsValid() && !bound.IsZero() {
+			values[j] = bound.Interface()
+		}
+	}
+	return values[0], values[1]
+}
+
+// fullTextMatch 全文检索：mysql 使用 MATCH ... AGAINST，postgres 使用 to_tsvector，sqlite 退化为 like
+func fullTextMatch(driver string, t *resolveSearchTag, keyword string) (string, []interface{}) {
+	columns := strings.Split(t.Column, ",")
+	quoted := make([]string, len(columns))
+	for j, column := range columns {
+		quoted[j] = Quote(driver, t.Table, column)
+	}
+	switch driver {
+	case Postgres:
+		tsquery := "plainto_tsquery"
+		if t.Mode == "boolean" {
+			tsquery = "websearch_to_tsquery"
+		}
+		return fmt.Sprintf("to_tsvector(concat_ws(' ', %s)) @@ %s(?)", strings.Join(quoted, ", "), tsquery), []interface{}{keyword}
+	case Sqlite:
+		clauses := make([]string, len(quoted))
+		args := make([]interface{}, len(quoted))
+		for j, column := range quoted {
+			clauses[j] = column + " like ?"
+			args[j] = "%" + keyword + "%"
+		}
+		return strings.Join(clauses, " or "), args
+	default:
+		mode := "in natural language mode"
+		if t.Mode == "boolean" {
+			mode = "in boolean mode"
+		}
+		return fmt.Sprintf("match (%s) against (? %s)", strings.Join(quoted, ", "), mode), []interface{}{keyword}
+	}
+}
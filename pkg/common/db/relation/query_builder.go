@@ -8,7 +8,7 @@ func MakeCondition(q interface{}) func(db *gorm.DB) *gorm.DB {
 			GormPublic: GormPublic{},
 			Join:       make([]*GormJoin, 0),
 		}
-		ResolveSearchQuery(Driver, q, condition)
+		ResolveSearchQuery(DriverOf(db), q, condition)
 		for _, join := range condition.Join {
 			if join == nil {
 				continue
@@ -16,9 +16,9 @@ func MakeCondition(q interface{}) func(db *gorm.DB) *gorm.DB {
 
 			var query = join.JoinOn
 			args := make([]interface{}, 0)
-			for k, v := range join.Where {
-				query = query + " AND " + k
-				args = append(args, v...)
+			for _, w := range join.Where {
+				query = query + " AND (" + w.Query + ")"
+				args = append(args, w.Args...)
 			}
 			db = db.Joins(query, args...)
 
@@ -33,11 +33,11 @@ func MakeCondition(q interface{}) func(db *gorm.DB) *gorm.DB {
 			//	db = db.Order(o)
 			//}
 		}
-		for k, v := range condition.Where {
-			db = db.Where(k, v...)
+		for _, w := range condition.Where {
+			db = db.Where(w.Query, w.Args...)
 		}
-		for k, v := range condition.Or {
-			db = db.Or(k, v...)
+		for _, w := range condition.Or {
+			db = db.Or(w.Query, w.Args...)
 		}
 		for _, o := range condition.Order {
 			db = db.Order(o)
@@ -82,6 +82,6 @@ func OrderBy(sort string) func(db *gorm.DB) *gorm.DB {
 
 func NotDeleted() func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		return db.Where("deleted = 0")
+		return db.Where("deleted = ?", false)
 	}
 }
@@ -0,0 +1,121 @@
+package relation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var jsonCompareOperators = map[string]string{
+	"":    "=",
+	"eq":  "=",
+	"ne":  "!=",
+	"gt":  ">",
+	"gte": ">=",
+	"lt":  "<",
+	"lte": "<=",
+}
+
+// quoteLiteral 单引号字符串字面量，路径来自 tag 而不是请求
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// jsonPathKeys 把 $.a.b[0] 形式的路径拆成 {a,b,0}，用于 postgres 的 #> 和 #>> 运算符
+func jsonPathKeys(path string) string {
+	path = strings.TrimPrefix(path, "$")
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	keys := make([]string, 0)
+	for _, key := range strings.Split(path, ".") {
+		if key != "" {
+			keys = append(keys, strings.Trim(key, `"`))
+		}
+	}
+	return "{" + strings.Join(keys, ",") + "}"
+}
+
+// jsonContains json 字段（或其中 path 指定的部分）包含字段值，字段为切片时要包含全部元素。
+// sqlite 只支持数组中包含标量
+func jsonContains(driver string, t *resolveSearchTag, field reflect.Value) (string, []interface{}) {
+	column := Quote(driver, t.Table, t.Column)
+	switch driver {
+	case Sqlite:
+		source := column
+		if t.Path != "" {
+			source += ", " + quoteLiteral(t.Path)
+		}
+		exists := "exists (select 1 from json_each(" + source + ") where value = ?)"
+		field = reflect.Indirect(field)
+		if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+			return exists, []interface{}{field.Interface()}
+		}
+		clauses := make([]string, 0, field.Len())
+		args := make([]interface{}, 0, field.Len())
+		for j := 0; j < field.Len(); j++ {
+			clauses = append(clauses, exists)
+			args = append(args, field.Index(j).Interface())
+		}
+		return strings.Join(clauses, " and "), args
+	}
+
+	candidate, err := json.Marshal(field.Interface())
+	if err != nil {
+		return "", nil
+	}
+	if driver == Postgres {
+		target := column + "::jsonb"
+		if t.Path != "" {
+			target = "(" + target + " #> " + quoteLiteral(jsonPathKeys(t.Path)) + ")"
+		}
+		return target + " @> ?::jsonb", []interface{}{string(candidate)}
+	}
+	if t.Path != "" {
+		return fmt.Sprintf("json_contains(%s, ?, %s)", column, quoteLiteral(t.Path)), []interface{}{string(candidate)}
+	}
+	return fmt.Sprintf("json_contains(%s, ?)", column), []interface{}{string(candidate)}
+}
+
+// jsonExtract 取 json 字段中 path 的值与字段值比较，按字段的类型比较字符串、数字或布尔值
+func jsonExtract(driver string, t *resolveSearchTag, field reflect.Value) (string, []interface{}) {
+	operator, ok := jsonCompareOperators[t.Op]
+	if !ok || t.Path == "" {
+		return "", nil
+	}
+	column := Quote(driver, t.Table, t.Column)
+	field = reflect.Indirect(field)
+	value := field.Interface()
+	kind := "string"
+	switch field.Kind() {
+	case reflect.Bool:
+		kind = "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		kind = "number"
+	}
+
+	var expr string
+	switch driver {
+	case Postgres:
+		expr = column + "::jsonb #>> " + quoteLiteral(jsonPathKeys(t.Path))
+		switch kind {
+		case "bool":
+			expr = "(" + expr + ")::boolean"
+		case "number":
+			expr = "(" + expr + ")::numeric"
+		}
+	case Sqlite:
+		expr = fmt.Sprintf("json_extract(%s, %s)", column, quoteLiteral(t.Path))
+	default:
+		expr = fmt.Sprintf("json_extract(%s, %s)", column, quoteLiteral(t.Path))
+		switch kind {
+		case "bool":
+			// json 中的 true/false 只能和 json 值比较
+			return fmt.Sprintf("%s %s cast(? as json)", expr, operator), []interface{}{fmt.Sprint(value)}
+		case "string":
+			expr = "json_unquote(" + expr + ")"
+		}
+	}
+	return fmt.Sprintf("%s %s ?", expr, operator), []interface{}{value}
+}
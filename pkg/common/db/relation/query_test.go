@@ -0,0 +1,145 @@
+package relation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type queryTestItem struct {
+	ID    int64 `gorm:"primaryKey"`
+	Name  string
+	Price int
+	Tags  string
+	Ext   *string
+}
+
+type queryTestGroup struct {
+	Name  string `search:"type:exact;column:name;table:query_test_items"`
+	Price int    `search:"type:gte;column:price;table:query_test_items"`
+}
+
+type queryTestQuery struct {
+	Any    []queryTestGroup `search:"type:or"`
+	Either struct {
+		Name   string           `search:"type:icontains;column:name;table:query_test_items"`
+		Tagged []queryTestGroup `search:"type:and"`
+	} `search:"type:or"`
+	Price  []int `search:"type:between;column:price;table:query_test_items"`
+	Bounds struct {
+		Min int
+		Max *int
+	} `search:"type:between;column:price;table:query_test_items"`
+	HasExt  bool     `search:"type:isnotnull;column:ext;table:query_test_items"`
+	Tags    []string `search:"type:json_contains;column:tags;table:query_test_items"`
+	Level   int      `search:"type:json_extract;column:ext;table:query_test_items;path:$.level;op:gte"`
+	Keyword string   `search:"type:match;column:name,tags;table:query_test_items;mode:boolean"`
+	Exact   string   `search:"type:exact;column:name;table:query_test_items;collate:utf8mb4_bin"`
+	IdOrder string   `search:"type:order;column:id;table:query_test_items"`
+}
+
+func TestResolveSearchQueryGroups(t *testing.T) {
+	q := queryTestQuery{Any: []queryTestGroup{{Name: "a", Price: 1}, {Name: "b"}}}
+	q.Either.Name = "x"
+	q.Either.Tagged = []queryTestGroup{{Name: "c"}}
+	condition := &GormCondition{}
+	ResolveSearchQuery(Mysql, q, condition)
+	want := []GormWhere{
+		{"((`query_test_items`.`name` = ?) AND (`query_test_items`.`price` >= ?)) OR (`query_test_items`.`name` = ?)", []interface{}{"a", 1, "b"}},
+		{"(`query_test_items`.`name` like ?) OR (`query_test_items`.`name` = ?)", []interface{}{"%x%", "c"}},
+	}
+	if !reflect.DeepEqual(condition.Where, want) {
+		t.Errorf("where = %v, want %v", condition.Where, want)
+	}
+}
+
+func TestSearchOperatorsDialects(t *testing.T) {
+	max := 20
+	q := queryTestQuery{Price: []int{0, 10}, Tags: []string{"a"}, Level: 3, Keyword: "go", Exact: "Tom"}
+	q.Bounds.Max = &max
+	cases := []struct {
+		dialector gorm.Dialector
+		want      []string
+	}{
+		{mysql.New(mysql.Config{DSN: "u:p@tcp(localhost)/db", SkipInitializeWithVersion: true}), []string{
+			"`query_test_items`.`price` <= 10",
+			"`query_test_items`.`price` <= 20",
+			"json_contains(`query_test_items`.`tags`, '[\"a\"]')",
+			"json_extract(`query_test_items`.`ext`, '$.level') >= 3",
+			"match (`query_test_items`.`name`, `query_test_items`.`tags`) against ('go' in boolean mode)",
+			"`query_test_items`.`name` collate utf8mb4_bin = 'Tom'",
+		}},
+		{postgres.New(postgres.Config{DSN: "host=localhost"}), []string{
+			`"query_test_items"."tags"::jsonb @> '["a"]'::jsonb`,
+			`("query_test_items"."ext"::jsonb #>> '{level}')::numeric >= 3`,
+			`to_tsvector(concat_ws(' ', "query_test_items"."name", "query_test_items"."tags")) @@ websearch_to_tsquery('go')`,
+			`"query_test_items"."name" = 'Tom'`,
+		}},
+	}
+	for _, c := range cases {
+		db, err := gorm.Open(c.dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Scopes(MakeCondition(q)).Find(&[]queryTestItem{})
+		})
+		for _, want := range c.want {
+			if !strings.Contains(sql, want) {
+				t.Errorf("%s: %s does not contain %s", db.Dialector.Name(), sql, want)
+			}
+		}
+	}
+}
+
+func TestSearchOperatorsSqlite(t *testing.T) {
+	db, err := newSqliteGormDB(&option{Database: t.TempDir() + "/test.db", MaxOpenConn: 1, LogLevel: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&queryTestItem{}); err != nil {
+		t.Fatal(err)
+	}
+	ext := func(s string) *string { return &s }
+	db.Create([]queryTestItem{
+		{ID: 1, Name: "apple", Price: 5, Tags: `["fruit","red"]`, Ext: ext(`{"level":1}`)},
+		{ID: 2, Name: "banana", Price: 15, Tags: `["fruit"]`, Ext: ext(`{"level":3}`)},
+		{ID: 3, Name: "carrot", Price: 25, Tags: `["vegetable","red"]`},
+	})
+	find := func(q queryTestQuery) []int64 {
+		var items []queryTestItem
+		if err := db.Scopes(MakeCondition(q)).Order("id").Find(&items).Error; err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]int64, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+	max := 20
+	cases := []struct {
+		name string
+		q    queryTestQuery
+		want []int64
+	}{
+		{"between", queryTestQuery{Price: []int{5, 15}}, []int64{1, 2}},
+		{"between lower bound only", queryTestQuery{Price: []int{10, 0}}, []int64{2, 3}},
+		{"between struct", func() queryTestQuery { q := queryTestQuery{}; q.Bounds.Max = &max; return q }(), []int64{1, 2}},
+		{"isnotnull", queryTestQuery{HasExt: true}, []int64{1, 2}},
+		{"json_contains", queryTestQuery{Tags: []string{"fruit", "red"}}, []int64{1}},
+		{"json_extract", queryTestQuery{Level: 2}, []int64{2}},
+		{"match", queryTestQuery{Keyword: "veg"}, []int64{3}},
+		{"or", queryTestQuery{Any: []queryTestGroup{{Name: "apple"}, {Price: 20}}}, []int64{1, 3}},
+		{"or with other conditions", queryTestQuery{Any: []queryTestGroup{{Name: "apple"}, {Price: 20}}, HasExt: true}, []int64{1}},
+	}
+	for _, c := range cases {
+		if got := find(c.q); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: ids = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
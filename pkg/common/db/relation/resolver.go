@@ -0,0 +1,185 @@
+package relation
+
+import (
+	"context"
+	"database/sql"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+	"orderin-server/pkg/common/log"
+)
+
+// replicaPingTimeout 健康检查 ping 从库的超时时间
+const replicaPingTimeout = 3 * time.Second
+
+type replicaOption struct {
+	Address     string
+	MaxOpenConn int
+	MaxIdleConn int
+	MaxLifeTime int
+}
+
+// replicaDriver 打开从库所需的驱动信息
+type replicaDriver struct {
+	name      string // database/sql 的驱动名
+	dsn       func(address string) string
+	dialector func(conn gorm.ConnPool) gorm.Dialector
+}
+
+// replica 一个只读从库。不健康时语句转到主库执行，只有一个从库时 dbresolver 不经过 Policy，也能回退到主库。
+// 没有实现 Ping，从库在启动时不可用也不影响启动
+type replica struct {
+	address string
+	db      *sql.DB
+	primary gorm.ConnPool
+	healthy atomic.Bool
+}
+
+func (r *replica) pool() gorm.ConnPool {
+	if r.healthy.Load() {
+		return r.db
+	}
+	return r.primary
+}
+
+func (r *replica) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.pool().PrepareContext(ctx, query)
+}
+
+func (r *replica) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.pool().ExecContext(ctx, query, args...)
+}
+
+func (r *replica) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.pool().QueryContext(ctx, query, args...)
+}
+
+func (r *replica) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.pool().QueryRowContext(ctx, query, args...)
+}
+
+// check ping 从库并更新状态，状态变化时记录日志
+func (r *replica) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+	defer cancel()
+	err := r.db.PingContext(ctx)
+	if err != nil && r.healthy.Swap(false) {
+		log.ZWarn(ctx, "database replica is unavailable, reads fall back to primary", err, "replica", r.address)
+	} else if err == nil && !r.healthy.Swap(true) {
+		log.ZInfo(ctx, "database replica is available", "replica", r.address)
+	}
+}
+
+// healthyPolicy 在健康的从库中随机选择，都不健康时返回的从库会转到主库
+type healthyPolicy struct{}
+
+func (healthyPolicy) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	healthy := make([]gorm.ConnPool, 0, len(pools))
+	for _, pool := range pools {
+		if r, ok := pool.(*replica); ok && r.healthy.Load() {
+			healthy = append(healthy, pool)
+		}
+	}
+	if len(healthy) == 0 {
+		return pools[0]
+	}
+	return healthy[rand.Intn(len(healthy))]
+}
+
+// useReplicas 注册读写分离：查询走从库，写入、事务中的语句和 FOR UPDATE 走主库，
+// 同一个 WithPrimarySticky 上下文中写入之后的查询也走主库。从库定时做健康检查，不可用时读主库
+func useReplicas(db *gorm.DB, o *option, driver replicaDriver) error {
+	primary := db.Config.ConnPool
+	replicas := make([]*replica, 0, len(o.Replicas))
+	dialectors := make([]gorm.Dialector, 0, len(o.Replicas))
+	for _, ro := range o.Replicas {
+		sqlDB, err := sql.Open(driver.name, driver.dsn(ro.Address))
+		if err != nil {
+			return err
+		}
+		sqlDB.SetConnMaxLifetime(time.Second * time.Duration(ro.MaxLifeTime))
+		sqlDB.SetMaxOpenConns(ro.MaxOpenConn)
+		sqlDB.SetMaxIdleConns(ro.MaxIdleConn)
+		r := &replica{address: ro.Address, db: sqlDB, primary: primary}
+		r.healthy.Store(true)
+		r.check(context.Background())
+		replicas = append(replicas, r)
+		dialectors = append(dialectors, driver.dialector(r))
+	}
+	err := db.Use(dbresolver.Register(dbresolver.Config{Replicas: dialectors, Policy: healthyPolicy{}}))
+	if err != nil {
+		return err
+	}
+	if err = registerPrimarySticky(db); err != nil {
+		return err
+	}
+	if o.ReplicaCheckInterval > 0 {
+		go watchReplicas(replicas, time.Duration(o.ReplicaCheckInterval)*time.Second)
+	}
+	return nil
+}
+
+func watchReplicas(replicas []*replica, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		for _, r := range replicas {
+			r.check(context.Background())
+		}
+	}
+}
+
+type primaryStickyKey struct{}
+
+// WithPrimarySticky 返回的上下文中发生写入后，之后使用该上下文的查询都走主库，避免从库延迟导致读不到刚写入的数据。
+// 一般每个请求一个
+func WithPrimarySticky(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryStickyKey{}, new(atomic.Bool))
+}
+
+// Primary 返回的 db 上的查询都走主库，用于迁移和必须读到最新数据的场景
+func Primary(db *gorm.DB) *gorm.DB {
+	written := new(atomic.Bool)
+	written.Store(true)
+	return db.WithContext(context.WithValue(db.Statement.Context, primaryStickyKey{}, written))
+}
+
+func registerPrimarySticky(db *gorm.DB) error {
+	callback := db.Callback()
+	var err error
+	register := func(e error) {
+		if err == nil {
+			err = e
+		}
+	}
+	register(callback.Query().Before("gorm:query").Register("relation:stick_to_primary", stickToPrimary))
+	register(callback.Row().Before("gorm:row").Register("relation:stick_to_primary", stickToPrimary))
+	register(callback.Create().After("gorm:create").Register("relation:mark_written", markWritten))
+	register(callback.Update().After("gorm:update").Register("relation:mark_written", markWritten))
+	register(callback.Delete().After("gorm:delete").Register("relation:mark_written", markWritten))
+	register(callback.Raw().After("gorm:raw").Register("relation:mark_written", markWritten))
+	return err
+}
+
+func writtenFlag(db *gorm.DB) *atomic.Bool {
+	if db.Statement.Context == nil {
+		return nil
+	}
+	flag, _ := db.Statement.Context.Value(primaryStickyKey{}).(*atomic.Bool)
+	return flag
+}
+
+func markWritten(db *gorm.DB) {
+	if flag := writtenFlag(db); flag != nil && db.Error == nil {
+		flag.Store(true)
+	}
+}
+
+func stickToPrimary(db *gorm.DB) {
+	if flag := writtenFlag(db); flag != nil && flag.Load() {
+		dbresolver.Write.ModifyStatement(db.Statement)
+	}
+}
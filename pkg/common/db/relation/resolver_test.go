@@ -0,0 +1,112 @@
+package relation
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+type resolverTestItem struct {
+	ID   int64 `gorm:"primaryKey"`
+	Name string
+}
+
+func TestReplicas(t *testing.T) {
+	dir := t.TempDir()
+	replicaPath := dir + "/replica.db"
+	replicaDB, err := newSqliteGormDB(&option{Database: replicaPath, MaxOpenConn: 1, LogLevel: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = replicaDB.AutoMigrate(&resolverTestItem{}); err != nil {
+		t.Fatal(err)
+	}
+	replicaDB.Create(&resolverTestItem{ID: 1, Name: "replica"})
+
+	db, err := newSqliteGormDB(&option{
+		Database:    dir + "/primary.db",
+		MaxOpenConn: 2,
+		MaxIdleConn: 1,
+		LogLevel:    1,
+		Replicas:    []replicaOption{{Address: replicaPath, MaxOpenConn: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Primary(db).AutoMigrate(&resolverTestItem{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&resolverTestItem{ID: 1, Name: "primary"})
+
+	read := func(tx *gorm.DB) string {
+		var item resolverTestItem
+		if err := tx.First(&item, 1).Error; err != nil {
+			t.Fatal(err)
+		}
+		return item.Name
+	}
+	if got := read(db); got != "replica" {
+		t.Errorf("read = %s, want replica", got)
+	}
+	var name string
+	db.Raw("SELECT name FROM resolver_test_items WHERE id = 1").Scan(&name)
+	if name != "replica" {
+		t.Errorf("raw select = %s, want replica", name)
+	}
+	_ = db.Transaction(func(tx *gorm.DB) error {
+		if got := read(tx); got != "primary" {
+			t.Errorf("read in transaction = %s, want primary", got)
+		}
+		return nil
+	})
+
+	ctx := WithPrimarySticky(context.Background())
+	if got := read(db.WithContext(ctx)); got != "replica" {
+		t.Errorf("read before write = %s, want replica", got)
+	}
+	db.WithContext(ctx).Create(&resolverTestItem{ID: 2, Name: "new"})
+	if got := read(db.WithContext(ctx)); got != "primary" {
+		t.Errorf("read after write = %s, want primary", got)
+	}
+	if got := read(db); got != "replica" {
+		t.Errorf("read in other context = %s, want replica", got)
+	}
+	if got := read(Primary(db)); got != "primary" {
+		t.Errorf("read from Primary = %s, want primary", got)
+	}
+
+	// 从库不可用时回到主库，恢复后继续读从库
+	r := replicaOf(t, db)
+	r.healthy.Store(false)
+	if got := read(db); got != "primary" {
+		t.Errorf("read with unhealthy replica = %s, want primary", got)
+	}
+	r.check(context.Background())
+	if got := read(db); got != "replica" {
+		t.Errorf("read with recovered replica = %s, want replica", got)
+	}
+
+	// 启动时从库不可用也能打开，查询走主库
+	db, err = newSqliteGormDB(&option{
+		Database: dir + "/primary.db",
+		LogLevel: 1,
+		Replicas: []replicaOption{{Address: dir + "/missing/replica.db"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := read(db); got != "primary" {
+		t.Errorf("read with replica down at startup = %s, want primary", got)
+	}
+}
+
+// replicaOf 取得 dbresolver 为查询选中的从库
+func replicaOf(t *testing.T, db *gorm.DB) *replica {
+	stmt := db.Session(&gorm.Session{DryRun: true}).First(&resolverTestItem{}).Statement
+	r, ok := stmt.ConnPool.(*replica)
+	if !ok {
+		t.Fatalf("conn pool %T is not a replica", stmt.ConnPool)
+	}
+	return r
+}
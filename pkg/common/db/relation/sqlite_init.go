@@ -0,0 +1,59 @@
+package relation
+
+import (
+	"errors"
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	sqlite3 "modernc.org/sqlite/lib"
+	"os"
+	"path/filepath"
+)
+
+// sqliteMemory 内存数据库，各连接共享同一个库
+const sqliteMemory = ":memory:"
+
+// newSqliteGormDB 打开 sqlite 数据库文件，不存在时自动创建。纯 Go 实现，不依赖 cgo，适合测试和单机部署。
+// 从库的 address 为数据库文件路径
+func newSqliteGormDB(o *option) (*gorm.DB, error) {
+	if o.Database != sqliteMemory {
+		if err := os.MkdirAll(filepath.Dir(o.Database), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	db, err := openGormDB(sqlite.Open(sqliteDsn(o.Database)), o, replicaDriver{
+		name: sqlite.DriverName,
+		dsn:  sqliteDsn,
+		dialector: func(conn gorm.ConnPool) gorm.Dialector {
+			return &sqlite.Dialector{Conn: conn}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if o.Database == sqliteMemory {
+		// 最后一个连接关闭时内存数据库即被销毁，保留一个连接不过期
+		sqlDB, _ := db.DB()
+		sqlDB.SetConnMaxLifetime(0)
+		if o.MaxIdleConn < 1 {
+			sqlDB.SetMaxIdleConns(1)
+		}
+	}
+	return db, nil
+}
+
+func sqliteDsn(database string) string {
+	if database == sqliteMemory {
+		return "file::memory:?cache=shared"
+	}
+	// 写入时等待其他连接释放锁，而不是立即返回 database is locked
+	return database + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+}
+
+func isSqliteDuplicateKey(err error) bool {
+	var sqliteErr *gosqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	}
+	return false
+}
@@ -0,0 +1,6 @@
+package dto
+
+// IdReq 根据主键操作的请求
+type IdReq struct {
+	ID int64 `json:"id,string" vd:"@:$>0"`
+}
@@ -18,3 +18,17 @@ func (m *Pagination) GetPageSize() int {
 	}
 	return m.PageSize
 }
+
+// CursorPagination 游标分页，Cursor 为上一页返回的 nextCursor，为空时查询第一页
+type CursorPagination struct {
+	Cursor   string `json:"cursor"`
+	PageSize int    `json:"pageSize"`
+	Count    string `json:"count" vd:"@:in($,'','exact','estimate')"` // 是否返回总数：为空不统计，exact精确统计，estimate估算
+}
+
+func (m *CursorPagination) GetPageSize() int {
+	if m.PageSize <= 0 {
+		m.PageSize = 10
+	}
+	return m.PageSize
+}
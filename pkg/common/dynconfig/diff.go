@@ -0,0 +1,97 @@
+package dynconfig
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// 变更类型
+const (
+	ChangeAdd    = "add"
+	ChangeRemove = "remove"
+	ChangeUpdate = "update"
+)
+
+// Change 两个配置值之间的一处差异，Path 为 json 路径（如 banner.items[0].title），非 json 类型时为空
+type Change struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// Diff 比较两个配置值，json 类型逐字段比较，其余类型整体比较，无差异时返回空
+func Diff(valueType, oldValue, newValue string) []Change {
+	changes := make([]Change, 0)
+	if valueType == TypeJSON {
+		var o, n interface{}
+		if json.Unmarshal([]byte(oldValue), &o) == nil && json.Unmarshal([]byte(newValue), &n) == nil {
+			diffJSON("", o, n, &changes)
+			return changes
+		}
+	}
+	if oldValue != newValue {
+		changes = append(changes, Change{Type: ChangeUpdate, Old: oldValue, New: newValue})
+	}
+	return changes
+}
+
+func diffJSON(path string, o, n interface{}, changes *[]Change) {
+	switch ov := o.(type) {
+	case map[string]interface{}:
+		if nv, ok := n.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(ov)+len(nv))
+			for k := range ov {
+				keys = append(keys, k)
+			}
+			for k := range nv {
+				if _, ok := ov[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				child := k
+				if path != "" {
+					child = path + "." + k
+				}
+				oc, oOk := ov[k]
+				nc, nOk := nv[k]
+				switch {
+				case !oOk:
+					*changes = append(*changes, Change{Path: child, Type: ChangeAdd, New: marshal(nc)})
+				case !nOk:
+					*changes = append(*changes, Change{Path: child, Type: ChangeRemove, Old: marshal(oc)})
+				default:
+					diffJSON(child, oc, nc, changes)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if nv, ok := n.([]interface{}); ok {
+			for i := 0; i < len(ov) || i < len(nv); i++ {
+				child := path + "[" + strconv.Itoa(i) + "]"
+				switch {
+				case i >= len(ov):
+					*changes = append(*changes, Change{Path: child, Type: ChangeAdd, New: marshal(nv[i])})
+				case i >= len(nv):
+					*changes = append(*changes, Change{Path: child, Type: ChangeRemove, Old: marshal(ov[i])})
+				default:
+					diffJSON(child, ov[i], nv[i], changes)
+				}
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(o, n) {
+		*changes = append(*changes, Change{Path: path, Type: ChangeUpdate, Old: marshal(o), New: marshal(n)})
+	}
+}
+
+func marshal(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
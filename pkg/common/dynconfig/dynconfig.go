@@ -0,0 +1,154 @@
+package dynconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// 值类型，与 SysConfig.ValueType 一致
+const (
+	TypeNumber = "number"
+	TypeBool   = "bool"
+	TypeString = "string"
+	TypeJSON   = "json"
+)
+
+// Definition 在代码中注册的动态配置定义，值保存在 sys_configs 表中
+type Definition struct {
+	Name        string   `json:"name"`
+	ValueType   string   `json:"valueType"`
+	Default     string   `json:"defaultValue"`
+	Description string   `json:"description"`
+	Min         *float64 `json:"min,omitempty"`    // number 类型的最小值
+	Max         *float64 `json:"max,omitempty"`    // number 类型的最大值
+	Schema      string   `json:"schema,omitempty"` // json 类型的 JSON-schema
+
+	schema *jsonschema.Schema
+}
+
+var (
+	mu          sync.RWMutex
+	definitions = map[string]*Definition{}
+	names       []string
+)
+
+// Float 用于设置 Min、Max
+func Float(v float64) *float64 {
+	return &v
+}
+
+// Register 注册配置定义，一般在 init 中调用。定义不合法（类型未知、schema 错误、缺省值不合法）时 panic
+func Register(defs ...Definition) {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range defs {
+		def := defs[i]
+		if def.Name == "" {
+			panic("dynconfig: name is required")
+		}
+		if def.Schema != "" {
+			if def.ValueType != TypeJSON {
+				panic(fmt.Sprintf("dynconfig: %s schema is only supported by json type", def.Name))
+			}
+			schema, err := jsonschema.CompileString(def.Name+".json", def.Schema)
+			if err != nil {
+				panic(fmt.Sprintf("dynconfig: invalid schema of %s: %v", def.Name, err))
+			}
+			def.schema = schema
+		}
+		if err := def.Check(def.Default); err != nil {
+			panic(fmt.Sprintf("dynconfig: invalid default value of %s: %v", def.Name, err))
+		}
+		if _, ok := definitions[def.Name]; !ok {
+			names = append(names, def.Name)
+		}
+		definitions[def.Name] = &def
+	}
+}
+
+// Lookup 查找配置定义
+func Lookup(name string) (Definition, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	def, ok := definitions[name]
+	if !ok {
+		return Definition{}, false
+	}
+	return *def, true
+}
+
+// Definitions 按注册顺序返回所有配置定义
+func Definitions() []Definition {
+	mu.RLock()
+	defer mu.RUnlock()
+	result := make([]Definition, 0, len(names))
+	for _, name := range names {
+		result = append(result, *definitions[name])
+	}
+	return result
+}
+
+// Check 按类型、取值范围和 schema 校验配置值
+func (d Definition) Check(value string) error {
+	if err := CheckType(d.ValueType, value); err != nil {
+		return err
+	}
+	switch d.ValueType {
+	case TypeNumber:
+		n, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if d.Min != nil && n < *d.Min {
+			return fmt.Errorf("must be greater than or equal to %v", *d.Min)
+		}
+		if d.Max != nil && n > *d.Max {
+			return fmt.Errorf("must be less than or equal to %v", *d.Max)
+		}
+	case TypeJSON:
+		if d.schema != nil {
+			var v interface{}
+			_ = json.Unmarshal([]byte(value), &v)
+			if err := d.schema.Validate(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CheckType 校验值是否符合类型
+func CheckType(valueType, value string) error {
+	switch valueType {
+	case TypeNumber:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+	case TypeBool:
+		if _, err := strconv.ParseBool(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("%q is not a bool", value)
+		}
+	case TypeJSON:
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("value is not valid json")
+		}
+	case TypeString:
+	default:
+		return fmt.Errorf("unknown value type %q, must be one of number|bool|string|json", valueType)
+	}
+	return nil
+}
+
+// Validate 校验配置项：已注册的按定义校验且类型必须一致，未注册的只校验类型，defined 表示是否已注册
+func Validate(name, valueType, value string) (defined bool, err error) {
+	def, ok := Lookup(name)
+	if !ok {
+		return false, CheckType(valueType, value)
+	}
+	if valueType != "" && valueType != def.ValueType {
+		return true, fmt.Errorf("value type of %s must be %s", name, def.ValueType)
+	}
+	return true, def.Check(value)
+}
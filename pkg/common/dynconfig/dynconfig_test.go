@@ -0,0 +1,71 @@
+package dynconfig
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	Register(
+		Definition{Name: "test.ttl", ValueType: TypeNumber, Default: "10", Min: Float(1), Max: Float(60)},
+		Definition{Name: "test.banner", ValueType: TypeJSON, Default: `{"title":"hi"}`,
+			Schema: `{"type":"object","required":["title"],"properties":{"title":{"type":"string"}}}`},
+	)
+
+	cases := []struct {
+		name, valueType, value string
+		defined, ok            bool
+	}{
+		{"test.ttl", TypeNumber, "30", true, true},
+		{"test.ttl", TypeNumber, "0", true, false},
+		{"test.ttl", TypeNumber, "abc", true, false},
+		{"test.ttl", TypeString, "30", true, false},
+		{"test.banner", TypeJSON, `{"title":"welcome"}`, true, true},
+		{"test.banner", TypeJSON, `{"title":1}`, true, false},
+		{"test.banner", TypeJSON, `{`, true, false},
+		{"test.unknown", TypeBool, "true", false, true},
+		{"test.unknown", TypeBool, "yes", false, false},
+		{"test.unknown", "list", "a", false, false},
+	}
+	for _, c := range cases {
+		defined, err := Validate(c.name, c.valueType, c.value)
+		if defined != c.defined || (err == nil) != c.ok {
+			t.Errorf("Validate(%s, %s, %q) = %v, %v", c.name, c.valueType, c.value, defined, err)
+		}
+	}
+
+	defs := Definitions()
+	if len(defs) != 2 || defs[0].Name != "test.ttl" || defs[1].Name != "test.banner" {
+		t.Errorf("Definitions() = %+v, want registration order", defs)
+	}
+}
+
+func TestRegisterInvalidDefault(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() with invalid default should panic")
+		}
+	}()
+	Register(Definition{Name: "test.invalid", ValueType: TypeNumber, Default: "1", Min: Float(5)})
+}
+
+func TestDiff(t *testing.T) {
+	changes := Diff(TypeJSON, `{"title":"a","items":[1,2],"old":true}`, `{"title":"b","items":[1],"new":{"x":1}}`)
+	want := []Change{
+		{Path: "items[1]", Type: ChangeRemove, Old: "2"},
+		{Path: "new", Type: ChangeAdd, New: `{"x":1}`},
+		{Path: "old", Type: ChangeRemove, Old: "true"},
+		{Path: "title", Type: ChangeUpdate, Old: `"a"`, New: `"b"`},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Diff() = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("Diff()[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+	if changes := Diff(TypeNumber, "10", "10"); len(changes) != 0 {
+		t.Errorf("Diff() of same value = %+v, want empty", changes)
+	}
+	if changes := Diff(TypeString, "a", "b"); len(changes) != 1 || changes[0].Old != "a" || changes[0].New != "b" {
+		t.Errorf("Diff() of string = %+v", changes)
+	}
+}
@@ -1,7 +1,11 @@
 package email
 
 import (
+	"context"
 	"orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/log"
+	"reflect"
+	"sync/atomic"
 )
 
 type EmailConfig struct {
@@ -12,37 +16,63 @@ type EmailConfig struct {
 	SmtpPort int
 }
 
-var EmailVendorInstance EmailVendor
+// emailVendorHolder atomic.Value 不能保存 nil 接口，包一层
+type emailVendorHolder struct {
+	vendor EmailVendor
+}
+
+var currentEmailVendor atomic.Value
+
+// GetEmailVendor 返回当前的邮件客户端，未配置时为 nil，配置热加载后会替换为新客户端
+func GetEmailVendor() EmailVendor {
+	holder, _ := currentEmailVendor.Load().(emailVendorHolder)
+	return holder.vendor
+}
 
 func InitEmailVendor() {
-	e := EmailConfig{
-		From:     config.Config.Mail.From,
-		Account:  config.Config.Mail.Account,
-		Password: config.Config.Mail.Password,
-		SmtpHost: config.Config.Mail.SmtpHost,
-		SmtpPort: config.Config.Mail.SmtpPort,
+	e := newEmailConfig(config.Get())
+	currentEmailVendor.Store(emailVendorHolder{vendor: e.Setup(VendorType(config.Get().Mail.DriverType))})
+
+	config.Subscribe("email", func(old, new *config.Snapshot) {
+		if reflect.DeepEqual(old.Mail, new.Mail) {
+			return
+		}
+		vendor, err := newEmailConfig(new).build(VendorType(new.Mail.DriverType))
+		if err != nil {
+			log.ZError(context.Background(), "rebuild email vendor failed, keep the old one", err)
+			return
+		}
+		currentEmailVendor.Store(emailVendorHolder{vendor: vendor})
+		log.ZInfo(context.Background(), "email vendor rebuilt", "driverType", new.Mail.DriverType)
+	})
+}
+
+func newEmailConfig(c *config.Snapshot) *EmailConfig {
+	return &EmailConfig{
+		From:     c.Mail.From,
+		Account:  c.Mail.Account,
+		Password: c.Mail.Password,
+		SmtpHost: c.Mail.SmtpHost,
+		SmtpPort: c.Mail.SmtpPort,
 	}
-	EmailVendorInstance = e.Setup(VendorType(config.Config.Mail.DriverType))
 }
 
 // Setup 配置文件存储driver
 func (e *EmailConfig) Setup(vendorType VendorType) EmailVendor {
+	result, err := e.build(vendorType)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (e *EmailConfig) build(vendorType VendorType) (EmailVendor, error) {
 	var result EmailVendor
 	switch vendorType {
-	case QQ:
+	case QQ, Gmail:
 		result = new(QQEmail)
-		err := result.Setup(e)
-		if err != nil {
-			panic(err)
-		}
-		return result
-	case Gmail:
-		result = new(QQEmail)
-		err := result.Setup(e)
-		if err != nil {
-			panic(err)
-		}
-		return result
+	default:
+		return nil, nil
 	}
-	return nil
+	return result, result.Setup(e)
 }
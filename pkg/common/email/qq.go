@@ -7,6 +7,7 @@ import (
 	"html/template"
 	"net/smtp"
 	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/prommetrics"
 	"orderin-server/pkg/common/utils"
 	"path/filepath"
 	"strings"
@@ -21,10 +22,12 @@ func (e *QQEmail) Setup(config *EmailConfig) error {
 	return nil
 }
 
-func (e *QQEmail) Send(request EmailRequest) error {
+func (e *QQEmail) Send(request EmailRequest) (err error) {
+	defer func() {
+		prommetrics.EmailSendTotal.WithLabelValues(prommetrics.Result(err == nil)).Inc()
+	}()
 	var body string
 	var contentType string
-	var err error
 	if request.Content != "" {
 		body = request.Content
 		contentType = "text/plain"
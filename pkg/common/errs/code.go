@@ -1,5 +1,10 @@
 package errs
 
+import (
+	"fmt"
+	"sort"
+)
+
 const (
 	// 通用错误码.
 	ServerInternalError = 500 // 服务器内部错误
@@ -26,3 +31,53 @@ const (
 	CheckIdentityError     = 1006
 	UnSupportedOperation   = 1007
 )
+
+// Code 错误码及名称
+type Code struct {
+	Code int
+	Name string
+}
+
+// codeNames 已登记的错误码，用于生成前端常量，新增错误码时需同时登记
+var codeNames = map[int]string{
+	ServerInternalError:    "ServerInternalError",
+	ArgsError:              "ArgsError",
+	UnauthorizedError:      "UnauthorizedError",
+	NoPermissionError:      "NoPermissionError",
+	RecordNotFoundError:    "RecordNotFoundError",
+	DuplicateKeyError:      "DuplicateKeyError",
+	TokenExpiredError:      "TokenExpiredError",
+	TokenInvalidError:      "TokenInvalidError",
+	TokenMalformedError:    "TokenMalformedError",
+	TokenNotValidYetError:  "TokenNotValidYetError",
+	TokenUnknownError:      "TokenUnknownError",
+	TokenKickedError:       "TokenKickedError",
+	TokenNotExistError:     "TokenNotExistError",
+	AccountOrPasswordError: "AccountOrPasswordError",
+	AccountNotExistError:   "AccountNotExistError",
+	SmsCodeError:           "SmsCodeError",
+	EmailCodeError:         "EmailCodeError",
+	MFACodeError:           "MFACodeError",
+	CheckIdentityError:     "CheckIdentityError",
+	UnSupportedOperation:   "UnSupportedOperation",
+}
+
+// RegisterCode 登记业务模块自定义的错误码，错误码重复时 panic
+func RegisterCode(code int, name string) {
+	if existing, ok := codeNames[code]; ok {
+		panic(fmt.Sprintf("error code %d is already registered as %s", code, existing))
+	}
+	codeNames[code] = name
+}
+
+// Codes 所有已登记的错误码，按错误码排序
+func Codes() []Code {
+	codes := make([]Code, 0, len(codeNames))
+	for code, name := range codeNames {
+		codes = append(codes, Code{Code: code, Name: name})
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].Code < codes[j].Code
+	})
+	return codes
+}
@@ -0,0 +1,33 @@
+package errs
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// TestCodesRegistered 确保 code.go 中的错误码都已登记
+func TestCodesRegistered(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "code.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registered := map[string]bool{}
+	for _, code := range Codes() {
+		registered[code.Name] = true
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			for _, name := range spec.(*ast.ValueSpec).Names {
+				if !registered[name.Name] {
+					t.Errorf("error code %s is not registered in codeNames", name.Name)
+				}
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ var (
 	ErrInternalServer       = NewCodeError(ServerInternalError, "ServerInternalError")
 	ErrRecordNotFound       = NewCodeError(RecordNotFoundError, "RecordNotFoundError")
 	ErrDuplicateKey         = NewCodeError(DuplicateKeyError, "DuplicateKeyError")
+	ErrTooManyRequests      = NewCodeError(ServerInternalError, "TooManyRequestsError") // 限流，客户端按 500 处理
 	ErrMFACode              = NewCodeError(MFACodeError, "MFACodeError")
 	ErrCheckIdentity        = NewCodeError(CheckIdentityError, "CheckIdentityError")
 	ErrUnSupportedOperation = NewCodeError(UnSupportedOperation, "UnSupportedOperation")
@@ -0,0 +1,199 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"orderin-server/pkg/common/constant"
+	mcontext "orderin-server/pkg/common/context"
+	"orderin-server/pkg/common/dynconfig"
+)
+
+// ConfigPrefix 开关以 json 类型的动态配置保存，配置名为 feature.<flag>
+const ConfigPrefix = "feature."
+
+// Schema 开关规则的 JSON-schema
+const Schema = `{
+	"type": "object",
+	"required": ["enabled"],
+	"properties": {
+		"enabled": {"type": "boolean"},
+		"percentage": {"type": "integer", "minimum": 0, "maximum": 100},
+		"platforms": {"type": "array", "items": {"type": "integer"}},
+		"allow": {"$ref": "#/definitions/list"},
+		"deny": {"$ref": "#/definitions/list"}
+	},
+	"definitions": {
+		"list": {
+			"type": "object",
+			"properties": {
+				"users": {"type": "array", "items": {"type": "string"}},
+				"roles": {"type": "array", "items": {"type": "string"}},
+				"tenants": {"type": "array", "items": {"type": "string"}}
+			}
+		}
+	}
+}`
+
+// Rule 开关规则。判断顺序：总开关 -> 黑名单 -> 白名单 -> 平台 -> 按用户id灰度
+type Rule struct {
+	Enabled    bool  `json:"enabled"`              // 总开关，关闭时对所有人关闭
+	Percentage *int  `json:"percentage,omitempty"` // 灰度比例 0-100，为空表示100
+	Platforms  []int `json:"platforms,omitempty"`  // 限定平台，见 constant 中的 PlatformID，为空表示不限
+	Allow      List  `json:"allow"`                // 白名单，命中时直接开启（平台、灰度不再判断）
+	Deny       List  `json:"deny"`                 // 黑名单，命中时关闭
+}
+
+// List 用户、角色、租户名单
+type List struct {
+	Users   []string `json:"users,omitempty"` // 用户id
+	Roles   []string `json:"roles,omitempty"`
+	Tenants []string `json:"tenants,omitempty"`
+}
+
+// Target 判断开关的对象
+type Target struct {
+	UserID     int64
+	Roles      []string
+	Tenant     string
+	PlatformID int
+}
+
+// ConfigName 开关对应的配置名
+func ConfigName(flag string) string {
+	return ConfigPrefix + flag
+}
+
+// Register 注册开关及缺省状态，对应的动态配置按 Schema 校验
+func Register(flag string, enabled bool, description string) {
+	dynconfig.Register(dynconfig.Definition{
+		Name:        ConfigName(flag),
+		ValueType:   dynconfig.TypeJSON,
+		Default:     `{"enabled":` + strconv.FormatBool(enabled) + `}`,
+		Description: description,
+		Schema:      Schema,
+	})
+}
+
+// Parse 解析开关规则
+func Parse(value string) (*Rule, error) {
+	var rule Rule
+	if err := json.Unmarshal([]byte(value), &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Evaluate 判断开关对 target 是否开启
+func (r *Rule) Evaluate(flag string, target Target) bool {
+	if r == nil || !r.Enabled {
+		return false
+	}
+	if r.Deny.match(target) {
+		return false
+	}
+	if r.Allow.match(target) {
+		return true
+	}
+	if len(r.Platforms) > 0 && !containsInt(r.Platforms, target.PlatformID) {
+		return false
+	}
+	if r.Percentage == nil || *r.Percentage >= 100 {
+		return true
+	}
+	// 匿名用户无法稳定分桶，只在全量时开启
+	if target.UserID == 0 {
+		return false
+	}
+	return Bucket(flag, target.UserID) < *r.Percentage
+}
+
+// Bucket 按开关名和用户id稳定地分到 0-99 的桶中，同一用户在不同开关中的桶互不相关
+func Bucket(flag string, userID int64) int {
+	h := fnv.New32a()
+	h.Write([]byte(flag + ":" + strconv.FormatInt(userID, 10)))
+	return int(h.Sum32() % 100)
+}
+
+func (l List) match(target Target) bool {
+	if target.UserID != 0 && containsString(l.Users, strconv.FormatInt(target.UserID, 10)) {
+		return true
+	}
+	if target.Tenant != "" && containsString(l.Tenants, target.Tenant) {
+		return true
+	}
+	for _, role := range target.Roles {
+		if containsString(l.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+type targetKey struct{}
+
+var targetFunc func(ctx context.Context, target *Target)
+
+// SetTargetFunc 设置为登录用户补充角色、租户的方法，TargetFromContext 和 Resolve 会调用
+func SetTargetFunc(fn func(ctx context.Context, target *Target)) {
+	targetFunc = fn
+}
+
+// WithTarget 在 ctx 中设置判断开关的对象，用于补充角色、租户等 ctx 中没有的信息
+func WithTarget(ctx context.Context, target Target) context.Context {
+	return context.WithValue(ctx, targetKey{}, target)
+}
+
+// TargetFromContext 优先取 WithTarget 设置的对象，否则取 ctx 中登录用户的id和平台，并用 Resolve 补充角色和租户
+func TargetFromContext(ctx context.Context) Target {
+	if target, ok := ctx.Value(targetKey{}).(Target); ok {
+		return target
+	}
+	target := Target{UserID: mcontext.GetOpUserID(ctx)}
+	if platform, ok := ctx.Value(constant.OpUserPlatform).(string); ok {
+		target.PlatformID = constant.PlatformNameToID(platform)
+	}
+	return Resolve(ctx, target)
+}
+
+// Resolve 用 SetTargetFunc 设置的方法为登录用户补充角色和租户，已有角色或租户时不处理
+func Resolve(ctx context.Context, target Target) Target {
+	if targetFunc != nil && target.UserID != 0 && len(target.Roles) == 0 && target.Tenant == "" {
+		targetFunc(ctx, &target)
+	}
+	return target
+}
+
+// IsEnabled 判断开关对 ctx 中的对象是否开启，对象见 TargetFromContext
+func (r *Rule) IsEnabled(ctx context.Context, flag string) bool {
+	return r.Evaluate(flag, TargetFromContext(ctx))
+}
+
+// FlagName 从配置名中取开关名，不是开关时返回 false
+func FlagName(configName string) (string, bool) {
+	if !strings.HasPrefix(configName, ConfigPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(configName, ConfigPrefix), true
+}
@@ -0,0 +1,125 @@
+package featureflag
+
+import (
+	"context"
+	"testing"
+
+	"orderin-server/pkg/common/constant"
+	"orderin-server/pkg/common/dynconfig"
+)
+
+func TestEvaluate(t *testing.T) {
+	rule, err := Parse(`{"enabled":true,"percentage":0,"platforms":[1,2],
+		"allow":{"users":["100"],"roles":["tester"]},"deny":{"users":["200"],"tenants":["t1"]}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name   string
+		target Target
+		want   bool
+	}{
+		{"allowed user", Target{UserID: 100, PlatformID: constant.WebPlatformID}, true},
+		{"allowed role", Target{UserID: 1, Roles: []string{"tester"}}, true},
+		{"denied user", Target{UserID: 200, Roles: []string{"tester"}}, false},
+		{"denied tenant", Target{UserID: 100, Tenant: "t1"}, false},
+		{"zero percentage", Target{UserID: 1, PlatformID: constant.IOSPlatformID}, false},
+	}
+	for _, c := range cases {
+		if got := rule.Evaluate("f", c.target); got != c.want {
+			t.Errorf("%s: Evaluate() = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	full, _ := Parse(`{"enabled":true,"platforms":[1]}`)
+	if !full.Evaluate("f", Target{PlatformID: constant.IOSPlatformID}) || full.Evaluate("f", Target{PlatformID: constant.WebPlatformID}) {
+		t.Errorf("platform targeting not applied")
+	}
+	disabled, _ := Parse(`{"enabled":false,"allow":{"users":["100"]}}`)
+	if disabled.Evaluate("f", Target{UserID: 100}) {
+		t.Errorf("disabled flag should be off for everyone")
+	}
+	var missing *Rule
+	if missing.Evaluate("f", Target{UserID: 100}) {
+		t.Errorf("missing flag should be off")
+	}
+}
+
+func TestPercentage(t *testing.T) {
+	rule, _ := Parse(`{"enabled":true,"percentage":30}`)
+	var enabled int
+	for id := int64(1); id <= 10000; id++ {
+		got := rule.Evaluate("rollout", Target{UserID: id})
+		if got != rule.Evaluate("rollout", Target{UserID: id}) {
+			t.Fatalf("Evaluate() is not stable for user %d", id)
+		}
+		if got {
+			enabled++
+		}
+	}
+	if enabled < 2700 || enabled > 3300 {
+		t.Errorf("enabled %d of 10000 users, want about 3000", enabled)
+	}
+	if rule.Evaluate("rollout", Target{}) {
+		t.Errorf("anonymous user should be off before full rollout")
+	}
+}
+
+func TestRegisterAndContext(t *testing.T) {
+	Register("test_flag", true, "test")
+	if _, err := dynconfig.Validate(ConfigName("test_flag"), dynconfig.TypeJSON, `{"enabled":true,"percentage":101}`); err == nil {
+		t.Errorf("percentage greater than 100 should be rejected by schema")
+	}
+	if _, err := dynconfig.Validate(ConfigName("test_flag"), dynconfig.TypeJSON, `{"enabled":true,"allow":{"users":["1"]}}`); err != nil {
+		t.Errorf("valid rule rejected: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), constant.OpUserID, int64(7))
+	ctx = context.WithValue(ctx, constant.OpUserPlatform, constant.AndroidPlatformStr)
+	if target := TargetFromContext(ctx); target.UserID != 7 || target.PlatformID != constant.AndroidPlatformID {
+		t.Errorf("TargetFromContext() = %+v", target)
+	}
+	ctx = WithTarget(ctx, Target{UserID: 8, Tenant: "t"})
+	if target := TargetFromContext(ctx); target.UserID != 8 || target.Tenant != "t" {
+		t.Errorf("TargetFromContext() with WithTarget = %+v", target)
+	}
+}
+
+func TestIsEnabledResolveTarget(t *testing.T) {
+	SetTargetFunc(func(ctx context.Context, target *Target) {
+		switch target.UserID {
+		case 1:
+			target.Roles = []string{"tester"}
+		case 2:
+			target.Roles = []string{"tester"}
+			target.Tenant = "t1"
+		}
+	})
+	defer SetTargetFunc(nil)
+
+	rule, _ := Parse(`{"enabled":true,"percentage":0,"allow":{"roles":["tester"]},"deny":{"tenants":["t1"]}}`)
+	cases := []struct {
+		name   string
+		userID int64
+		want   bool
+	}{
+		{"allowed role", 1, true},
+		{"denied tenant", 2, false},
+		{"no role", 3, false},
+		{"anonymous", 0, false},
+	}
+	for _, c := range cases {
+		ctx := context.WithValue(context.Background(), constant.OpUserID, c.userID)
+		if got := rule.IsEnabled(ctx, "f"); got != c.want {
+			t.Errorf("%s: IsEnabled() = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	ctx := WithTarget(context.Background(), Target{UserID: 1})
+	if rule.IsEnabled(ctx, "f") {
+		t.Errorf("target set by WithTarget should not be resolved")
+	}
+	if target := Resolve(context.Background(), Target{UserID: 1}); len(target.Roles) != 1 {
+		t.Errorf("Resolve() = %+v", target)
+	}
+}
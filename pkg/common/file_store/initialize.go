@@ -1,8 +1,11 @@
 package file_store
 
 import (
-	"fmt"
+	"context"
 	"orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/log"
+	"reflect"
+	"sync/atomic"
 )
 
 type OXS struct {
@@ -18,45 +21,70 @@ type OXS struct {
 	Domain string
 }
 
-var FileStore FileStoreType
+// fileStoreHolder atomic.Value 不能保存 nil 接口，包一层
+type fileStoreHolder struct {
+	store FileStoreType
+}
+
+var currentFileStore atomic.Value
+
+// GetFileStore 返回当前的文件存储客户端，配置热加载后会替换为新客户端，已取得旧客户端的请求不受影响
+func GetFileStore() FileStoreType {
+	holder, _ := currentFileStore.Load().(fileStoreHolder)
+	return holder.store
+}
 
 func InitFileStore() {
-	e := OXS{
-		Endpoint:        config.Config.FileStore.Endpoint,
-		AccessKeyID:     config.Config.FileStore.AccessKeyID,
-		AccessKeySecret: config.Config.FileStore.AccessKeySecret,
-		BucketName:      config.Config.FileStore.BucketName,
-		Domain:          config.Config.FileStore.Domain,
+	store, err := newOXS(config.Get()).build(DriverType(config.Get().FileStore.DriverType))
+	if err != nil {
+		log.ZError(context.Background(), "build file store failed", err, "driverType", config.Get().FileStore.DriverType)
+	}
+	currentFileStore.Store(fileStoreHolder{store: store})
+
+	config.Subscribe("file_store", func(old, new *config.Snapshot) {
+		if reflect.DeepEqual(old.FileStore, new.FileStore) {
+			return
+		}
+		store, err := newOXS(new).build(DriverType(new.FileStore.DriverType))
+		if err != nil {
+			log.ZError(context.Background(), "rebuild file store failed, keep the old one", err)
+			return
+		}
+		currentFileStore.Store(fileStoreHolder{store: store})
+		log.ZInfo(context.Background(), "file store rebuilt", "driverType", new.FileStore.DriverType)
+	})
+}
+
+func newOXS(c *config.Snapshot) *OXS {
+	return &OXS{
+		Endpoint:        c.FileStore.Endpoint,
+		AccessKeyID:     c.FileStore.AccessKeyID,
+		AccessKeySecret: c.FileStore.AccessKeySecret,
+		BucketName:      c.FileStore.BucketName,
+		Domain:          c.FileStore.Domain,
 	}
-	FileStore = e.Setup(DriverType(config.Config.FileStore.DriverType))
 }
 
 // Setup 配置文件存储driver
 func (e *OXS) Setup(driver DriverType, options ...ClientOption) FileStoreType {
-	fileStoreType := driver
+	fileStore, err := e.build(driver)
+	if err != nil {
+		log.ZError(context.Background(), "build file store failed", err, "driverType", driver)
+	}
+	return fileStore
+}
+
+func (e *OXS) build(driver DriverType) (FileStoreType, error) {
 	var fileStore FileStoreType
-	switch fileStoreType {
+	switch driver {
 	case AliYunOSS:
 		fileStore = new(ALiYunOSS)
-		err := fileStore.Setup(e.Endpoint, e.AccessKeyID, e.AccessKeySecret, e.BucketName, e.Domain)
-		if err != nil {
-			fmt.Println(err)
-		}
-		return fileStore
 	case HuaweiOBS:
 		fileStore = new(HuaWeiOBS)
-		err := fileStore.Setup(e.Endpoint, e.AccessKeyID, e.AccessKeySecret, e.BucketName, e.Domain)
-		if err != nil {
-			fmt.Println(err)
-		}
-		return fileStore
 	case QiNiuKodo:
 		fileStore = new(QiNiuKODO)
-		err := fileStore.Setup(e.Endpoint, e.AccessKeyID, e.AccessKeySecret, e.BucketName, e.Domain)
-		if err != nil {
-			fmt.Println(err)
-		}
-		return fileStore
+	default:
+		return nil, nil
 	}
-	return nil
+	return fileStore, fileStore.Setup(e.Endpoint, e.AccessKeyID, e.AccessKeySecret, e.BucketName, e.Domain)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 	"io"
 	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/tracing"
 )
 
 type ALiYunOSS struct {
@@ -17,7 +18,7 @@ type ALiYunOSS struct {
 // Setup 装载
 // endpoint
 func (e *ALiYunOSS) Setup(endpoint, accessKeyID, accessKeySecret, bucketName string, domain string, options ...ClientOption) error {
-	client, err := oss.New(endpoint, accessKeyID, accessKeySecret)
+	client, err := oss.New(endpoint, accessKeyID, accessKeySecret, oss.HTTPClient(tracing.NewHTTPClient()))
 	if err != nil {
 		log.ZError(context.Background(), "set up aliyun oss client fail", err)
 		return err
@@ -15,6 +15,6 @@ func Logger() gin.HandlerFunc {
 		// 计算处理时间
 		cost := time.Since(start)
 		// 打印请求信息
-		log.ZInfo(c, "http request log", "uri", c.Request.RequestURI, "cost", cost.Milliseconds(), "method", c.Request.Method)
+		log.Http.Info(c, "http request log", "uri", c.Request.RequestURI, "cost", cost.Milliseconds(), "method", c.Request.Method)
 	}
 }
@@ -0,0 +1,33 @@
+package ginmiddleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"orderin-server/pkg/common/constant"
+	"orderin-server/pkg/common/prommetrics"
+	"strconv"
+	"time"
+)
+
+// Metrics 按路由模板统计请求数、耗时和业务错误数
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := metricsRoute(c)
+		method := c.Request.Method
+		prommetrics.HttpRequestsTotal.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		prommetrics.HttpRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		if code, ok := c.Get(constant.RespCode); ok && code != 0 {
+			prommetrics.HttpBizErrorsTotal.WithLabelValues(method, route).Inc()
+		}
+	}
+}
+
+// metricsRoute 使用路由模板而不是原始路径，避免label基数失控
+func metricsRoute(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return prommetrics.RouteUnmatched
+}
@@ -0,0 +1,27 @@
+package ginmiddleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"net/http/httptest"
+	"orderin-server/pkg/common/prommetrics"
+	"testing"
+)
+
+func TestMetricsUseRouteTemplate(t *testing.T) {
+	r := gin.New()
+	r.Use(Metrics())
+	r.GET("/user/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	for _, path := range []string{"/user/1", "/user/2", "/not_found"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	if got := testutil.ToFloat64(prommetrics.HttpRequestsTotal.WithLabelValues(http.MethodGet, "/user/:id", "200")); got != 2 {
+		t.Errorf("requests of /user/:id = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(prommetrics.HttpRequestsTotal.WithLabelValues(http.MethodGet, prommetrics.RouteUnmatched, "404")); got != 1 {
+		t.Errorf("requests of unmatched route = %v, want 1", got)
+	}
+}
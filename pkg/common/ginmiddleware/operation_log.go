@@ -0,0 +1,129 @@
+package ginmiddleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"io"
+	"orderin-server/internal/components"
+	"orderin-server/internal/models"
+	"orderin-server/pkg/common/application"
+	"orderin-server/pkg/common/constant"
+	mcontext "orderin-server/pkg/common/context"
+	"orderin-server/pkg/common/log"
+	"strings"
+	"time"
+)
+
+const (
+	maxOperationLogBodyLength = 2000
+	redactedValue             = log.Masked
+)
+
+var (
+	// 只读接口的路径后缀前缀，命中则不记录操作日志
+	readOnlyActionPrefixes = []string{"page_query", "get_", "batch_get_", "should_"}
+	// 请求体中除 log.IsSensitiveKey 覆盖的字段外，还需脱敏的字段（转为小写并去掉 _ - 后完全匹配），如验证码。
+	// 不按包含匹配，避免 dictCode、templateCode 等业务字段被脱敏
+	sensitiveBodyKeys = map[string]bool{
+		"code":        true,
+		"smscode":     true,
+		"emailcode":   true,
+		"mfacode":     true,
+		"recovercode": true,
+		"captcha":     true,
+		"accesskey":   true,
+	}
+)
+
+// OperationLog 记录写操作的审计日志，日志经由 components.OperationLogWriter 异步批量落库
+func OperationLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isReadOnlyAction(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+		body := readRequestBody(c)
+		start := time.Now()
+		c.Next()
+
+		writer, ok := application.AppContext.GetComponent(components.COMPONENT_OPERATION_LOG).(*components.OperationLogWriter)
+		if !ok {
+			return
+		}
+		writer.Write(models.SysOperationLog{
+			UserID:    mcontext.GetOpUserID(c),
+			Platform:  c.GetString(constant.OpUserPlatform),
+			Ip:        c.RemoteIP(),
+			RequestId: mcontext.GetRequestId(c),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+			Body:      body,
+			RespCode:  c.GetInt(constant.RespCode),
+			Latency:   time.Since(start).Milliseconds(),
+		})
+	}
+}
+
+func isReadOnlyAction(path string) bool {
+	action := path[strings.LastIndex(path, "/")+1:]
+	for _, prefix := range readOnlyActionPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readRequestBody 读取并回填请求体，只保留脱敏后的json
+func readRequestBody(c *gin.Context) string {
+	if c.Request.Body == nil || !strings.HasPrefix(c.ContentType(), "application/json") {
+		return ""
+	}
+	data, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewBuffer(data))
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	var payload interface{}
+	if err = json.Unmarshal(data, &payload); err != nil {
+		return ""
+	}
+	redacted, err := json.Marshal(redactSensitive(payload))
+	if err != nil {
+		return ""
+	}
+	if len(redacted) > maxOperationLogBodyLength {
+		return string(redacted[:maxOperationLogBodyLength])
+	}
+	return string(redacted)
+}
+
+func redactSensitive(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactSensitive(item)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactSensitive(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	if log.IsSensitiveKey(key) {
+		return true
+	}
+	key = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return sensitiveBodyKeys[key]
+}
@@ -0,0 +1,37 @@
+package ginmiddleware
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIsReadOnlyAction(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/api/v1/sys/user/page_query", true},
+		{"/api/v1/sys/dict/item/get_by_name", true},
+		{"/api/v1/sys/dict/item/batch_get_by_names", true},
+		{"/api/v1/sys/user/add", false},
+		{"/api/v1/sys/config/update", false},
+	}
+	for _, tt := range tests {
+		if got := isReadOnlyAction(tt.path); got != tt.want {
+			t.Errorf("isReadOnlyAction(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRedactSensitive(t *testing.T) {
+	var payload interface{}
+	body := `{"username":"admin","password":"123456","dictCode":"sex","list":[{"smsCode":"8888","name":"x","mfa_code":"1"}]}`
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(redactSensitive(payload))
+	want := `{"dictCode":"sex","list":[{"mfa_code":"******","name":"x","smsCode":"******"}],"password":"******","username":"admin"}`
+	if string(data) != want {
+		t.Errorf("redactSensitive() = %s, want %s", data, want)
+	}
+}
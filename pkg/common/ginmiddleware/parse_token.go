@@ -81,6 +81,6 @@ func ParseToken(authService auth.AuthService, rdb redis.UniversalClient, exclude
 
 func Secret() jwt.Keyfunc {
 	return func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.Config.Secret), nil
+		return []byte(config.Get().Secret), nil
 	}
 }
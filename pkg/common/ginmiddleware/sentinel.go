@@ -1,29 +1,48 @@
 package ginmiddleware
 
 import (
+	"context"
 	"github.com/alibaba/sentinel-golang/core/system"
 	sentinel "github.com/alibaba/sentinel-golang/pkg/adapters/gin"
 	"github.com/gin-gonic/gin"
-	"log"
+	"orderin-server/pkg/common/api"
+	"orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/log"
+	"orderin-server/pkg/common/prommetrics"
 )
 
-// Sentinel 限流
+// Sentinel 限流，阈值修改后随配置热加载生效
 func Sentinel() gin.HandlerFunc {
-	if _, err := system.LoadRules([]*system.Rule{
-		{
-			MetricType:   system.InboundQPS,
-			TriggerCount: 200,
-			Strategy:     system.BBR,
-		},
-	}); err != nil {
-		log.Fatalf("Unexpected error: %+v", err)
+	if err := loadSentinelRules(config.Get().Sentinel.InboundQps); err != nil {
+		panic(err)
 	}
+	config.Subscribe("sentinel", func(old, new *config.Snapshot) {
+		if old.Sentinel == new.Sentinel {
+			return
+		}
+		if err := loadSentinelRules(new.Sentinel.InboundQps); err != nil {
+			log.ZError(context.Background(), "reload sentinel rules failed", err)
+			return
+		}
+		log.ZInfo(context.Background(), "sentinel rules reloaded", "inboundQps", new.Sentinel.InboundQps)
+	})
 	return sentinel.SentinelMiddleware(
 		sentinel.WithBlockFallback(func(ctx *gin.Context) {
-			ctx.AbortWithStatusJSON(200, map[string]interface{}{
-				"msg":  "too many request; the quota used up!",
-				"code": 500,
-			})
+			prommetrics.SentinelBlockedTotal.WithLabelValues(metricsRoute(ctx)).Inc()
+			api.GinError(ctx, errs.ErrTooManyRequests)
+			ctx.Abort()
 		}),
 	)
 }
+
+func loadSentinelRules(inboundQps float64) error {
+	_, err := system.LoadRules([]*system.Rule{
+		{
+			MetricType:   system.InboundQPS,
+			TriggerCount: inboundQps,
+			Strategy:     system.BBR,
+		},
+	})
+	return err
+}
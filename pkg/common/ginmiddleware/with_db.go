@@ -3,10 +3,13 @@ package ginmiddleware
 import (
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"orderin-server/pkg/common/db/relation"
 )
 
+// WithContextDb 把绑定请求上下文的 db 放入 gin.Context，请求中写入之后的查询走主库
 func WithContextDb(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(relation.WithPrimarySticky(c.Request.Context()))
 		c.Set("db", db.WithContext(c))
 		c.Next()
 	}
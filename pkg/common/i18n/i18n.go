@@ -0,0 +1,159 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+	"orderin-server/pkg/common/constant"
+)
+
+// DefaultLocale 代码中的中文文案即为该语言，没有匹配的语言时使用
+const DefaultLocale = "zh-CN"
+
+// localeKey 解析出的语言缓存在 gin.Context 中的 key
+const localeKey = "locale"
+
+// Catalog 某个语言的文案：Codes 按错误码提供通用文案，Messages 按原文（代码中的文案）提供译文
+type Catalog struct {
+	Codes    map[int]string    `yaml:"codes"`
+	Messages map[string]string `yaml:"messages"`
+}
+
+//go:embed locales/*.yaml
+var localeFiles embed.FS
+
+var (
+	mu       sync.RWMutex
+	catalogs = map[string]*Catalog{}
+	// matcher 的候选语言，与 matchLocales 一一对应，默认语言在首位
+	matcher      language.Matcher
+	matchLocales []string
+	userLocale   func(ctx context.Context, userID int64) string
+)
+
+func init() {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var catalog Catalog
+		if err := yaml.Unmarshal(data, &catalog); err != nil {
+			panic("invalid locale file " + entry.Name() + ": " + err.Error())
+		}
+		Register(strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())), &catalog)
+	}
+}
+
+// Register 注册或合并某个语言的文案，同名的条目覆盖已有的
+func Register(locale string, catalog *Catalog) {
+	mu.Lock()
+	defer mu.Unlock()
+	existing, ok := catalogs[locale]
+	if !ok {
+		existing = &Catalog{Codes: map[int]string{}, Messages: map[string]string{}}
+		catalogs[locale] = existing
+		if locale != DefaultLocale {
+			matchLocales = append(matchLocales, locale)
+		}
+	}
+	for code, msg := range catalog.Codes {
+		existing.Codes[code] = msg
+	}
+	for key, msg := range catalog.Messages {
+		existing.Messages[key] = msg
+	}
+	tags := []language.Tag{language.Make(DefaultLocale)}
+	for _, l := range matchLocales {
+		tags = append(tags, language.Make(l))
+	}
+	matcher = language.NewMatcher(tags)
+}
+
+// Supported 是否支持该语言
+func Supported(locale string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := catalogs[locale]
+	return ok || locale == DefaultLocale
+}
+
+// Locales 支持的语言，默认语言在首位
+func Locales() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]string{DefaultLocale}, matchLocales...)
+}
+
+// SetUserLocaleFunc 设置获取用户偏好语言的方法，返回空表示没有设置
+func SetUserLocaleFunc(fn func(ctx context.Context, userID int64) string) {
+	userLocale = fn
+}
+
+// Locale 请求使用的语言：登录用户的偏好语言 -> Accept-Language -> DefaultLocale
+func Locale(c *gin.Context) string {
+	if c == nil {
+		return DefaultLocale
+	}
+	if locale := c.GetString(localeKey); locale != "" {
+		return locale
+	}
+	locale := ""
+	if userID := c.GetInt64(constant.OpUserID); userID != 0 && userLocale != nil {
+		if l := userLocale(c, userID); l != "" && Supported(l) {
+			locale = l
+		}
+	}
+	if locale == "" {
+		locale = Match(c.GetHeader("Accept-Language"))
+	}
+	c.Set(localeKey, locale)
+	return locale
+}
+
+// Match 从 Accept-Language 中选出支持的语言
+func Match(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return DefaultLocale
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No || index == 0 {
+		return DefaultLocale
+	}
+	return matchLocales[index-1]
+}
+
+// Message 错误文案：有原文的译文时返回译文；否则非默认语言返回错误码的通用文案，避免出现其他语言；都没有时返回原文
+func Message(locale string, code int, msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	catalog, ok := catalogs[locale]
+	if !ok {
+		return msg
+	}
+	if translated, ok := catalog.Messages[msg]; ok {
+		return translated
+	}
+	if locale != DefaultLocale || msg == "" {
+		if translated, ok := catalog.Codes[code]; ok {
+			return translated
+		}
+	}
+	return msg
+}
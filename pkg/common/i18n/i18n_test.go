@@ -0,0 +1,43 @@
+package i18n
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	cases := map[string]string{
+		"":                        DefaultLocale,
+		"en-US,en;q=0.9":          "en-US",
+		"en":                      "en-US",
+		"zh":                      DefaultLocale,
+		"fr-FR,en;q=0.5":          "en-US",
+		"fr-FR":                   DefaultLocale,
+		"zh-CN,zh;q=0.9,en;q=0.8": DefaultLocale,
+		"invalid;;":               DefaultLocale,
+	}
+	for header, want := range cases {
+		if got := Match(header); got != want {
+			t.Errorf("Match(%q) = %s, want %s", header, got, want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	cases := []struct {
+		locale string
+		code   int
+		msg    string
+		want   string
+	}{
+		{DefaultLocale, 1001, "账号或密码错误", "账号或密码错误"},
+		{DefaultLocale, 400, "ArgsError", "参数错误"},
+		{DefaultLocale, 404, "字典不存在：abc", "字典不存在：abc"},
+		{"en-US", 1001, "账号或密码错误", "Incorrect account or password"},
+		{"en-US", 404, "字典不存在：abc", "Record not found"},
+		{"en-US", 9999, "未知错误", "未知错误"},
+		{"ja-JP", 1001, "账号或密码错误", "账号或密码错误"},
+	}
+	for _, c := range cases {
+		if got := Message(c.locale, c.code, c.msg); got != c.want {
+			t.Errorf("Message(%s, %d, %q) = %q, want %q", c.locale, c.code, c.msg, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// ModuleRoot 根logger，即 ZDebug/ZInfo/ZWarn/ZError 使用的logger
+	ModuleRoot = "root"
+)
+
+// 具名子logger，未单独设置级别时跟随根logger
+const (
+	Sql    NamedLogger = "sql"
+	Http   NamedLogger = "http"
+	Wechat NamedLogger = "wechat"
+)
+
+var modules = []string{ModuleRoot, string(Sql), string(Http), string(Wechat)}
+
+// moduleLevel 每个logger的级别，atomic 用于构建该logger的 zap core，修改级别即 SetLevel，输出日志时不加锁。
+// 其余字段记录运行时的设置，只在修改级别时读写
+type moduleLevel struct {
+	atomic   zap.AtomicLevel
+	level    zapcore.Level
+	override bool
+	expireAt time.Time
+	timer    *time.Timer
+	gen      uint64 // 每次 SetLevel 加一，避免过期的定时器撤销掉后来的设置
+}
+
+// LevelInfo 某个logger当前的级别
+type LevelInfo struct {
+	Module   string     `json:"module"`
+	Level    string     `json:"level"`
+	Override bool       `json:"override"`
+	ExpireAt *time.Time `json:"expireAt"`
+}
+
+var (
+	levelMu      sync.Mutex
+	defaultLevel = zapcore.InfoLevel
+	levels       = newLevels()
+)
+
+func newLevels() map[string]*moduleLevel {
+	m := make(map[string]*moduleLevel, len(modules))
+	for _, module := range modules {
+		m[module] = &moduleLevel{atomic: zap.NewAtomicLevelAt(zapcore.InfoLevel)}
+	}
+	return m
+}
+
+// atomicLevel 返回logger的级别，modules 之外的名称跟随根logger
+func atomicLevel(module string) zap.AtomicLevel {
+	if l, ok := levels[module]; ok {
+		return l.atomic
+	}
+	return levels[ModuleRoot].atomic
+}
+
+func initLevels(level zapcore.Level) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	for _, l := range levels {
+		if l.timer != nil {
+			l.timer.Stop()
+		}
+		l.override, l.expireAt, l.timer = false, time.Time{}, nil
+	}
+	defaultLevel = level
+	applyLevels()
+}
+
+// applyLevels 按运行时设置和配置文件中的级别更新各logger的级别，调用方需持有 levelMu
+func applyLevels() {
+	root := defaultLevel
+	if l := levels[ModuleRoot]; l.override {
+		root = l.level
+	}
+	for _, l := range levels {
+		if l.override {
+			l.atomic.SetLevel(l.level)
+		} else {
+			l.atomic.SetLevel(root)
+		}
+	}
+}
+
+// SetDefaultLevel 修改配置文件中的级别，配置热加载时调用，不影响运行时通过 SetLevel 设置的级别
+func SetDefaultLevel(logLevel int) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	defaultLevel = logLevelMap[logLevel]
+	applyLevels()
+}
+
+// SetLevel 运行时修改logger级别，ttl大于0时到期后自动恢复为配置文件中的级别
+func SetLevel(module string, level string, ttl time.Duration) error {
+	if module == "" {
+		module = ModuleRoot
+	}
+	if err := CheckLevel(module, level); err != nil {
+		return err
+	}
+	var zapLevel zapcore.Level
+	_ = zapLevel.UnmarshalText([]byte(level))
+
+	levelMu.Lock()
+	l := levels[module]
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.gen++
+	l.level, l.override, l.expireAt, l.timer = zapLevel, true, time.Time{}, nil
+	if ttl > 0 {
+		gen := l.gen
+		l.expireAt = time.Now().Add(ttl)
+		l.timer = time.AfterFunc(ttl, func() {
+			if resetLevel(module, gen) {
+				ZInfo(context.Background(), "log level override expired", "module", module)
+			}
+		})
+	}
+	applyLevels()
+	levelMu.Unlock()
+
+	ZInfo(context.Background(), "log level changed", "module", module, "level", zapLevel.String(), "ttl", ttl.String())
+	return nil
+}
+
+// CheckLevel 校验logger名称和级别是否合法
+func CheckLevel(module string, level string) error {
+	if module != "" && !isKnownModule(module) {
+		return fmt.Errorf("unknown logger module: %s", module)
+	}
+	var zapLevel zapcore.Level
+	return zapLevel.UnmarshalText([]byte(level))
+}
+
+// ResetLevel 撤销运行时设置的级别
+func ResetLevel(module string) error {
+	if module == "" {
+		module = ModuleRoot
+	}
+	if !isKnownModule(module) {
+		return fmt.Errorf("unknown logger module: %s", module)
+	}
+	resetLevel(module, 0)
+	return nil
+}
+
+// resetLevel gen不为0时，只有当前设置仍是第gen次 SetLevel 的设置才撤销
+func resetLevel(module string, gen uint64) bool {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	l := levels[module]
+	if !l.override || (gen != 0 && l.gen != gen) {
+		return false
+	}
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.override, l.expireAt, l.timer = false, time.Time{}, nil
+	applyLevels()
+	return true
+}
+
+// Levels 返回所有logger当前的级别
+func Levels() []LevelInfo {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	result := make([]LevelInfo, 0, len(modules))
+	for _, module := range modules {
+		l := levels[module]
+		info := LevelInfo{Module: module, Level: l.atomic.Level().String(), Override: l.override}
+		if l.override && !l.expireAt.IsZero() {
+			expireAt := l.expireAt
+			info.ExpireAt = &expireAt
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+func isKnownModule(module string) bool {
+	for _, m := range modules {
+		if m == module {
+			return true
+		}
+	}
+	return false
+}
+
+// NamedLogger 具名子logger，级别可单独调整
+type NamedLogger string
+
+var namedLoggers sync.Map
+
+func (n NamedLogger) logger() Logger {
+	if pkgLogger == nil {
+		return nil
+	}
+	if l, ok := namedLoggers.Load(n); ok {
+		return l.(Logger)
+	}
+	zl, ok := pkgLogger.(*ZapLogger)
+	if !ok {
+		return pkgLogger
+	}
+	l, _ := namedLoggers.LoadOrStore(n, zl.withLevel(atomicLevel(string(n))).WithName(string(n)))
+	return l.(Logger)
+}
+
+// Enabled 判断该logger当前是否会输出指定级别的日志
+func (n NamedLogger) Enabled(level zapcore.Level) bool {
+	return atomicLevel(string(n)).Enabled(level)
+}
+
+func (n NamedLogger) Debug(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	if l := n.logger(); l != nil {
+		l.Debug(ctx, msg, keysAndValues...)
+	}
+}
+
+func (n NamedLogger) Info(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	if l := n.logger(); l != nil {
+		l.Info(ctx, msg, keysAndValues...)
+	}
+}
+
+func (n NamedLogger) Warn(ctx context.Context, msg string, err error, keysAndValues ...interface{}) {
+	if l := n.logger(); l != nil {
+		l.Warn(ctx, msg, err, keysAndValues...)
+	}
+}
+
+func (n NamedLogger) Error(ctx context.Context, msg string, err error, keysAndValues ...interface{}) {
+	if l := n.logger(); l != nil {
+		l.Error(ctx, msg, err, keysAndValues...)
+	}
+}
@@ -0,0 +1,71 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSetLevel(t *testing.T) {
+	initLevels(zapcore.InfoLevel)
+	if Sql.Enabled(zapcore.DebugLevel) {
+		t.Fatal("sql logger should inherit info level")
+	}
+	if err := SetLevel(string(Sql), "debug", 0); err != nil {
+		t.Fatal(err)
+	}
+	if !Sql.Enabled(zapcore.DebugLevel) || Http.Enabled(zapcore.DebugLevel) {
+		t.Fatal("only sql logger should be debug")
+	}
+	if err := SetLevel(ModuleRoot, "error", 0); err != nil {
+		t.Fatal(err)
+	}
+	if Http.Enabled(zapcore.WarnLevel) || !Sql.Enabled(zapcore.DebugLevel) {
+		t.Fatal("http logger should follow root, sql logger keeps its own level")
+	}
+	if err := SetLevel("unknown", "debug", 0); err == nil {
+		t.Fatal("unknown module should fail")
+	}
+}
+
+func TestSetLevelTtl(t *testing.T) {
+	initLevels(zapcore.InfoLevel)
+	if err := SetLevel(string(Http), "debug", 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if !Http.Enabled(zapcore.DebugLevel) {
+		t.Fatal("http logger should be debug")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if Http.Enabled(zapcore.DebugLevel) {
+		t.Fatal("http logger should revert after ttl")
+	}
+}
+
+// TestLevelOutput 级别由 core 过滤
+func TestLevelOutput(t *testing.T) {
+	var buf bytes.Buffer
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	zl := &ZapLogger{outputs: []output{{enc, zapcore.AddSync(&buf)}}}
+	zl.zap = zap.New(zl.newCore(zapcore.DebugLevel)).Sugar()
+	initLevels(zapcore.InfoLevel)
+	root, sql := zl.withLevel(atomicLevel(ModuleRoot)), zl.withLevel(atomicLevel(string(Sql)))
+
+	ctx := context.Background()
+	sql.Debug(ctx, "sql before")
+	root.Debug(ctx, "root before")
+	if err := SetLevel(string(Sql), "debug", 0); err != nil {
+		t.Fatal(err)
+	}
+	sql.Debug(ctx, "sql after")
+	root.Debug(ctx, "root after")
+	out := buf.String()
+	if strings.Contains(out, "before") || strings.Contains(out, "root after") || !strings.Contains(out, "sql after") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
@@ -0,0 +1,242 @@
+package log
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Masked 脱敏后的占位值
+const Masked = "******"
+
+// SecretTag 结构体字段标记 `secret:"true"` 后，日志中输出为 Masked
+const SecretTag = "secret"
+
+// 按名称判断敏感字段的关键词，匹配前会转为小写并去掉 _ - . 分隔符
+var sensitiveKeyWords = []string{"password", "passwd", "pwd", "secret", "token", "credential", "privatekey", "apikey", "aeskey", "authorization"}
+
+// 最大递归深度，防止循环引用
+const maxRedactDepth = 10
+
+// IsSensitiveKey 按名称判断是否为敏感字段，如 password、accessKeySecret、x-auth-token
+func IsSensitiveKey(key string) bool {
+	key = normalizeKey(key)
+	for _, word := range sensitiveKeyWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeKey(key string) string {
+	key = strings.ToLower(key)
+	return strings.NewReplacer("_", "", "-", "", ".", "", "`", "", `"`, "").Replace(key)
+}
+
+// Redact 返回脱敏后的副本，结构体中带 secret 标签或名称敏感的字符串字段会被替换为 Masked。
+// 结构体会被转换为 map，字段名依次取 json、yaml 标签和字段名
+func Redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(v), 0)
+}
+
+func redactValue(v reflect.Value, depth int) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if depth > maxRedactDepth {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	if v.CanInterface() {
+		switch v.Interface().(type) {
+		case time.Time, *time.Time, time.Duration, error, fmt.Stringer, encoding.TextMarshaler, json.Marshaler:
+			return v.Interface()
+		}
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem(), depth+1)
+	case reflect.Struct:
+		t := v.Type()
+		out := make(map[string]interface{}, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := fieldName(field)
+			if name == "-" {
+				continue
+			}
+			fv := v.Field(i)
+			if field.Anonymous && fv.Kind() == reflect.Struct {
+				if embedded, ok := redactValue(fv, depth+1).(map[string]interface{}); ok {
+					for k, item := range embedded {
+						out[k] = item
+					}
+					continue
+				}
+			}
+			if field.Tag.Get(SecretTag) == "true" || (IsSensitiveKey(name) && isMaskable(fv)) {
+				out[name] = maskValue(fv)
+				continue
+			}
+			out[name] = redactValue(fv, depth+1)
+		}
+		return out
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			if IsSensitiveKey(key) && isMaskable(iter.Value()) {
+				out[key] = maskValue(iter.Value())
+				continue
+			}
+			out[key] = redactValue(iter.Value(), depth+1)
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		out := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out[i] = redactValue(v.Index(i), depth+1)
+		}
+		return out
+	default:
+		if v.CanInterface() {
+			return v.Interface()
+		}
+		return nil
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "yaml"} {
+		if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// isMaskable 名称敏感时只脱敏字符串等标量，结构体和集合继续递归，避免 tokenPolicy 之类的配置被整体隐藏
+func isMaskable(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return true
+	case reflect.Slice:
+		return v.Type().Elem().Kind() == reflect.Uint8 || v.Type().Elem().Kind() == reflect.String
+	default:
+		return false
+	}
+}
+
+// maskValue 空值保持为空，便于排查是否漏配
+func maskValue(v reflect.Value) interface{} {
+	if !v.IsValid() || v.IsZero() {
+		return ""
+	}
+	return Masked
+}
+
+// redactFields 对 zap 字段脱敏：敏感名称的标量字段替换为 Masked，反射类型字段递归脱敏
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		redacted, changed := redactField(f)
+		if !changed {
+			if out != nil {
+				out = append(out, f)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, i, len(fields))
+			copy(out, fields[:i])
+		}
+		out = append(out, redacted)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+func redactField(f zapcore.Field) (zapcore.Field, bool) {
+	switch f.Type {
+	case zapcore.StringType, zapcore.ByteStringType, zapcore.BinaryType:
+		if IsSensitiveKey(f.Key) {
+			if f.String == "" && f.Interface == nil {
+				return f, false
+			}
+			return zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: Masked}, true
+		}
+	case zapcore.ReflectType:
+		if f.Interface == nil {
+			return f, false
+		}
+		rv := reflect.ValueOf(f.Interface)
+		if IsSensitiveKey(f.Key) && isMaskable(rv) {
+			return zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: fmt.Sprint(maskValue(rv))}, true
+		}
+		return zapcore.Field{Key: f.Key, Type: zapcore.ReflectType, Interface: Redact(f.Interface)}, true
+	}
+	return f, false
+}
+
+// redactEncoder 包装 zap 的 encoder，在编码前对字段脱敏
+type redactEncoder struct {
+	zapcore.Encoder
+}
+
+func newRedactEncoder(enc zapcore.Encoder) zapcore.Encoder {
+	return redactEncoder{Encoder: enc}
+}
+
+func (e redactEncoder) Clone() zapcore.Encoder {
+	return redactEncoder{Encoder: e.Encoder.Clone()}
+}
+
+// AddString With 添加的字段不经过 EncodeEntry，需要单独处理
+func (e redactEncoder) AddString(key, value string) {
+	if value != "" && IsSensitiveKey(key) {
+		value = Masked
+	}
+	e.Encoder.AddString(key, value)
+}
+
+func (e redactEncoder) AddReflected(key string, value interface{}) error {
+	if value != nil && IsSensitiveKey(key) && isMaskable(reflect.ValueOf(value)) {
+		e.Encoder.AddString(key, Masked)
+		return nil
+	}
+	return e.Encoder.AddReflected(key, Redact(value))
+}
+
+func (e redactEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	return e.Encoder.EncodeEntry(entry, redactFields(fields))
+}
@@ -0,0 +1,124 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type redactTestConfig struct {
+	Mysql struct {
+		Username string `yaml:"username"`
+		Password string `yaml:"password" secret:"true"`
+	} `yaml:"mysql"`
+	Secret      string `yaml:"secret" secret:"true"`
+	AppKey      string `yaml:"appKey" secret:"true"`
+	TokenPolicy struct {
+		Expire int64 `yaml:"expire"`
+	} `yaml:"tokenPolicy"`
+	ApiToken string `json:"apiToken"`
+	Empty    string `yaml:"empty" secret:"true"`
+}
+
+func TestIsSensitiveKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"password":         true,
+		"password_salt":    true,
+		"accessKeySecret":  true,
+		"X-Auth-Token":     true,
+		"messageAesKey":    true,
+		"username":         false,
+		"accessKeyId":      false,
+		"`sys_user`.`id`":  false,
+		"remainLogLevel":   false,
+		"`sys_user`.`pwd`": true,
+	} {
+		if got := IsSensitiveKey(key); got != want {
+			t.Errorf("IsSensitiveKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	c := redactTestConfig{Secret: "jwt-secret", AppKey: "k", ApiToken: "t"}
+	c.Mysql.Username = "root"
+	c.Mysql.Password = "123456"
+	c.TokenPolicy.Expire = 3600
+
+	got := Redact(&c).(map[string]interface{})
+	want := map[string]interface{}{
+		"mysql":       map[string]interface{}{"username": "root", "password": Masked},
+		"secret":      Masked,
+		"appKey":      Masked,
+		"tokenPolicy": map[string]interface{}{"expire": int64(3600)},
+		"apiToken":    Masked,
+		"empty":       "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Redact() = %v, want %v", got, want)
+	}
+	if c.Mysql.Password != "123456" {
+		t.Errorf("Redact() modified the original value")
+	}
+}
+
+func TestRedactEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := newRedactEncoder(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()))
+	core := zapcore.NewCore(enc, zapcore.AddSync(&buf), zapcore.DebugLevel)
+	l := &ZapLogger{zap: zap.New(core).Sugar().With("token", "abc")}
+
+	c := redactTestConfig{Secret: "jwt-secret"}
+	c.Mysql.Password = "123456"
+	l.Info(context.Background(), "load config", "config", c, "password", "plain", "username", "admin")
+
+	out := buf.String()
+	for _, leaked := range []string{"jwt-secret", "123456", "plain", "abc"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("log output leaks %q: %s", leaked, out)
+		}
+	}
+	if !strings.Contains(out, `"username":"admin"`) {
+		t.Errorf("log output lost normal field: %s", out)
+	}
+}
+
+func TestSqlParamsFilter(t *testing.T) {
+	tests := []struct {
+		sql    string
+		params []interface{}
+		want   []interface{}
+	}{
+		{
+			sql:    "SELECT * FROM `sys_user` WHERE username = ? AND `sys_user`.`password_salt` = ? LIMIT 1",
+			params: []interface{}{"admin", "md5"},
+			want:   []interface{}{"admin", Masked},
+		},
+		{
+			sql:    "INSERT INTO `sys_user` (`username`,`password_salt`,`status`) VALUES (?,?,?),(?,?,?)",
+			params: []interface{}{"a", "x", 1, "b", "y", 1},
+			want:   []interface{}{"a", Masked, 1, "b", Masked, 1},
+		},
+		{
+			sql:    "UPDATE `sys_user` SET `password_salt`=?,`updated_at`=? WHERE `id` IN (?,?)",
+			params: []interface{}{"x", "now", 1, 2},
+			want:   []interface{}{Masked, "now", 1, 2},
+		},
+		{
+			sql:    "SELECT * FROM `sys_config` WHERE name = '?' AND token IN (?,?)",
+			params: []interface{}{"t1", "t2"},
+			want:   []interface{}{Masked, Masked},
+		},
+	}
+	for _, tt := range tests {
+		_, got := SqlLogger{}.ParamsFilter(context.Background(), tt.sql, tt.params...)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParamsFilter(%q) = %v, want %v", tt.sql, got, tt.want)
+		}
+	}
+}
@@ -3,14 +3,23 @@ package log
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"go.uber.org/zap/zapcore"
 	"gorm.io/gorm"
 	gormLogger "gorm.io/gorm/logger"
 	gormUtils "gorm.io/gorm/utils"
 )
 
+var (
+	insertColumnsRegexp     = regexp.MustCompile("(?is)^\\s*(?:INSERT|REPLACE)\\s+(?:IGNORE\\s+)?INTO\\s+\\S+\\s*\\(([^)]*)\\)\\s*VALUES")
+	onDuplicateRegexp       = regexp.MustCompile("(?i)ON\\s+DUPLICATE\\s+KEY\\s+UPDATE|ON\\s+CONFLICT")
+	placeholderColumnRegexp = regexp.MustCompile("(?i)([`\"\\w.]+)\\s*(?:=|<>|!=|<=|>=|<|>|\\s(?:NOT\\s+)?LIKE|\\s(?:NOT\\s+)?IN\\s*\\(?)\\s*$")
+)
+
 type SqlLogger struct {
 	LogLevel                  gormLogger.LogLevel
 	IgnoreRecordNotFoundError bool
@@ -32,15 +41,85 @@ func (l *SqlLogger) LogMode(logLevel gormLogger.LogLevel) gormLogger.Interface {
 }
 
 func (SqlLogger) Info(ctx context.Context, msg string, args ...interface{}) {
-	ZInfo(ctx, msg, args)
+	Sql.Info(ctx, msg, args)
 }
 
 func (SqlLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
-	ZWarn(ctx, msg, nil, args)
+	Sql.Warn(ctx, msg, nil, args)
 }
 
 func (SqlLogger) Error(ctx context.Context, msg string, args ...interface{}) {
-	ZError(ctx, msg, nil, args)
+	Sql.Error(ctx, msg, nil, args)
+}
+
+// ParamsFilter 实现 gorm.ParamsFilter，打印sql前把敏感列（如 password_salt）绑定的参数替换为 Masked
+func (SqlLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	var masked []interface{}
+	for i, column := range placeholderColumns(sql, len(params)) {
+		if column == "" || !IsSensitiveKey(column) {
+			continue
+		}
+		if masked == nil {
+			masked = append([]interface{}(nil), params...)
+		}
+		masked[i] = Masked
+	}
+	if masked == nil {
+		return sql, params
+	}
+	return sql, masked
+}
+
+// placeholderColumns 按顺序估算每个 ? 占位符对应的列名，无法识别的为空
+func placeholderColumns(sql string, n int) []string {
+	columns := make([]string, 0, n)
+	var insertColumns []string
+	valuesStart, valuesEnd := -1, len(sql)
+	if m := insertColumnsRegexp.FindStringSubmatchIndex(sql); m != nil {
+		for _, column := range strings.Split(sql[m[2]:m[3]], ",") {
+			insertColumns = append(insertColumns, strings.TrimSpace(column))
+		}
+		valuesStart = m[1]
+		if loc := onDuplicateRegexp.FindStringIndex(sql[valuesStart:]); loc != nil {
+			valuesEnd = valuesStart + loc[0]
+		}
+	}
+	last, segStart, insertIndex := "", 0, 0
+	var quote byte
+	for i := 0; i < len(sql) && len(columns) < n; i++ {
+		c := sql[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'':
+			quote = c
+		case '?':
+			column := ""
+			prefix := sql[segStart:i]
+			if len(insertColumns) > 0 && i > valuesStart && i < valuesEnd {
+				column = insertColumns[insertIndex%len(insertColumns)]
+				insertIndex++
+			} else if m := placeholderColumnRegexp.FindStringSubmatch(prefix); m != nil {
+				column = m[1]
+			} else if strings.HasSuffix(strings.TrimSpace(prefix), ",") {
+				column = last
+			}
+			if idx := strings.LastIndex(column, "."); idx >= 0 {
+				column = column[idx+1:]
+			}
+			columns = append(columns, column)
+			last = column
+			segStart = i + 1
+		}
+	}
+	for len(columns) < n {
+		columns = append(columns, "")
+	}
+	return columns
 }
 
 func (l *SqlLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
@@ -52,24 +131,24 @@ func (l *SqlLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql s
 	case err != nil && l.LogLevel >= gormLogger.Error && (!errors.Is(err, gorm.ErrRecordNotFound) || !l.IgnoreRecordNotFoundError):
 		sql, rows := fc()
 		if rows == -1 {
-			ZError(ctx, "sql exec detail", err, "gorm", gormUtils.FileWithLineNum(), "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "sql", sql)
+			Sql.Error(ctx, "sql exec detail", err, "gorm", gormUtils.FileWithLineNum(), "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "sql", sql)
 		} else {
-			ZError(ctx, "sql exec detail", err, "gorm", gormUtils.FileWithLineNum(), "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "rows", rows, "sql", sql)
+			Sql.Error(ctx, "sql exec detail", err, "gorm", gormUtils.FileWithLineNum(), "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "rows", rows, "sql", sql)
 		}
 	case elapsed > l.SlowThreshold && l.SlowThreshold != 0 && l.LogLevel >= gormLogger.Warn:
 		sql, rows := fc()
 		slowLog := fmt.Sprintf("SLOW SQL >= %v", l.SlowThreshold)
 		if rows == -1 {
-			ZWarn(ctx, "sql exec detail", nil, "gorm", gormUtils.FileWithLineNum(), "slow sql", slowLog, "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "sql", sql)
+			Sql.Warn(ctx, "sql exec detail", nil, "gorm", gormUtils.FileWithLineNum(), "slow sql", slowLog, "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "sql", sql)
 		} else {
-			ZWarn(ctx, "sql exec detail", nil, "gorm", gormUtils.FileWithLineNum(), "slow sql", slowLog, "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "rows", rows, "sql", sql)
+			Sql.Warn(ctx, "sql exec detail", nil, "gorm", gormUtils.FileWithLineNum(), "slow sql", slowLog, "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "rows", rows, "sql", sql)
 		}
-	case l.LogLevel == gormLogger.Info:
+	case l.LogLevel == gormLogger.Info || Sql.Enabled(zapcore.DebugLevel):
 		sql, rows := fc()
 		if rows == -1 {
-			ZDebug(ctx, "sql exec detail", "gorm", gormUtils.FileWithLineNum(), "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "sql", sql)
+			Sql.Debug(ctx, "sql exec detail", "gorm", gormUtils.FileWithLineNum(), "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "sql", sql)
 		} else {
-			ZDebug(ctx, "sql exec detail", "gorm", gormUtils.FileWithLineNum(), "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "rows", rows, "sql", sql)
+			Sql.Debug(ctx, "sql exec detail", "gorm", gormUtils.FileWithLineNum(), "elapsed time", fmt.Sprintf("%f(ms)", float64(elapsed.Nanoseconds())/1e6), "rows", rows, "sql", sql)
 		}
 	}
 }
@@ -6,11 +6,13 @@ import (
 	"orderin-server/pkg/common/constant"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	rotatelogs "github.com/lestrrat-go/file-rotatelogs"
 	mcontext "orderin-server/pkg/common/context"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -43,7 +45,9 @@ func InitFromConfig(
 	if err != nil {
 		return err
 	}
-	pkgLogger = l.WithCallDepth(2)
+	initLevels(logLevelMap[logLevel])
+	namedLoggers = sync.Map{}
+	pkgLogger = l.withLevel(atomicLevel(ModuleRoot)).WithCallDepth(2)
 	if isJson {
 		pkgLogger = pkgLogger.WithName(moduleName)
 	}
@@ -80,7 +84,8 @@ func ZError(ctx context.Context, msg string, err error, keysAndValues ...interfa
 
 type ZapLogger struct {
 	zap              *zap.SugaredLogger
-	level            zapcore.Level
+	level            zap.AtomicLevel
+	outputs          []output
 	loggerName       string
 	loggerPrefixName string
 	rotationTime     time.Duration
@@ -95,8 +100,9 @@ func NewZapLogger(
 	rotateCount uint,
 	rotationTime uint,
 ) (*ZapLogger, error) {
+	level := zap.NewAtomicLevelAt(logLevelMap[logLevel])
 	zapConfig := zap.Config{
-		Level: zap.NewAtomicLevelAt(logLevelMap[logLevel]),
+		Level: level,
 		// EncoderConfig: zap.NewProductionEncoderConfig(),
 		// InitialFields:     map[string]interface{}{"PID": os.Getegid()},
 		DisableStacktrace: true,
@@ -109,7 +115,7 @@ func NewZapLogger(
 	// if isStdout {
 	// 	zapConfig.OutputPaths = append(zapConfig.OutputPaths, "stdout", "stderr")
 	// }
-	zl := &ZapLogger{level: logLevelMap[logLevel], loggerName: loggerName, loggerPrefixName: loggerPrefixName, rotationTime: time.Duration(rotationTime) * time.Hour}
+	zl := &ZapLogger{level: level, loggerName: loggerName, loggerPrefixName: loggerPrefixName, rotationTime: time.Duration(rotationTime) * time.Hour}
 	opts, err := zl.cores(isStdout, isJson, logLocation, rotateCount)
 	if err != nil {
 		return nil, err
@@ -141,28 +147,50 @@ func (l *ZapLogger) cores(isStdout bool, isJson bool, logLocation string, rotate
 		c.EncodeCaller = l.customCallerEncoder
 		fileEncoder = zapcore.NewConsoleEncoder(c)
 	}
+	fileEncoder = newRedactEncoder(fileEncoder)
 	writer, err := l.getWriter(logLocation, rotateCount)
 	if err != nil {
 		return nil, err
 	}
-	var cores []zapcore.Core
 	// if logLocation == "" && !isStdout {
 	// 	return nil, errors.New("log storage location is empty and not stdout")
 	// }
 	if logLocation != "" {
-		cores = []zapcore.Core{
-			zapcore.NewCore(fileEncoder, writer, zap.NewAtomicLevelAt(l.level)),
-		}
+		l.outputs = []output{{fileEncoder, writer}}
 	}
 	if isStdout {
-		cores = append(cores, zapcore.NewCore(fileEncoder, zapcore.Lock(os.Stdout), zap.NewAtomicLevelAt(l.level)))
-		// cores = append(cores, zapcore.NewCore(fileEncoder, zapcore.Lock(os.Stderr), zap.NewAtomicLevelAt(l.level)))
+		l.outputs = append(l.outputs, output{fileEncoder, zapcore.Lock(os.Stdout)})
+		// l.outputs = append(l.outputs, output{fileEncoder, zapcore.Lock(os.Stderr)})
 	}
 	return zap.WrapCore(func(c zapcore.Core) zapcore.Core {
-		return zapcore.NewTee(cores...)
+		return l.newCore(l.level)
 	}), nil
 }
 
+// output 日志的编码和输出位置，各logger共用，按各自的级别构建 core
+type output struct {
+	encoder zapcore.Encoder
+	writer  zapcore.WriteSyncer
+}
+
+func (l *ZapLogger) newCore(level zapcore.LevelEnabler) zapcore.Core {
+	cores := make([]zapcore.Core, 0, len(l.outputs))
+	for _, o := range l.outputs {
+		cores = append(cores, zapcore.NewCore(o.encoder, o.writer, level))
+	}
+	return zapcore.NewTee(cores...)
+}
+
+// withLevel 返回使用 level 的logger，修改 level 即修改该logger的级别。会丢弃 WithValues 添加的字段
+func (l *ZapLogger) withLevel(level zap.AtomicLevel) *ZapLogger {
+	dup := *l
+	dup.level = level
+	dup.zap = l.zap.WithOptions(zap.WrapCore(func(zapcore.Core) zapcore.Core {
+		return dup.newCore(level)
+	}))
+	return &dup
+}
+
 func (l *ZapLogger) customCallerEncoder(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
 	s := "[" + caller.TrimmedPath() + "]"
 	// color, ok := _levelToColor[l.level]
@@ -249,6 +277,9 @@ func (l *ZapLogger) kvAppend(ctx context.Context, keysAndValues []interface{}) [
 	if requestId != "" {
 		keysAndValues = append([]interface{}{constant.RequestId, requestId}, keysAndValues...)
 	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		keysAndValues = append([]interface{}{constant.TraceId, spanContext.TraceID().String()}, keysAndValues...)
+	}
 	if opUserID != 0 {
 		keysAndValues = append([]interface{}{constant.OpUserID, opUserID}, keysAndValues...)
 	}
@@ -0,0 +1,63 @@
+package network
+
+import (
+	"context"
+	"github.com/oschwald/geoip2-golang"
+	"net"
+	"orderin-server/pkg/common/log"
+	"strings"
+)
+
+const LocationIntranet = "内网IP"
+
+var geoDB *geoip2.Reader
+
+// InitGeoDB 加载离线IP库(MaxMind GeoLite2-City格式)，未配置时不解析地理位置
+func InitGeoDB(path string) {
+	if path == "" {
+		return
+	}
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		log.ZError(context.Background(), "open ip database fail", err, "path", path)
+		return
+	}
+	geoDB = reader
+}
+
+// LookupLocation 根据ip获取 国家 省份 城市
+func LookupLocation(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	if parsed.IsPrivate() || parsed.IsLoopback() {
+		return LocationIntranet
+	}
+	if geoDB == nil {
+		return ""
+	}
+	record, err := geoDB.City(parsed)
+	if err != nil {
+		return ""
+	}
+	names := []string{localizedName(record.Country.Names)}
+	if len(record.Subdivisions) > 0 {
+		names = append(names, localizedName(record.Subdivisions[0].Names))
+	}
+	names = append(names, localizedName(record.City.Names))
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		if name != "" {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func localizedName(names map[string]string) string {
+	if name, ok := names["zh-CN"]; ok {
+		return name
+	}
+	return names["en"]
+}
@@ -0,0 +1,48 @@
+package network
+
+import (
+	"github.com/mssola/useragent"
+	"strings"
+)
+
+const (
+	DeviceMobile  = "Mobile"
+	DeviceDesktop = "Desktop"
+	DeviceBot     = "Bot"
+	Unknown       = "Unknown"
+)
+
+type UserAgentInfo struct {
+	Device  string
+	Os      string
+	Browser string
+}
+
+// ParseUserAgent 解析User-Agent，得到设备类型、操作系统和浏览器
+func ParseUserAgent(ua string) UserAgentInfo {
+	if strings.TrimSpace(ua) == "" {
+		return UserAgentInfo{Device: Unknown, Os: Unknown, Browser: Unknown}
+	}
+	parsed := useragent.New(ua)
+	info := UserAgentInfo{Device: DeviceDesktop, Os: parsed.OS(), Browser: Unknown}
+	if parsed.Bot() {
+		info.Device = DeviceBot
+	} else if parsed.Mobile() {
+		info.Device = DeviceMobile
+	}
+	if name, version := parsed.Browser(); name != "" {
+		info.Browser = strings.TrimSpace(name + " " + majorVersion(version))
+	}
+	if info.Os == "" {
+		info.Os = Unknown
+	}
+	return info
+}
+
+// majorVersion 只保留主版本号，避免浏览器小版本升级被识别为新设备
+func majorVersion(version string) string {
+	if i := strings.Index(version, "."); i > 0 {
+		return version[:i]
+	}
+	return version
+}
@@ -0,0 +1,29 @@
+package network
+
+import "testing"
+
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		ua   string
+		want UserAgentInfo
+	}{
+		{
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			UserAgentInfo{Device: DeviceDesktop, Os: "Windows 10", Browser: "Chrome 120"},
+		},
+		{"", UserAgentInfo{Device: Unknown, Os: Unknown, Browser: Unknown}},
+	}
+	for _, tt := range tests {
+		if got := ParseUserAgent(tt.ua); got != tt.want {
+			t.Errorf("ParseUserAgent(%s) = %+v, want %+v", tt.ua, got, tt.want)
+		}
+	}
+}
+
+func TestLookupLocationIntranet(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "192.168.1.10", "10.0.0.1"} {
+		if got := LookupLocation(ip); got != LocationIntranet {
+			t.Errorf("LookupLocation(%s) = %s, want %s", ip, got, LocationIntranet)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package prommetrics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"net/http"
+	"orderin-server/pkg/common/log"
+)
+
+const (
+	namespace = "orderin"
+
+	ResultSuccess = "success"
+	ResultFail    = "fail"
+	// RouteUnmatched 未匹配到路由的请求统一归为一类，避免label基数失控
+	RouteUnmatched = "unmatched"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	HttpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP请求数",
+	}, []string{"method", "route", "status"})
+	HttpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP请求耗时",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method", "route"})
+	HttpBizErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_biz_errors_total",
+		Help:      "返回业务错误码的请求数",
+	}, []string{"method", "route"})
+	SentinelBlockedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sentinel_blocked_total",
+		Help:      "被sentinel限流的请求数",
+	}, []string{"route"})
+	LoginTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_total",
+		Help:      "登录次数",
+	}, []string{"method", "result"})
+	SmsSendTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sms_send_total",
+		Help:      "短信发送次数",
+	}, []string{"vendor", "result"})
+	EmailSendTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "email_send_total",
+		Help:      "邮件发送次数",
+	}, []string{"result"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HttpRequestsTotal,
+		HttpRequestDuration,
+		HttpBizErrorsTotal,
+		SentinelBlockedTotal,
+		LoginTotal,
+		SmsSendTotal,
+		EmailSendTotal,
+	)
+}
+
+// Serve 注册连接池采集器并在后台启动metrics服务
+func Serve(port int, db *gorm.DB, rdb redis.UniversalClient) {
+	if sqlDB, err := db.DB(); err == nil {
+		if err = RegisterDB(sqlDB, db.Dialector.Name()); err != nil {
+			log.ZWarn(context.Background(), "register db stats collector fail", err)
+		}
+	}
+	if err := RegisterRedis(rdb); err != nil {
+		log.ZWarn(context.Background(), "register redis pool collector fail", err)
+	}
+	go func() {
+		if err := Start(port); err != nil {
+			log.ZError(context.Background(), "prometheus metrics server exit", err, "port", port)
+		}
+	}()
+}
+
+// Start 在指定端口暴露/metrics，阻塞直到服务退出
+func Start(port int) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+	address := fmt.Sprintf(":%d", port)
+	log.ZInfo(context.Background(), "start prometheus metrics server", "address", address)
+	return http.ListenAndServe(address, mux)
+}
+
+// RegisterDB 采集数据库连接池状态
+func RegisterDB(db *sql.DB, dbName string) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// RegisterRedis 采集redis连接池状态
+func RegisterRedis(rdb redis.UniversalClient) error {
+	return registry.Register(newRedisPoolCollector(rdb))
+}
+
+func Result(success bool) string {
+	if success {
+		return ResultSuccess
+	}
+	return ResultFail
+}
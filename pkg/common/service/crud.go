@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	mcontext "orderin-server/pkg/common/context"
+	"orderin-server/pkg/common/db/relation"
+	"orderin-server/pkg/common/errs"
+	"orderin-server/pkg/common/log"
+)
+
+// CRUD 操作，传给权限检查钩子
+const (
+	OpQuery  = "query"
+	OpGet    = "get"
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// CrudHooks CrudService 的钩子，不需要的可以为空
+type CrudHooks[M any] struct {
+	// CheckPermission 操作前检查权限，分页查询时 m 为空，新增时为要新增的记录，其他操作为数据库中的原记录
+	CheckPermission func(ctx context.Context, op string, m *M) error
+	// Validate 保存前校验，与保存在同一个事务中，create 表示新增
+	Validate func(tx *gorm.DB, m *M, create bool) error
+	// BeforeSave 校验通过后、保存前调用
+	BeforeSave func(tx *gorm.DB, m *M, create bool) error
+	// AfterSave 保存后调用，返回错误时回滚
+	AfterSave func(tx *gorm.DB, m *M, create bool) error
+}
+
+// pager 分页查询条件，嵌入 dto.Pagination 的查询条件都实现了该接口
+type pager interface {
+	GetPageIndex() int
+	GetPageSize() int
+}
+
+// CrudService 通用的增删改查，M 为模型，Q 为带 search 标签的分页查询条件，主键为 int64。
+// CreatedBy/UpdatedBy 由 relation 注册的回调按上下文中的操作人填充。
+// 模型有 deleted 字段（嵌入 LogicalDeleted）时删除只做标记，查询时排除已删除的记录
+type CrudService[M any, Q any] struct {
+	Service
+	Hooks CrudHooks[M]
+}
+
+func (e CrudService[M, Q]) checkPermission(op string, m *M) error {
+	if e.Hooks.CheckPermission == nil {
+		return nil
+	}
+	return e.Hooks.CheckPermission(e.Context, op, m)
+}
+
+// PageQuery 按查询条件分页查询，Q 没有实现 GetPageIndex/GetPageSize 时查询全部
+func (e CrudService[M, Q]) PageQuery(req *Q, list *[]M, count *int64) error {
+	if err := e.checkPermission(OpQuery, nil); err != nil {
+		return err
+	}
+	db := e.Orm.Scopes(relation.MakeCondition(*req))
+	if e.logicalDeleted() {
+		db = db.Scopes(relation.NotDeleted())
+	}
+	if p, ok := any(req).(pager); ok {
+		db = db.Scopes(relation.Paginate(p.GetPageSize(), p.GetPageIndex()))
+	}
+	err := db.Find(list).Limit(-1).Offset(-1).Count(count).Error
+	if err != nil {
+		log.ZError(e.Context, "page query fail", err, "model", fmt.Sprintf("%T", *new(M)))
+		return err
+	}
+	return nil
+}
+
+// Get 根据主键查询，不存在时返回 errs.RecordNotFoundError
+func (e CrudService[M, Q]) Get(id int64) (*M, error) {
+	m, err := e.first(id)
+	if err != nil {
+		return nil, err
+	}
+	if err = e.checkPermission(OpGet, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (e CrudService[M, Q]) first(id int64) (*M, error) {
+	m := new(M)
+	db := e.Orm
+	if e.logicalDeleted() {
+		db = db.Scopes(relation.NotDeleted())
+	}
+	err := db.First(m, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errs.NewCodeError(errs.RecordNotFoundError, "记录不存在")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Insert 新增，校验、保存和钩子在同一个事务中
+func (e CrudService[M, Q]) Insert(m *M) (err error) {
+	if err = e.checkPermission(OpCreate, m); err != nil {
+		return err
+	}
+	tx := e.Orm.Begin()
+	defer e.EndTx(tx, &err)
+
+	return e.save(tx, m, true, func() error {
+		return tx.Create(m).Error
+	})
+}
+
+// UpdateSelectiveById 根据主键更新 m 中的非零值字段，设置了权限钩子时先查询原记录用于检查
+func (e CrudService[M, Q]) UpdateSelectiveById(id int64, m *M) (err error) {
+	if e.Hooks.CheckPermission != nil {
+		old, err := e.first(id)
+		if err != nil {
+			return err
+		}
+		if err = e.checkPermission(OpUpdate, old); err != nil {
+			return err
+		}
+	}
+	if err = e.setPrimaryKey(m, id); err != nil {
+		return err
+	}
+	tx := e.Orm.Begin()
+	defer e.EndTx(tx, &err)
+
+	return e.save(tx, m, false, func() error {
+		return tx.Model(m).Updates(m).Error
+	})
+}
+
+// Delete 根据主键删除，逻辑删除的模型只标记删除，设置了权限钩子时先查询原记录用于检查
+func (e CrudService[M, Q]) Delete(id int64) error {
+	if e.Hooks.CheckPermission != nil {
+		old, err := e.first(id)
+		if err != nil {
+			return err
+		}
+		if err = e.checkPermission(OpDelete, old); err != nil {
+			return err
+		}
+	}
+	if !e.logicalDeleted() {
+		return e.Orm.Delete(new(M), id).Error
+	}
+	m := new(M)
+	if err := e.setPrimaryKey(m, id); err != nil {
+		return err
+	}
+	values := map[string]interface{}{"deleted": true, "deleted_at": time.Now()}
+	if userID := mcontext.GetOpUserID(e.Context); userID != 0 {
+		values["deleted_by"] = userID
+	}
+	return e.Orm.Model(m).Updates(values).Error
+}
+
+// logicalDeleted 模型是否有 deleted 字段
+func (e CrudService[M, Q]) logicalDeleted() bool {
+	stmt := &gorm.Statement{DB: e.Orm}
+	if err := stmt.Parse(new(M)); err != nil {
+		return false
+	}
+	return stmt.Schema.LookUpField("deleted") != nil
+}
+
+func (e CrudService[M, Q]) save(tx *gorm.DB, m *M, create bool, save func() error) error {
+	for _, hook := range []func(tx *gorm.DB, m *M, create bool) error{e.Hooks.Validate, e.Hooks.BeforeSave} {
+		if hook == nil {
+			continue
+		}
+		if err := hook(tx, m, create); err != nil {
+			return err
+		}
+	}
+	if err := save(); err != nil {
+		return err
+	}
+	if e.Hooks.AfterSave != nil {
+		return e.Hooks.AfterSave(tx, m, create)
+	}
+	return nil
+}
+
+// setPrimaryKey 给泛型模型设置主键
+func (e CrudService[M, Q]) setPrimaryKey(m *M, id int64) error {
+	stmt := &gorm.Statement{DB: e.Orm}
+	if err := stmt.Parse(m); err != nil {
+		return err
+	}
+	field := stmt.Schema.PrioritizedPrimaryField
+	if field == nil {
+		return fmt.Errorf("%s has no primary key", stmt.Schema.Name)
+	}
+	return field.Set(e.Context, reflect.ValueOf(m).Elem(), id)
+}
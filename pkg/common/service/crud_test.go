@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"orderin-server/pkg/common/constant"
+	"orderin-server/pkg/common/dto"
+	"orderin-server/pkg/common/errs"
+)
+
+type crudTestItem struct {
+	ID    int64 `gorm:"primaryKey"`
+	Name  string
+	Owner int64
+}
+
+type crudTestQuery struct {
+	dto.Pagination `search:"-"`
+	Name           string `search:"type:contains;column:name;table:crud_test_items"`
+}
+
+func TestCrudService(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/test.db"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&crudTestItem{}); err != nil {
+		t.Fatal(err)
+	}
+	var calls []string
+	errDenied := errors.New("denied")
+	errInvalid := errors.New("invalid")
+	s := CrudService[crudTestItem, crudTestQuery]{Service: Service{Orm: db, Context: context.Background()}}
+	s.Hooks = CrudHooks[crudTestItem]{
+		CheckPermission: func(ctx context.Context, op string, m *crudTestItem) error {
+			calls = append(calls, op)
+			if m != nil && m.Owner != 1 {
+				return errDenied
+			}
+			return nil
+		},
+		Validate: func(tx *gorm.DB, m *crudTestItem, create bool) error {
+			calls = append(calls, "validate")
+			if m.Name == "" {
+				return errInvalid
+			}
+			return nil
+		},
+		BeforeSave: func(tx *gorm.DB, m *crudTestItem, create bool) error {
+			calls = append(calls, "before")
+			return nil
+		},
+		AfterSave: func(tx *gorm.DB, m *crudTestItem, create bool) error {
+			calls = append(calls, "after")
+			if m.Name == "rollback" {
+				return errInvalid
+			}
+			return nil
+		},
+	}
+
+	if err = s.Insert(&crudTestItem{ID: 1, Name: "apple", Owner: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"create", "validate", "before", "after"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if err = s.Insert(&crudTestItem{ID: 2, Owner: 1}); !errors.Is(err, errInvalid) {
+		t.Errorf("insert without name = %v, want invalid", err)
+	}
+	if err = s.Insert(&crudTestItem{ID: 3, Name: "rollback", Owner: 1}); !errors.Is(err, errInvalid) {
+		t.Errorf("insert rollback = %v, want invalid", err)
+	}
+	if err = s.Insert(&crudTestItem{ID: 4, Name: "banana", Owner: 2}); !errors.Is(err, errDenied) {
+		t.Errorf("insert denied = %v, want denied", err)
+	}
+	db.Create(&crudTestItem{ID: 5, Name: "cherry", Owner: 2})
+
+	if err = s.UpdateSelectiveById(1, &crudTestItem{Name: "apricot"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.UpdateSelectiveById(5, &crudTestItem{Name: "coconut"}); !errors.Is(err, errDenied) {
+		t.Errorf("update denied = %v, want denied", err)
+	}
+	if m, err := s.Get(1); err != nil || m.Name != "apricot" {
+		t.Errorf("get = %v, %v", m, err)
+	}
+	if _, err = s.Get(99); errs.ErrCode(err) == nil || errs.ErrCode(err).Code() != errs.RecordNotFoundError {
+		t.Errorf("get missing = %v, want record not found", err)
+	}
+	if _, err = s.Get(5); !errors.Is(err, errDenied) {
+		t.Errorf("get denied = %v, want denied", err)
+	}
+
+	var list []crudTestItem
+	var count int64
+	q := crudTestQuery{Pagination: dto.Pagination{PageIndex: 1, PageSize: 1}, Name: "c"}
+	if err = s.PageQuery(&q, &list, &count); err != nil || count != 2 || len(list) != 1 {
+		t.Errorf("page query = %v, %d, %v", list, count, err)
+	}
+
+	if err = s.Delete(5); !errors.Is(err, errDenied) {
+		t.Errorf("delete denied = %v, want denied", err)
+	}
+	if err = s.Delete(1); err != nil {
+		t.Fatal(err)
+	}
+	var ids []int64
+	db.Model(&crudTestItem{}).Order("id").Pluck("id", &ids)
+	if want := []int64{5}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+}
+
+type crudTestDeletable struct {
+	ID        int64 `gorm:"primaryKey"`
+	Name      string
+	Deleted   bool
+	DeletedBy *int64
+	DeletedAt *time.Time
+}
+
+type crudTestDeletableQuery struct {
+	dto.Pagination `search:"-"`
+}
+
+func TestCrudServiceLogicalDelete(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/test.db"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AutoMigrate(&crudTestDeletable{}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.WithValue(context.Background(), constant.OpUserID, int64(9))
+	s := CrudService[crudTestDeletable, crudTestDeletableQuery]{Service: Service{Orm: db, Context: ctx}}
+	db.Create(&[]crudTestDeletable{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}})
+
+	if err = s.Delete(1); err != nil {
+		t.Fatal(err)
+	}
+	var deleted crudTestDeletable
+	if err = db.First(&deleted, 1).Error; err != nil {
+		t.Fatalf("logically deleted row is gone: %v", err)
+	}
+	if !deleted.Deleted || deleted.DeletedBy == nil || *deleted.DeletedBy != 9 || deleted.DeletedAt == nil {
+		t.Errorf("deleted row = %+v", deleted)
+	}
+	if _, err = s.Get(1); errs.ErrCode(err) == nil || errs.ErrCode(err).Code() != errs.RecordNotFoundError {
+		t.Errorf("get deleted = %v, want record not found", err)
+	}
+	var list []crudTestDeletable
+	var count int64
+	q := crudTestDeletableQuery{Pagination: dto.Pagination{PageIndex: 1, PageSize: 10}}
+	if err = s.PageQuery(&q, &list, &count); err != nil || count != 1 || len(list) != 1 || list[0].ID != 2 {
+		t.Errorf("page query = %v, %d, %v", list, count, err)
+	}
+}
@@ -2,10 +2,33 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"gorm.io/gorm"
+	"orderin-server/pkg/common/log"
 )
 
 type Service struct {
 	Orm     *gorm.DB
 	Context context.Context
 }
+
+// EndTx 根据 panic、tx.Error 和 *err 回滚或提交事务，需直接 defer 调用
+func (e Service) EndTx(tx *gorm.DB, err *error) {
+	if r := recover(); r != nil {
+		// 发生了 panic，回滚事务
+		tx.Rollback()
+		log.ZError(e.Context, "Transaction rolled back", nil, "err", r)
+		*err = fmt.Errorf("transaction panic: %v", r)
+	} else if tx.Error != nil || *err != nil {
+		// 发生了错误，回滚事务
+		tx.Rollback()
+		if *err == nil {
+			*err = tx.Error
+		}
+		log.ZError(e.Context, "Transaction rolled back", *err)
+	} else {
+		// 没有发生错误，提交事务
+		tx.Commit()
+		log.ZInfo(e.Context, "Transaction committed")
+	}
+}
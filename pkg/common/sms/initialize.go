@@ -1,7 +1,11 @@
 package sms
 
 import (
+	"context"
 	"orderin-server/pkg/common/config"
+	"orderin-server/pkg/common/log"
+	"reflect"
+	"sync/atomic"
 )
 
 type SmsVendorConfig struct {
@@ -13,27 +17,60 @@ type SmsVendorConfig struct {
 	AccessKeySecret string
 }
 
-var SmsVendorInstance SmsVendor
+// smsVendorHolder atomic.Value 不能保存 nil 接口，包一层
+type smsVendorHolder struct {
+	vendor SmsVendor
+}
+
+var currentSmsVendor atomic.Value
+
+// GetSmsVendor 返回当前的短信客户端，配置热加载后会替换为新客户端
+func GetSmsVendor() SmsVendor {
+	holder, _ := currentSmsVendor.Load().(smsVendorHolder)
+	return holder.vendor
+}
 
 func InitSmsVendor() {
-	e := SmsVendorConfig{
-		Endpoint:        config.Config.Sms.Endpoint,
-		AccessKeyID:     config.Config.Sms.AccessKeyID,
-		AccessKeySecret: config.Config.Sms.AccessKeySecret}
-	SmsVendorInstance = e.Setup(VendorType(config.Config.Sms.DriverType))
+	e := newSmsVendorConfig(config.Get())
+	currentSmsVendor.Store(smsVendorHolder{vendor: e.Setup(VendorType(config.Get().Sms.DriverType))})
+
+	config.Subscribe("sms", func(old, new *config.Snapshot) {
+		if reflect.DeepEqual(old.Sms, new.Sms) {
+			return
+		}
+		vendor, err := newSmsVendorConfig(new).build(VendorType(new.Sms.DriverType))
+		if err != nil {
+			log.ZError(context.Background(), "rebuild sms vendor failed, keep the old one", err)
+			return
+		}
+		currentSmsVendor.Store(smsVendorHolder{vendor: vendor})
+		log.ZInfo(context.Background(), "sms vendor rebuilt", "driverType", new.Sms.DriverType)
+	})
+}
+
+func newSmsVendorConfig(c *config.Snapshot) *SmsVendorConfig {
+	return &SmsVendorConfig{
+		Endpoint:        c.Sms.Endpoint,
+		AccessKeyID:     c.Sms.AccessKeyID,
+		AccessKeySecret: c.Sms.AccessKeySecret}
 }
 
 // Setup 配置文件存储driver
 func (e *SmsVendorConfig) Setup(vendorType VendorType) SmsVendor {
+	smsVendor, err := e.build(vendorType)
+	if err != nil {
+		panic(err)
+	}
+	return smsVendor
+}
+
+func (e *SmsVendorConfig) build(vendorType VendorType) (SmsVendor, error) {
 	var smsVendor SmsVendor
 	switch vendorType {
 	case AliYunDysms:
 		smsVendor = new(AliyunDysms)
-		err := smsVendor.Setup(e.Endpoint, e.AccessKeyID, e.AccessKeySecret)
-		if err != nil {
-			panic(err)
-		}
-		return smsVendor
+	default:
+		return nil, nil
 	}
-	return nil
+	return smsVendor, smsVendor.Setup(e.Endpoint, e.AccessKeyID, e.AccessKeySecret)
 }
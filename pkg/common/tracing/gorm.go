@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// GormPlugin 为每条sql创建一个span
+type GormPlugin struct{}
+
+func (p GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	var err error
+	register := func(e error) {
+		if err == nil {
+			err = e
+		}
+	}
+	register(callback.Create().Before("gorm:create").Register("tracing:before_create", p.before("gorm.Create")))
+	register(callback.Create().After("gorm:create").Register("tracing:after_create", p.after))
+	register(callback.Query().Before("gorm:query").Register("tracing:before_query", p.before("gorm.Query")))
+	register(callback.Query().After("gorm:query").Register("tracing:after_query", p.after))
+	register(callback.Update().Before("gorm:update").Register("tracing:before_update", p.before("gorm.Update")))
+	register(callback.Update().After("gorm:update").Register("tracing:after_update", p.after))
+	register(callback.Delete().Before("gorm:delete").Register("tracing:before_delete", p.before("gorm.Delete")))
+	register(callback.Delete().After("gorm:delete").Register("tracing:after_delete", p.after))
+	register(callback.Row().Before("gorm:row").Register("tracing:before_row", p.before("gorm.Row")))
+	register(callback.Row().After("gorm:row").Register("tracing:after_row", p.after))
+	register(callback.Raw().Before("gorm:raw").Register("tracing:before_raw", p.before("gorm.Raw")))
+	register(callback.Raw().After("gorm:raw").Register("tracing:after_raw", p.after))
+	return err
+}
+
+func (p GormPlugin) before(spanName string) func(tx *gorm.DB) {
+	return func(tx *gorm.DB) {
+		if tx.Statement.Context == nil {
+			return
+		}
+		ctx, span := Tracer().Start(tx.Statement.Context, spanName, trace.WithSpanKind(trace.SpanKindClient))
+		if !span.IsRecording() {
+			return
+		}
+		tx.Statement.Context = ctx
+		tx.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func (p GormPlugin) after(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemKey.String(tx.Dialector.Name()),
+		semconv.DBStatement(tx.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+	}
+	if tx.Statement.Table != "" {
+		attrs = append(attrs, semconv.DBSQLTable(tx.Statement.Table))
+	}
+	span.SetAttributes(attrs...)
+	err := tx.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	End(span, err)
+}
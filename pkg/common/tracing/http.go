@@ -0,0 +1,28 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"net/http"
+)
+
+// NewTransport 包装base，为每个出站请求创建span并注入traceparent，base为空时使用http.DefaultTransport
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return otelhttp.NewTransport(base)
+}
+
+// NewHTTPClient 返回带追踪的http client
+func NewHTTPClient() *http.Client {
+	return &http.Client{Transport: NewTransport(nil)}
+}
+
+// InstrumentDefaultTransport 替换http.DefaultTransport，
+// 覆盖未暴露http client配置的三方sdk（如微信、七牛）
+func InstrumentDefaultTransport() {
+	if _, ok := http.DefaultTransport.(*otelhttp.Transport); ok {
+		return
+	}
+	http.DefaultTransport = otelhttp.NewTransport(http.DefaultTransport)
+}
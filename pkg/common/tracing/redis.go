@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// RedisHook 为每条redis命令及pipeline创建一个span，只记录命令名，不记录参数
+type RedisHook struct{}
+
+func (h RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := Tracer().Start(ctx, "redis."+cmd.FullName(),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemRedis, semconv.DBOperation(cmd.Name())))
+		err := next(ctx, cmd)
+		End(span, ignoreRedisNil(err))
+		return err
+	}
+}
+
+func (h RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name())
+		}
+		ctx, span := Tracer().Start(ctx, "redis.pipeline",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemRedis,
+				semconv.DBOperation(strings.Join(names, " ")),
+				attribute.Int("db.redis.num_cmd", len(cmds))))
+		err := next(ctx, cmds)
+		End(span, ignoreRedisNil(err))
+		return err
+	}
+}
+
+func ignoreRedisNil(err error) error {
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
+var _ redis.Hook = RedisHook{}
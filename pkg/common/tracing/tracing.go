@@ -0,0 +1,115 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"orderin-server/pkg/common/config"
+)
+
+const (
+	ExporterOff    = "off"
+	ExporterStdout = "stdout"
+	ExporterOtlp   = "otlp"
+
+	instrumentationName = "orderin-server"
+)
+
+var (
+	serviceName    string
+	tracerProvider *sdktrace.TracerProvider
+)
+
+// Init 根据配置初始化全局TracerProvider，exporter为off时只设置propagator，span均为noop
+func Init(name string) error {
+	serviceName = name
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch config.Get().Tracing.Exporter {
+	case "", ExporterOff:
+		return nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOtlp:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Get().Tracing.Endpoint)}
+		if config.Get().Tracing.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), opts...)
+	default:
+		return fmt.Errorf("unsupported tracing exporter: %s", config.Get().Tracing.Exporter)
+	}
+	if err != nil {
+		return err
+	}
+
+	ratio := config.Get().Tracing.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(name),
+			semconv.ServiceVersion(config.Version),
+			semconv.DeploymentEnvironment(config.Get().Env.Profiles),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	return nil
+}
+
+// Shutdown 退出前将缓冲的span全部导出
+func Shutdown(ctx context.Context) error {
+	if tracerProvider == nil {
+		return nil
+	}
+	return tracerProvider.Shutdown(ctx)
+}
+
+func ServiceName() string {
+	return serviceName
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start 开启一个子span，调用方负责End
+func Start(ctx context.Context, spanName string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, spanName, trace.WithAttributes(attrs...))
+}
+
+// End 结束span，err不为空时记录错误
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID 获取当前上下文的trace id，未开启追踪时返回空串
+func TraceID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
@@ -0,0 +1,19 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestTraceID(t *testing.T) {
+	if got := TraceID(context.Background()); got != "" {
+		t.Errorf("TraceID(background) = %s, want empty", got)
+	}
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+	if got, want := TraceID(ctx), span.SpanContext().TraceID().String(); got != want {
+		t.Errorf("TraceID() = %s, want %s", got, want)
+	}
+}
@@ -12,23 +12,23 @@ func NewOpenPlatformAppService() (*openPlatform.OpenPlatform, error) {
 
 	var cache kernel.CacheInterface
 
-	if len(config.Config.Redis.Address) > 0 {
+	if len(config.Get().Redis.Address) > 0 {
 		cache = kernel.NewRedisClient(&kernel.UniversalOptions{
-			Addrs:    config.Config.Redis.Address,
-			Username: config.Config.Redis.Username,
-			Password: config.Config.Redis.Password,
-			DB:       config.Config.Redis.DB,
-			PoolSize: config.Config.Redis.PoolSize,
+			Addrs:    config.Get().Redis.Address,
+			Username: config.Get().Redis.Username,
+			Password: config.Get().Redis.Password,
+			DB:       config.Get().Redis.DB,
+			PoolSize: config.Get().Redis.PoolSize,
 		})
 	}
 
 	app, err := openPlatform.NewOpenPlatform(&openPlatform.UserConfig{
 
-		AppID:  config.Config.WxOpenPlatform.AppID,
-		Secret: config.Config.WxOpenPlatform.AppSecret,
+		AppID:  config.Get().WxOpenPlatform.AppID,
+		Secret: config.Get().WxOpenPlatform.AppSecret,
 
-		Token:  config.Config.WxOpenPlatform.MessageToken,
-		AESKey: config.Config.WxOpenPlatform.MessageAesKey,
+		Token:  config.Get().WxOpenPlatform.MessageToken,
+		AESKey: config.Get().WxOpenPlatform.MessageAesKey,
 
 		Log: openPlatform.Log{
 			Level: "debug",